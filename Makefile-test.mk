@@ -151,6 +151,14 @@ ifndef NO_SCALABILITY_KUEUE_LOGS
 SCALABILITY_EXTRA_ARGS +=  --withLogs=true --logToFile=true
 endif
 
+ifdef SCALABILITY_MAX_ADMISSIONS_PER_CYCLE
+SCALABILITY_EXTRA_ARGS += --maxAdmissionsPerCycle=$(SCALABILITY_MAX_ADMISSIONS_PER_CYCLE)
+endif
+
+ifdef SCALABILITY_FEATURE_GATES
+SCALABILITY_EXTRA_ARGS += --featureGates=$(SCALABILITY_FEATURE_GATES)
+endif
+
 SCALABILITY_SCRAPE_INTERVAL ?= 5s
 ifndef NO_SCALABILITY_SCRAPE
 SCALABILITY_SCRAPE_ARGS +=  --metricsScrapeInterval=$(SCALABILITY_SCRAPE_INTERVAL)
@@ -21,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
 )
 
@@ -81,9 +82,17 @@ type Configuration struct {
 	// FairSharing controls the fair sharing semantics across the cluster.
 	FairSharing *FairSharing `json:"fairSharing,omitempty"`
 
+	// AdmissionFairSharing controls the fair sharing based on the historical
+	// resource consumption of the queues. Requires the AdmissionFairSharing
+	// feature gate.
+	AdmissionFairSharing *AdmissionFairSharing `json:"admissionFairSharing,omitempty"`
+
 	// Resources provides additional configuration options for handling the resources.
 	Resources *Resources `json:"resources,omitempty"`
 
+	// Scheduling configures the scheduler and the plugins extending it.
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+
 	// FeatureGates is a map of feature names to bools that allows to override the
 	// default enablement status of a feature. The map cannot be used in conjunction
 	// with passing the list of features via the command line argument "--feature-gates"
@@ -437,3 +446,124 @@ type FairSharing struct {
 	// The default strategy is ["LessThanOrEqualToFinalShare", "LessThanInitialShare"].
 	PreemptionStrategies []PreemptionStrategy `json:"preemptionStrategies,omitempty"`
 }
+
+type AdmissionFairSharing struct {
+	// usageHalfLifeTime indicates the time after which the consumed resources
+	// of a queue are reduced by half. The consumed resources are accumulated
+	// as resource-seconds of the admitted workloads.
+	// Defaults to 24h.
+	UsageHalfLifeTime *metav1.Duration `json:"usageHalfLifeTime,omitempty"`
+
+	// usageSamplingInterval indicates how often the consumed resources of the
+	// queues are sampled and persisted in their status.
+	// Defaults to 5min.
+	UsageSamplingInterval *metav1.Duration `json:"usageSamplingInterval,omitempty"`
+}
+
+// Scheduling defines the configuration of the scheduler.
+type Scheduling struct {
+	// plugins lists the plugins enabled at each extension point of the
+	// scheduler. The plugins are consulted after the built-in logic, in the
+	// order in which they are listed.
+	Plugins *SchedulingPlugins `json:"plugins,omitempty"`
+
+	// pluginConfig provides the arguments of the plugins. Each plugin can be
+	// configured at most once, and its arguments are shared by all the
+	// extension points it is enabled at.
+	PluginConfig []PluginConfig `json:"pluginConfig,omitempty"`
+
+	// maxAdmissionsPerCycle is the maximum number of workloads admitted from
+	// the same ClusterQueue in a scheduling cycle. Beyond the head, the
+	// workloads are only admitted while they fit the available quota without
+	// preemption, and the ClusterQueues whose head was admitted take turns.
+	// Defaults to 1.
+	MaxAdmissionsPerCycle *int32 `json:"maxAdmissionsPerCycle,omitempty"`
+
+	// trace configures the recording of the decisions taken in the
+	// scheduling cycles: the heads considered, their flavor assignment,
+	// preemption targets and outcome. The recorded cycles are served on the
+	// /debug/kueue/scheduling-trace path of the metrics server, and can be
+	// written to a file.
+	// The recording is disabled when not set.
+	Trace *SchedulingTrace `json:"trace,omitempty"`
+}
+
+// SchedulingTrace defines the recording of the scheduling cycles.
+type SchedulingTrace struct {
+	// samplingPercentage is the percentage of the scheduling cycles which
+	// are recorded, between 1 and 100.
+	// Defaults to 100.
+	SamplingPercentage *int32 `json:"samplingPercentage,omitempty"`
+
+	// bufferSize is the number of the most recently recorded cycles which
+	// are kept in memory.
+	// Defaults to 100.
+	BufferSize *int32 `json:"bufferSize,omitempty"`
+
+	// file configures writing the recorded cycles to a file, as JSON lines.
+	// The cycles are only kept in memory when not set.
+	File *SchedulingTraceFile `json:"file,omitempty"`
+}
+
+// SchedulingTraceFile defines the file the recorded cycles are written to.
+type SchedulingTraceFile struct {
+	// path of the file. When the file reaches maxSizeMegabytes, it is renamed
+	// to <path>.1, the previous <path>.1 to <path>.2, and so on.
+	Path string `json:"path"`
+
+	// maxSizeMegabytes is the size of the file above which it is rotated.
+	// Defaults to 100.
+	MaxSizeMegabytes *int32 `json:"maxSizeMegabytes,omitempty"`
+
+	// maxBackups is the number of rotated files which are kept.
+	// Defaults to 3.
+	MaxBackups *int32 `json:"maxBackups,omitempty"`
+}
+
+// SchedulingPlugins defines the plugins enabled at each extension point of
+// the scheduler.
+type SchedulingPlugins struct {
+	// queueSort plugins order the pending workloads, both within a
+	// ClusterQueue and across the heads of the ClusterQueues, when the
+	// built-in criteria up to the priority tie.
+	QueueSort []Plugin `json:"queueSort,omitempty"`
+
+	// flavorFilter plugins exclude the flavors which can't be assigned to a
+	// PodSet.
+	FlavorFilter []Plugin `json:"flavorFilter,omitempty"`
+
+	// flavorScore plugins score the flavors which can be assigned to a PodSet.
+	// When set, all the flavors of a resource group are evaluated, and the
+	// flavor with the highest weighted score is chosen among the flavors with
+	// the best assignment mode.
+	FlavorScore []Plugin `json:"flavorScore,omitempty"`
+
+	// preemptionOrder plugins order the preemption candidates when the
+	// built-in criteria up to the priority tie.
+	PreemptionOrder []Plugin `json:"preemptionOrder,omitempty"`
+
+	// postAdmission plugins are notified of the workloads which reserved
+	// quota.
+	PostAdmission []Plugin `json:"postAdmission,omitempty"`
+}
+
+// Plugin identifies a plugin enabled at an extension point.
+type Plugin struct {
+	// name of the plugin, as registered in the scheduling framework.
+	Name string `json:"name"`
+
+	// weight of the scores of the plugin. Only allowed for the flavorScore
+	// plugins.
+	// Defaults to 1.
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+// PluginConfig defines the arguments of a plugin.
+type PluginConfig struct {
+	// name of the plugin.
+	Name string `json:"name"`
+
+	// args holds the arguments of the plugin, in the format expected by the
+	// plugin.
+	Args runtime.RawExtension `json:"args,omitempty"`
+}
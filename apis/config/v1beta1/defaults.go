@@ -28,28 +28,34 @@ import (
 )
 
 const (
-	DefaultNamespace                                    = "kueue-system"
-	DefaultWebhookServiceName                           = "kueue-webhook-service"
-	DefaultWebhookSecretName                            = "kueue-webhook-server-cert"
-	DefaultWebhookPort                                  = 9443
-	DefaultHealthProbeBindAddress                       = ":8081"
-	DefaultMetricsBindAddress                           = ":8443"
-	DefaultLeaderElectionID                             = "c1f6bfd2.kueue.x-k8s.io"
-	DefaultLeaderElectionLeaseDuration                  = 15 * time.Second
-	DefaultLeaderElectionRenewDeadline                  = 10 * time.Second
-	DefaultLeaderElectionRetryPeriod                    = 2 * time.Second
-	DefaultClientConnectionQPS                  float32 = 20.0
-	DefaultClientConnectionBurst                int32   = 30
-	defaultPodsReadyTimeout                             = 5 * time.Minute
-	DefaultQueueVisibilityUpdateIntervalSeconds int32   = 5
-	DefaultClusterQueuesMaxCount                int32   = 10
-	defaultJobFrameworkName                             = "batch/job"
-	DefaultMultiKueueGCInterval                         = time.Minute
-	DefaultMultiKueueOrigin                             = "multikueue"
-	DefaultMultiKueueWorkerLostTimeout                  = 15 * time.Minute
-	DefaultRequeuingBackoffBaseSeconds                  = 60
-	DefaultRequeuingBackoffMaxSeconds                   = 3600
-	DefaultResourceTransformationStrategy               = Retain
+	DefaultNamespace                                         = "kueue-system"
+	DefaultWebhookServiceName                                = "kueue-webhook-service"
+	DefaultWebhookSecretName                                 = "kueue-webhook-server-cert"
+	DefaultWebhookPort                                       = 9443
+	DefaultHealthProbeBindAddress                            = ":8081"
+	DefaultMetricsBindAddress                                = ":8443"
+	DefaultLeaderElectionID                                  = "c1f6bfd2.kueue.x-k8s.io"
+	DefaultLeaderElectionLeaseDuration                       = 15 * time.Second
+	DefaultLeaderElectionRenewDeadline                       = 10 * time.Second
+	DefaultLeaderElectionRetryPeriod                         = 2 * time.Second
+	DefaultClientConnectionQPS                       float32 = 20.0
+	DefaultClientConnectionBurst                     int32   = 30
+	defaultPodsReadyTimeout                                  = 5 * time.Minute
+	DefaultQueueVisibilityUpdateIntervalSeconds      int32   = 5
+	DefaultClusterQueuesMaxCount                     int32   = 10
+	defaultJobFrameworkName                                  = "batch/job"
+	DefaultMultiKueueGCInterval                              = time.Minute
+	DefaultMultiKueueOrigin                                  = "multikueue"
+	DefaultMultiKueueWorkerLostTimeout                       = 15 * time.Minute
+	DefaultRequeuingBackoffBaseSeconds                       = 60
+	DefaultRequeuingBackoffMaxSeconds                        = 3600
+	DefaultResourceTransformationStrategy                    = Retain
+	DefaultAdmissionFairSharingUsageHalfLifeTime             = 24 * time.Hour
+	DefaultAdmissionFairSharingUsageSamplingInterval         = 5 * time.Minute
+	DefaultSchedulingTraceSamplingPercentage         int32   = 100
+	DefaultSchedulingTraceBufferSize                 int32   = 100
+	DefaultSchedulingTraceFileMaxSizeMegabytes       int32   = 100
+	DefaultSchedulingTraceFileMaxBackups             int32   = 3
 )
 
 func getOperatorNamespace() string {
@@ -208,6 +214,32 @@ func SetDefaults_Configuration(cfg *Configuration) {
 	if fs := cfg.FairSharing; fs != nil && fs.Enable && len(fs.PreemptionStrategies) == 0 {
 		fs.PreemptionStrategies = []PreemptionStrategy{LessThanOrEqualToFinalShare, LessThanInitialShare}
 	}
+	if afs := cfg.AdmissionFairSharing; afs != nil {
+		if afs.UsageHalfLifeTime == nil {
+			afs.UsageHalfLifeTime = &metav1.Duration{Duration: DefaultAdmissionFairSharingUsageHalfLifeTime}
+		}
+		if afs.UsageSamplingInterval == nil {
+			afs.UsageSamplingInterval = &metav1.Duration{Duration: DefaultAdmissionFairSharingUsageSamplingInterval}
+		}
+	}
+
+	if cfg.Scheduling != nil && cfg.Scheduling.Trace != nil {
+		trace := cfg.Scheduling.Trace
+		if trace.SamplingPercentage == nil {
+			trace.SamplingPercentage = ptr.To(DefaultSchedulingTraceSamplingPercentage)
+		}
+		if trace.BufferSize == nil {
+			trace.BufferSize = ptr.To(DefaultSchedulingTraceBufferSize)
+		}
+		if file := trace.File; file != nil {
+			if file.MaxSizeMegabytes == nil {
+				file.MaxSizeMegabytes = ptr.To(DefaultSchedulingTraceFileMaxSizeMegabytes)
+			}
+			if file.MaxBackups == nil {
+				file.MaxBackups = ptr.To(DefaultSchedulingTraceFileMaxBackups)
+			}
+		}
+	}
 
 	if cfg.Resources != nil {
 		for idx := range cfg.Resources.Transformations {
@@ -615,6 +615,70 @@ func TestSetDefaults_Configuration(t *testing.T) {
 				},
 			},
 		},
+		"add default admission fair sharing configuration": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				AdmissionFairSharing: &AdmissionFairSharing{
+					UsageHalfLifeTime: &metav1.Duration{Duration: time.Hour},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				AdmissionFairSharing: &AdmissionFairSharing{
+					UsageHalfLifeTime:     &metav1.Duration{Duration: time.Hour},
+					UsageSamplingInterval: &metav1.Duration{Duration: DefaultAdmissionFairSharingUsageSamplingInterval},
+				},
+			},
+		},
+		"scheduling.trace": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				Scheduling: &Scheduling{
+					Trace: &SchedulingTrace{
+						SamplingPercentage: ptr.To[int32](10),
+						File: &SchedulingTraceFile{
+							Path: "/tmp/trace.jsonl",
+						},
+					},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				Scheduling: &Scheduling{
+					Trace: &SchedulingTrace{
+						SamplingPercentage: ptr.To[int32](10),
+						BufferSize:         ptr.To(DefaultSchedulingTraceBufferSize),
+						File: &SchedulingTraceFile{
+							Path:             "/tmp/trace.jsonl",
+							MaxSizeMegabytes: ptr.To(DefaultSchedulingTraceFileMaxSizeMegabytes),
+							MaxBackups:       ptr.To(DefaultSchedulingTraceFileMaxBackups),
+						},
+					},
+				},
+			},
+		},
 		"resources.transformations strategy": {
 			original: &Configuration{
 				InternalCertManagement: &InternalCertManagement{
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kueuebeta "sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -60,10 +62,69 @@ type CohortSpec struct {
 	//+listType=atomic
 	//+kubebuilder:validation:MaxItems=16
 	ResourceGroups []kueuebeta.ResourceGroup `json:"resourceGroups,omitempty"`
+
+	// fairSharing defines the properties of the Cohort when participating in fair sharing.
+	// The share of the Cohort is computed, relative to its parent, over the resources of
+	// the whole Cohort subtree, and the weight gives it a comparative advantage against
+	// its sibling Cohorts and ClusterQueues.
+	// The values are only relevant if fair sharing is enabled in the Kueue configuration.
+	// +optional
+	FairSharing *kueuebeta.FairSharing `json:"fairSharing,omitempty"`
+}
+
+// CohortStatus defines the observed state of Cohort
+type CohortStatus struct {
+	// flavorsUsage are the quotas and usage, by flavor, aggregated over the
+	// Cohort subtree, that is the Cohort and all its descendant Cohorts and
+	// ClusterQueues.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	FlavorsUsage []CohortFlavorUsage `json:"flavorsUsage,omitempty"`
+
+	// fairSharing contains the information about the current status of fair
+	// sharing of the Cohort subtree within its parent Cohort.
+	// +optional
+	FairSharing *kueuebeta.FairSharingStatus `json:"fairSharing,omitempty"`
+}
+
+type CohortFlavorUsage struct {
+	// name of the flavor.
+	Name kueuebeta.ResourceFlavorReference `json:"name"`
+
+	// resources lists the quota and usage for the resources in this flavor.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Resources []CohortResourceUsage `json:"resources"`
+}
+
+type CohortResourceUsage struct {
+	// name of the resource
+	Name corev1.ResourceName `json:"name"`
+
+	// nominalQuota is the sum of the nominal quotas of the Cohort and all
+	// its descendant Cohorts and ClusterQueues.
+	NominalQuota resource.Quantity `json:"nominalQuota,omitempty"`
+
+	// total is the total quantity of quota used by the workloads in the
+	// ClusterQueues of the Cohort subtree.
+	Total resource.Quantity `json:"total,omitempty"`
+
+	// borrowed is the quantity of quota that the Cohort subtree borrows from
+	// its parent Cohort. It is always zero for a Cohort without a parent.
+	Borrowed resource.Quantity `json:"borrowed,omitempty"`
+
+	// lendable is the quantity of quota of the Cohort subtree which can be
+	// lent to the ClusterQueues outside of the subtree, that is the quota
+	// not guarded by the lending limits.
+	Lendable resource.Quantity `json:"lendable,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
 
 // Cohort is the Schema for the cohorts API. Using Hierarchical
 // Cohorts (any Cohort which has a parent) with Fair Sharing
@@ -72,7 +133,8 @@ type Cohort struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec CohortSpec `json:"spec,omitempty"`
+	Spec   CohortSpec   `json:"spec,omitempty"`
+	Status CohortStatus `json:"status,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueuebeta "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+const (
+	// QuotaScheduleActive indicates whether a window of the QuotaSchedule
+	// is applied to the quota of the ClusterQueue.
+	QuotaScheduleActive = "Active"
+
+	// QuotaScheduleReasonWindowActive is the reason of the Active condition
+	// when a window is applied.
+	QuotaScheduleReasonWindowActive = "WindowActive"
+
+	// QuotaScheduleReasonNoActiveWindow is the reason of the Active condition
+	// when no window is active, and the ClusterQueue uses the quota from its
+	// spec.
+	QuotaScheduleReasonNoActiveWindow = "NoActiveWindow"
+
+	// QuotaScheduleReasonInvalidSchedule is the reason of the Active
+	// condition when a schedule or the time zone can't be parsed.
+	QuotaScheduleReasonInvalidSchedule = "InvalidSchedule"
+)
+
+// QuotaReclaimPolicy defines how the quota used above the quota of a
+// window is reclaimed, when the window starts or ends.
+// +kubebuilder:validation:Enum=Never;Preempt
+type QuotaReclaimPolicy string
+
+const (
+	// QuotaReclaimNever lets the admitted workloads run to completion; no
+	// new workloads are admitted until the usage is within the quota.
+	QuotaReclaimNever QuotaReclaimPolicy = "Never"
+
+	// QuotaReclaimPreempt preempts the workloads of the ClusterQueue, with
+	// the lowest priority and then the most recently admitted first, until
+	// the usage is within the quota.
+	QuotaReclaimPreempt QuotaReclaimPolicy = "Preempt"
+)
+
+// QuotaScheduleSpec defines the desired state of QuotaSchedule
+type QuotaScheduleSpec struct {
+	// clusterQueue is the name of the ClusterQueue whose quota is changed
+	// during the windows.
+	ClusterQueue kueuebeta.ClusterQueueReference `json:"clusterQueue"`
+
+	// timeZone is the name of the time zone of the schedules, like
+	// "Europe/Warsaw". Defaults to UTC.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// windows are the time windows during which the quota of the
+	// ClusterQueue is changed. When multiple windows are active at the same
+	// time, the first one in the list applies.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Windows []QuotaWindow `json:"windows"`
+
+	// reclaimPolicy determines how the quota used above the quota in effect,
+	// after a window starts or ends, is reclaimed. The possible values are:
+	//
+	// - `Never` (default): the admitted workloads keep running.
+	// - `Preempt`: the workloads of the ClusterQueue are preempted until its
+	//   usage fits the quota.
+	//
+	// +kubebuilder:default=Never
+	// +optional
+	ReclaimPolicy QuotaReclaimPolicy `json:"reclaimPolicy,omitempty"`
+}
+
+// QuotaWindow is a recurring time window with the quota it applies.
+type QuotaWindow struct {
+	// name of the window.
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// schedule is the start of the window in the cron format, with five
+	// fields: minute, hour, day of month, month and day of week.
+	// For example, "0 9 * * 1-5" starts the window at 9:00, Monday to
+	// Friday.
+	// +kubebuilder:validation:MinLength=9
+	// +kubebuilder:validation:MaxLength=256
+	Schedule string `json:"schedule"`
+
+	// duration of the window.
+	Duration metav1.Duration `json:"duration"`
+
+	// flavors are the quotas applied during the window. The quotas of the
+	// resources not listed are the ones in the ClusterQueue spec.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Flavors []ScheduledFlavorQuotas `json:"flavors"`
+}
+
+type ScheduledFlavorQuotas struct {
+	// name of the flavor, as in the ClusterQueue spec.
+	Name kueuebeta.ResourceFlavorReference `json:"name"`
+
+	// resources are the quotas of the resources applied during the window.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Resources []ScheduledResourceQuota `json:"resources"`
+}
+
+// ScheduledResourceQuota holds the quota of a resource applied during a
+// window. The unset fields keep their value from the ClusterQueue spec.
+type ScheduledResourceQuota struct {
+	// name of the resource, as in the ClusterQueue spec.
+	Name corev1.ResourceName `json:"name"`
+
+	// nominalQuota applied during the window.
+	// +optional
+	NominalQuota *resource.Quantity `json:"nominalQuota,omitempty"`
+
+	// borrowingLimit applied during the window.
+	// +optional
+	BorrowingLimit *resource.Quantity `json:"borrowingLimit,omitempty"`
+
+	// lendingLimit applied during the window.
+	// +optional
+	LendingLimit *resource.Quantity `json:"lendingLimit,omitempty"`
+}
+
+// QuotaScheduleStatus defines the observed state of QuotaSchedule
+type QuotaScheduleStatus struct {
+	// activeWindow is the name of the window applied to the ClusterQueue.
+	// +optional
+	ActiveWindow string `json:"activeWindow,omitempty"`
+
+	// conditions hold the latest available observations of the
+	// QuotaSchedule current state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="ClusterQueue",JSONPath=".spec.clusterQueue",type=string,description="ClusterQueue whose quota is scheduled"
+//+kubebuilder:printcolumn:name="Active Window",JSONPath=".status.activeWindow",type=string,description="Window applied to the ClusterQueue"
+
+// QuotaSchedule is the Schema for the quotaschedules API. It changes the
+// quota of a ClusterQueue during recurring time windows.
+type QuotaSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   QuotaScheduleSpec   `json:"spec,omitempty"`
+	Status QuotaScheduleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// QuotaScheduleList contains a list of QuotaSchedule
+type QuotaScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []QuotaSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&QuotaSchedule{}, &QuotaScheduleList{})
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueuebeta "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+const (
+	// ReservationLabel is a label set on the Job, and propagated to its
+	// Workload, to indicate the name of the Reservation that the Workload
+	// uses. Such a Workload is only admitted during the window of the
+	// Reservation, using its reserved quota.
+	ReservationLabel = "kueue.x-k8s.io/reservation"
+
+	// ReservationActive indicates whether the Reservation window is ongoing.
+	ReservationActive = "Active"
+
+	// ReservationReasonPending is the reason of the Active condition when
+	// the window of the Reservation didn't start yet.
+	ReservationReasonPending = "Pending"
+
+	// ReservationReasonStarted is the reason of the Active condition during
+	// the window of the Reservation.
+	ReservationReasonStarted = "Started"
+
+	// ReservationReasonExpired is the reason of the Active condition when
+	// the window of the Reservation has ended.
+	ReservationReasonExpired = "Expired"
+)
+
+// ReservationSpec defines the desired state of Reservation
+// +kubebuilder:validation:XValidation:rule="has(self.clusterQueue) != has(self.cohort)", message="exactly one of clusterQueue or cohort must be set"
+// +kubebuilder:validation:XValidation:rule="self.endTime > self.startTime", message="endTime must be after startTime"
+type ReservationSpec struct {
+	// clusterQueue is the name of the ClusterQueue whose quota is reserved.
+	// Only one of clusterQueue and cohort can be set.
+	// +optional
+	ClusterQueue kueuebeta.ClusterQueueReference `json:"clusterQueue,omitempty"`
+
+	// cohort is the name of the Cohort whose quota is reserved. The reserved
+	// quota can be used by the Workloads of any ClusterQueue in the Cohort
+	// subtree. Only one of clusterQueue and cohort can be set.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+	// +optional
+	Cohort string `json:"cohort,omitempty"`
+
+	// flavors is the quota reserved, by flavor.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Flavors []ReservedFlavor `json:"flavors"`
+
+	// startTime is the beginning of the window during which the reserved
+	// quota can only be used by the Workloads referencing the Reservation.
+	// At the start of the window, the Workloads which use the reserved quota
+	// without referencing the Reservation are evicted.
+	StartTime metav1.Time `json:"startTime"`
+
+	// endTime is the end of the window of the Reservation. After this time,
+	// the reserved quota is released.
+	EndTime metav1.Time `json:"endTime"`
+
+	// leadTime is the duration, before startTime, from which the reserved
+	// quota can no longer be used by new Workloads, so that the Workloads
+	// already using it have a chance to finish before the window starts.
+	// Defaults to 0.
+	// +optional
+	LeadTime *metav1.Duration `json:"leadTime,omitempty"`
+}
+
+type ReservedFlavor struct {
+	// name of the flavor.
+	Name kueuebeta.ResourceFlavorReference `json:"name"`
+
+	// resources is the quantity of each resource reserved in the flavor.
+	Resources corev1.ResourceList `json:"resources"`
+}
+
+// ReservationStatus defines the observed state of Reservation
+type ReservationStatus struct {
+	// conditions hold the latest available observations of the Reservation
+	// current state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="ClusterQueue",JSONPath=".spec.clusterQueue",type=string,description="ClusterQueue whose quota is reserved"
+//+kubebuilder:printcolumn:name="Cohort",JSONPath=".spec.cohort",type=string,description="Cohort whose quota is reserved"
+//+kubebuilder:printcolumn:name="Start",JSONPath=".spec.startTime",type=date,description="Start of the reservation window"
+//+kubebuilder:printcolumn:name="End",JSONPath=".spec.endTime",type=date,description="End of the reservation window"
+
+// Reservation is the Schema for the reservations API. It holds a slice
+// of the quota of a ClusterQueue or a Cohort for the Workloads which
+// reference it, during a time window.
+type Reservation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReservationSpec   `json:"spec,omitempty"`
+	Status ReservationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ReservationList contains a list of Reservation
+type ReservationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Reservation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Reservation{}, &ReservationList{})
+}
@@ -80,11 +80,55 @@ type ClusterQueueSpec struct {
 	// - BestEffortFIFO: workloads are ordered by creation time,
 	// however older workloads that can't be admitted will not block
 	// admitting newer workloads that fit existing quota.
+	// - EarliestDeadlineFirst: workloads are ordered by the latest time at
+	// which they can start to complete before their completionDeadline.
+	// Workloads without a deadline are ordered after, by creation time.
+	// Like with BestEffortFIFO, workloads that can't be admitted will not
+	// block admitting other workloads.
 	//
 	// +kubebuilder:default=BestEffortFIFO
-	// +kubebuilder:validation:Enum=StrictFIFO;BestEffortFIFO
+	// +kubebuilder:validation:Enum=StrictFIFO;BestEffortFIFO;EarliestDeadlineFirst
 	QueueingStrategy QueueingStrategy `json:"queueingStrategy,omitempty"`
 
+	// orderingExpression is a CEL expression which defines the order of the
+	// pending workloads in the ClusterQueue. The expression is evaluated for
+	// each workload and the workloads are ordered by its result, lowest first.
+	// The workloads with equal results are ordered according to the
+	// queueingStrategy.
+	//
+	// The expression can use the following variables:
+	// - labels: the labels of the workload, as map(string, string).
+	// - annotations: the annotations of the workload, as map(string, string).
+	// - priority: the effective priority of the workload, raised by the
+	//   priorityAging, as int. It's the priority used by the default order.
+	// - basePriority: the priority of the workload, ignoring the
+	//   priorityAging, as int.
+	// - requests: the total requests of the workload per resource, as
+	//   map(string, int), with CPU in millicores and the other resources in
+	//   their base units.
+	// - creationTimestamp: the creation time of the workload, as timestamp.
+	// - queueName: the name of the LocalQueue of the workload, as string.
+	//
+	// The result must be an int, uint, double, string, timestamp or duration.
+	// For example, "-priority" orders the workloads by priority, highest first.
+	//
+	// The expression is only used when the QueueOrderingExpression feature
+	// gate is enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	OrderingExpression *string `json:"orderingExpression,omitempty"`
+
+	// backfill allows admitting the workloads queued behind a head that
+	// doesn't fit the available quota, as long as they declare a
+	// maximumExecutionTimeSeconds and are expected to finish before the head
+	// could be admitted. The time at which the head could be admitted is
+	// estimated from the maximumExecutionTimeSeconds of the admitted workloads.
+	// It is only supported with the StrictFIFO queueing strategy.
+	// +optional
+	Backfill *Backfill `json:"backfill,omitempty"`
+
 	// namespaceSelector defines which namespaces are allowed to submit workloads to
 	// this clusterQueue. Beyond this basic support for policy, a policy agent like
 	// Gatekeeper should be used to enforce more advanced policies.
@@ -97,6 +141,32 @@ type ClusterQueueSpec struct {
 	// +kubebuilder:default={}
 	FlavorFungibility *FlavorFungibility `json:"flavorFungibility,omitempty"`
 
+	// flavorSelectionPolicy determines how the flavors of a resource group
+	// are selected for the resources of a pod set. The possible values are:
+	//
+	// - `DeclarationOrder` (default): select the first flavor that fits, in
+	//   the order they are listed in the resource group.
+	// - `LeastAllocated`: select the flavor with the lowest fraction of its
+	//   capacity in use, spreading the workloads across the flavors.
+	// - `MostAllocated`: select the flavor with the highest fraction of its
+	//   capacity in use, packing the workloads in as few flavors as possible.
+	// - `LowestCost`: select the flavor with the lowest cost, as defined in
+	//   the ResourceFlavor.
+	//
+	// The capacity of a flavor includes the quota that can be borrowed from
+	// the cohort, and its usage includes the usage of the cohort.
+	//
+	// With a policy other than DeclarationOrder, all the flavors are
+	// evaluated, and the policy selects among the flavors at which the
+	// flavorFungibility would stop. The declaration order breaks the ties.
+	//
+	// The policy is only used when the FlavorSelectionPolicy feature gate is
+	// enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum={DeclarationOrder,LeastAllocated,MostAllocated,LowestCost}
+	FlavorSelectionPolicy *FlavorSelectionPolicy `json:"flavorSelectionPolicy,omitempty"`
+
 	// preemption describes policies to preempt Workloads from this ClusterQueue
 	// or the ClusterQueue's cohort.
 	//
@@ -142,6 +212,107 @@ type ClusterQueueSpec struct {
 	// fairSharing defines the properties of the ClusterQueue when participating in fair sharing.
 	// The values are only relevant if fair sharing is enabled in the Kueue configuration.
 	FairSharing *FairSharing `json:"fairSharing,omitempty"`
+
+	// localQueueFairSharing enables fair sharing between the LocalQueues pointing
+	// to this ClusterQueue. When set, the pending workloads of the LocalQueues with
+	// the lowest share of the usage are considered first, before the priority and
+	// the timestamp of the workloads.
+	// +optional
+	LocalQueueFairSharing *LocalQueueFairSharing `json:"localQueueFairSharing,omitempty"`
+
+	// priorityAging raises the effective priority of the pending workloads of
+	// this ClusterQueue as their queued time grows, so that the workloads with
+	// a low priority are not starved by a steady flow of workloads with a higher
+	// priority. The effective priority is used to order the workloads and to
+	// determine which workloads can be preempted, and it's reported in the
+	// Workload status.
+	//
+	// This field is used when the PriorityAging feature gate is enabled.
+	// +optional
+	PriorityAging *PriorityAging `json:"priorityAging,omitempty"`
+}
+
+type LocalQueueUsageMode string
+
+const (
+	// LocalQueueCurrentUsage means that the LocalQueues are compared by the
+	// resources currently reserved by their workloads.
+	LocalQueueCurrentUsage LocalQueueUsageMode = "Current"
+
+	// LocalQueueHistoricalUsage means that the LocalQueues are compared by
+	// their historical resource consumption, decayed over time. It requires
+	// the admission fair sharing to be enabled in the Kueue configuration;
+	// the current usage is compared otherwise.
+	LocalQueueHistoricalUsage LocalQueueUsageMode = "Historical"
+)
+
+// LocalQueueFairSharing defines how the LocalQueues of a ClusterQueue share
+// its resources.
+type LocalQueueFairSharing struct {
+	// usageMode indicates which usage of the LocalQueues is compared.
+	// Possible values are:
+	//
+	// - Current: the resources currently reserved by the workloads of the LocalQueue.
+	// - Historical: the historical resource consumption of the LocalQueue, decayed over time.
+	//
+	// The share of a LocalQueue is the maximum, among the resources, of the ratio of
+	// its usage to the nominal quota of the ClusterQueue, divided by the weight of
+	// the LocalQueue.
+	// +kubebuilder:default=Current
+	// +kubebuilder:validation:Enum=Current;Historical
+	UsageMode LocalQueueUsageMode `json:"usageMode,omitempty"`
+}
+
+type PriorityAgingPolicy string
+
+const (
+	// PriorityAgingLinear raises the priority continuously, by increment
+	// over every interval.
+	PriorityAgingLinear PriorityAgingPolicy = "Linear"
+
+	// PriorityAgingStep raises the priority by increment at the end of every
+	// interval.
+	PriorityAgingStep PriorityAgingPolicy = "Step"
+)
+
+// PriorityAging defines how the effective priority of a pending workload
+// grows with the time since it was created or last requeued.
+type PriorityAging struct {
+	// policy indicates how the priority grows within an interval.
+	// Possible values are:
+	//
+	// - Step: the priority is raised by increment at the end of every interval.
+	// - Linear: the priority is raised continuously, by increment over every interval.
+	//
+	// +kubebuilder:default=Step
+	// +kubebuilder:validation:Enum=Linear;Step
+	Policy PriorityAgingPolicy `json:"policy,omitempty"`
+
+	// interval is the queued time over which the priority is raised by
+	// increment.
+	Interval metav1.Duration `json:"interval"`
+
+	// increment is the amount by which the priority is raised every interval.
+	// +kubebuilder:validation:Minimum=1
+	Increment int32 `json:"increment"`
+
+	// maxIncrease caps the amount by which the priority of a workload can be
+	// raised.
+	// +kubebuilder:validation:Minimum=1
+	MaxIncrease int32 `json:"maxIncrease"`
+}
+
+// Backfill defines how the workloads queued behind a blocked head are
+// considered for admission.
+type Backfill struct {
+	// maxCandidates is the maximum number of workloads queued behind the
+	// blocked head which are considered for backfilling in a scheduling cycle.
+	// Defaults to 10.
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	MaxCandidates *int32 `json:"maxCandidates,omitempty"`
 }
 
 // AdmissionChecksStrategy defines a strategy for a AdmissionCheck.
@@ -173,6 +344,13 @@ const (
 	// however older workloads that can't be admitted will not block
 	// admitting newer workloads that fit existing quota.
 	BestEffortFIFO QueueingStrategy = "BestEffortFIFO"
+
+	// EarliestDeadlineFirst means that workloads of the same priority are ordered
+	// by their latest start time, which is their completion deadline minus their
+	// maximum execution time. Workloads without a deadline are ordered after, by
+	// creation time. Workloads that can't be admitted will not block admitting
+	// other workloads that fit existing quota.
+	EarliestDeadlineFirst QueueingStrategy = "EarliestDeadlineFirst"
 )
 
 // +kubebuilder:validation:XValidation:rule="self.flavors.all(x, size(x.resources) == size(self.coveredResources))", message="flavors must have the same number of resources as the coveredResources"
@@ -369,6 +547,21 @@ type FairSharingStatus struct {
 	// If the ClusterQueue has a weight of zero, this will return 9223372036854775807,
 	// the maximum possible share value.
 	WeightedShare int64 `json:"weightedShare"`
+
+	// admissionFairSharingStatus represents the historical resource consumption
+	// used by the admission fair sharing. It is only populated when the
+	// AdmissionFairSharing feature gate is enabled and configured.
+	// +optional
+	AdmissionFairSharingStatus *AdmissionFairSharingStatus `json:"admissionFairSharingStatus,omitempty"`
+}
+
+type AdmissionFairSharingStatus struct {
+	// consumedResources represents the aggregated usage of resources over time,
+	// in resource-seconds, with the decay defined by the usage half-life time.
+	ConsumedResources corev1.ResourceList `json:"consumedResources"`
+
+	// lastUpdate is the time when the consumed resources were last sampled.
+	LastUpdate metav1.Time `json:"lastUpdate"`
 }
 
 const (
@@ -394,6 +587,15 @@ const (
 	TryNextFlavor FlavorFungibilityPolicy = "TryNextFlavor"
 )
 
+type FlavorSelectionPolicy string
+
+const (
+	DeclarationOrder FlavorSelectionPolicy = "DeclarationOrder"
+	LeastAllocated   FlavorSelectionPolicy = "LeastAllocated"
+	MostAllocated    FlavorSelectionPolicy = "MostAllocated"
+	LowestCost       FlavorSelectionPolicy = "LowestCost"
+)
+
 // FlavorFungibility determines whether a workload should try the next flavor
 // before borrowing or preempting in current flavor.
 type FlavorFungibility struct {
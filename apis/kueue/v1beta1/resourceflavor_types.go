@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -99,6 +100,15 @@ type ResourceFlavorSpec struct {
 	//
 	// +optional
 	TopologyName *TopologyReference `json:"topologyName,omitempty"`
+
+	// cost is the relative cost of the resources of the ResourceFlavor, for
+	// example their hourly price. It is used by the ClusterQueues with the
+	// LowestCost flavorSelectionPolicy, which prefer the cheapest flavors.
+	// ResourceFlavors without a cost are considered more expensive than the
+	// ones with a cost.
+	//
+	// +optional
+	Cost *resource.Quantity `json:"cost,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -84,6 +85,73 @@ type WorkloadSpec struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=1
 	MaximumExecutionTimeSeconds *int32 `json:"maximumExecutionTimeSeconds,omitempty"`
+
+	// completionDeadline if provided, is the time by which the workload needs
+	// to complete. The latest time at which the workload can start is the
+	// deadline minus its maximumExecutionTimeSeconds, if set. The ClusterQueues
+	// with the EarliestDeadlineFirst queueing strategy order the workloads by
+	// their latest start time. A pending workload is deactivated once its
+	// latest start time has passed.
+	//
+	// The deadline is only enforced when the DeadlineAwareScheduling feature
+	// gate is enabled.
+	//
+	// +optional
+	CompletionDeadline *metav1.Time `json:"completionDeadline,omitempty"`
+
+	// dependencies are the workloads, in the same namespace, which need to
+	// finish before this workload can be admitted. The workload is kept
+	// inadmissible until all its dependencies are satisfied, and it is
+	// finished with the DependencyFailed reason if a dependency finishes
+	// without satisfying its condition.
+	//
+	// The dependencies are only enforced when the WorkloadDependencies
+	// feature gate is enabled.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=8
+	Dependencies []WorkloadDependency `json:"dependencies,omitempty"`
+}
+
+// WorkloadDependencyCondition is the condition a dependency needs to finish
+// with to be satisfied.
+type WorkloadDependencyCondition string
+
+const (
+	// WorkloadDependencySucceeded means that the dependency needs to finish
+	// successfully.
+	WorkloadDependencySucceeded WorkloadDependencyCondition = "Succeeded"
+
+	// WorkloadDependencyFinished means that the dependency needs to finish,
+	// whether it succeeded or not.
+	WorkloadDependencyFinished WorkloadDependencyCondition = "Finished"
+)
+
+// WorkloadDependency references the workloads a workload depends on.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.selector)", message="exactly one of name or selector must be set"
+type WorkloadDependency struct {
+	// name is the name of the Workload this workload depends on.
+	//
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// selector selects the Workloads this workload depends on. The
+	// dependency is satisfied when at least one workload matches the
+	// selector, and all the matching workloads satisfy the condition.
+	//
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// condition is the condition the dependency needs to finish with.
+	// The possible values are:
+	// - Succeeded: the dependency needs to finish successfully.
+	// - Finished: the dependency needs to finish, whether it succeeded or not.
+	//
+	// +kubebuilder:default=Succeeded
+	// +kubebuilder:validation:Enum=Succeeded;Finished
+	Condition WorkloadDependencyCondition `json:"condition,omitempty"`
 }
 
 // PodSetTopologyRequest defines the topology request for a PodSet.
@@ -202,8 +270,35 @@ type PodSetAssignment struct {
 	//
 	// +optional
 	TopologyAssignment *TopologyAssignment `json:"topologyAssignment,omitempty"`
+
+	// delayedTopologyRequest indicates that the topology assignment for the
+	// PodSet is delayed. This happens when the ClusterQueue uses a
+	// ProvisioningRequest AdmissionCheck, as the nodes which accommodate the
+	// PodSet are only known once the check is Ready.
+	// The value is Pending until Kueue computes the topology assignment in
+	// the second pass of scheduling, after all the AdmissionChecks are Ready,
+	// and then it is Ready.
+	//
+	// +optional
+	DelayedTopologyRequest *DelayedTopologyRequestState `json:"delayedTopologyRequest,omitempty"`
 }
 
+// DelayedTopologyRequestState indicates the state of the delayed topology
+// assignment for a PodSet.
+// +enum
+// +kubebuilder:validation:Enum=Pending;Ready
+type DelayedTopologyRequestState string
+
+const (
+	// DelayedTopologyRequestStatePending means that the topology assignment
+	// is not computed yet.
+	DelayedTopologyRequestStatePending DelayedTopologyRequestState = "Pending"
+
+	// DelayedTopologyRequestStateReady means that the topology assignment
+	// is computed.
+	DelayedTopologyRequestStateReady DelayedTopologyRequestState = "Ready"
+)
+
 type TopologyAssignment struct {
 	// levels is an ordered list of keys denoting the levels of the assigned
 	// topology (i.e. node label keys), from the highest to the lowest level of
@@ -352,6 +447,161 @@ type WorkloadStatus struct {
 	//
 	// +optional
 	AccumulatedPastExexcutionTimeSeconds *int32 `json:"accumulatedPastExexcutionTimeSeconds,omitempty"`
+
+	// lastSchedulingAttempt holds the structured details of the last
+	// scheduling attempt in which the workload couldn't reserve quota.
+	// It describes, for each podSet, why each of the evaluated flavors
+	// couldn't be assigned immediately.
+	// If admission is non-null, lastSchedulingAttempt will be empty.
+	//
+	// This field is populated when the WorkloadSchedulingAttemptDetails
+	// feature gate is enabled.
+	//
+	// +optional
+	LastSchedulingAttempt *SchedulingAttempt `json:"lastSchedulingAttempt,omitempty"`
+
+	// effectivePriority is the priority of the workload raised by the
+	// priority aging policy of its ClusterQueue. It's updated while the
+	// workload is pending, and kept while the workload has quota reserved,
+	// so that it's not preempted by the workloads it was ordered before.
+	//
+	// This field is populated when the PriorityAging feature gate is enabled.
+	//
+	// +optional
+	EffectivePriority *int32 `json:"effectivePriority,omitempty"`
+}
+
+// SchedulingAttempt holds the details of a scheduling attempt for a workload.
+type SchedulingAttempt struct {
+	// clusterQueue is the name of the ClusterQueue that evaluated the workload.
+	// +required
+	// +kubebuilder:validation:Required
+	ClusterQueue ClusterQueueReference `json:"clusterQueue"`
+
+	// podSets holds the details of the flavor assignment for the podSets
+	// that couldn't be assigned flavors immediately.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=8
+	PodSets []PodSetSchedulingAttempt `json:"podSets,omitempty"`
+}
+
+// PodSetSchedulingAttempt holds the details of the flavor assignment for a podSet.
+type PodSetSchedulingAttempt struct {
+	// name is the name of the podSet. It should match one of the names in .spec.podSets.
+	// +kubebuilder:default=main
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern="^(?i)[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+
+	// flavors lists the flavors that were evaluated for the resources of
+	// the podSet that couldn't be assigned immediately, in the order in
+	// which they were evaluated.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=256
+	Flavors []FlavorSchedulingAttempt `json:"flavors,omitempty"`
+
+	// reasons lists the reasons that don't relate to a specific flavor,
+	// for example, a resource that is not covered by the ClusterQueue.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=16
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// FlavorSchedulingAttemptMode describes the outcome of evaluating a flavor.
+type FlavorSchedulingAttemptMode string
+
+const (
+	// FlavorSchedulingAttemptFit means that the resources fit in the flavor.
+	FlavorSchedulingAttemptFit FlavorSchedulingAttemptMode = "Fit"
+
+	// FlavorSchedulingAttemptPreempt means that the resources could fit in
+	// the flavor after preempting other workloads or after they finish.
+	FlavorSchedulingAttemptPreempt FlavorSchedulingAttemptMode = "Preempt"
+
+	// FlavorSchedulingAttemptNoFit means that the resources don't fit in the flavor.
+	FlavorSchedulingAttemptNoFit FlavorSchedulingAttemptMode = "NoFit"
+)
+
+// FlavorSchedulingAttempt holds the outcome of evaluating a flavor for a podSet.
+type FlavorSchedulingAttempt struct {
+	// name is the name of the ResourceFlavor.
+	// +required
+	// +kubebuilder:validation:Required
+	Name ResourceFlavorReference `json:"name"`
+
+	// mode is the outcome of evaluating the flavor, one of Fit, Preempt or NoFit.
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Fit;Preempt;NoFit
+	Mode FlavorSchedulingAttemptMode `json:"mode"`
+
+	// borrowing indicates whether using the flavor requires borrowing
+	// quota from the cohort.
+	// +optional
+	Borrowing bool `json:"borrowing,omitempty"`
+
+	// preemptionConsidered indicates whether preempting other workloads
+	// was evaluated to make room for the podSet in the flavor.
+	// +optional
+	PreemptionConsidered bool `json:"preemptionConsidered,omitempty"`
+
+	// resources lists the resources that don't fit in the unused quota
+	// of the flavor.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	Resources []ResourceSchedulingAttempt `json:"resources,omitempty"`
+
+	// reasons lists the reasons why the flavor can't be assigned immediately.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=64
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// ResourceSchedulingAttempt holds the quota evaluation of a resource in a flavor.
+type ResourceSchedulingAttempt struct {
+	// name of the resource.
+	// +required
+	// +kubebuilder:validation:Required
+	Name corev1.ResourceName `json:"name"`
+
+	// requested is the quantity of the resource requested by the workload
+	// in the flavor, including the previous podSets assigned to the same flavor.
+	// +required
+	// +kubebuilder:validation:Required
+	Requested resource.Quantity `json:"requested"`
+
+	// available is the unused quota of the resource in the flavor, including
+	// the quota that could be borrowed from the cohort.
+	// +required
+	// +kubebuilder:validation:Required
+	Available resource.Quantity `json:"available"`
+
+	// missing is the quantity that is lacking for the request to fit in
+	// the unused quota.
+	// +required
+	// +kubebuilder:validation:Required
+	Missing resource.Quantity `json:"missing"`
+
+	// maxCapacity is the maximum quantity of the resource that the
+	// ClusterQueue could use in the flavor, including the quota that could
+	// be borrowed from the cohort.
+	// +required
+	// +kubebuilder:validation:Required
+	MaxCapacity resource.Quantity `json:"maxCapacity"`
 }
 
 type RequeueState struct {
@@ -498,6 +748,21 @@ const (
 	// WorkloadDeactivationTarget means that the Workload should be deactivated.
 	// This condition is temporary, so it should be removed after deactivation.
 	WorkloadDeactivationTarget = "DeactivationTarget"
+
+	// WorkloadDependenciesSatisfied means that all the dependencies of the
+	// Workload finished as required.
+	WorkloadDependenciesSatisfied = "DependenciesSatisfied"
+)
+
+// Reasons for the WorkloadDependenciesSatisfied condition.
+const (
+	// WorkloadDependenciesReasonSatisfied indicates that all the dependencies
+	// of the workload finished as required.
+	WorkloadDependenciesReasonSatisfied = "Satisfied"
+
+	// WorkloadDependenciesReasonPending indicates that at least one
+	// dependency of the workload didn't finish yet.
+	WorkloadDependenciesReasonPending = "Pending"
 )
 
 // Reasons for the WorkloadPreempted condition.
@@ -517,6 +782,14 @@ const (
 	// InCohortReclaimWhileBorrowingReason indicates the Workload was preempted
 	// due to reclamation within the cohort while borrowing.
 	InCohortReclaimWhileBorrowingReason string = "InCohortReclaimWhileBorrowing"
+
+	// InReservationReason indicates the Workload was preempted to free the
+	// quota held by a Reservation at the start of its window.
+	InReservationReason string = "InReservation"
+
+	// InQuotaReductionReason indicates the Workload was preempted because
+	// the quota of its ClusterQueue was reduced by a QuotaSchedule.
+	InQuotaReductionReason string = "InQuotaReduction"
 )
 
 const (
@@ -578,6 +851,10 @@ const (
 	// WorkloadMaximumExecutionTimeExceeded indicates that the workload exceeded its
 	// maximum execution time.
 	WorkloadMaximumExecutionTimeExceeded = "MaximumExecutionTimeExceeded"
+
+	// WorkloadDeadlineExceeded indicates that the workload can no longer
+	// complete before its completion deadline.
+	WorkloadDeadlineExceeded = "DeadlineExceeded"
 )
 
 const (
@@ -592,6 +869,9 @@ const (
 
 	// WorkloadFinishedReasonOutOfSync indicates that the prebuilt workload is not in sync with its parent job.
 	WorkloadFinishedReasonOutOfSync = "OutOfSync"
+
+	// WorkloadFinishedReasonDependencyFailed indicates that a dependency of the workload finished without satisfying its condition.
+	WorkloadFinishedReasonDependencyFailed = "DependencyFailed"
 )
 
 // +genclient
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // +genclient
@@ -25,6 +26,7 @@ import (
 // +k8s:openapi-gen=true
 // +genclient:nonNamespaced
 // +genclient:method=GetPendingWorkloadsSummary,verb=get,subresource=pendingworkloads,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkloadsSummary
+// +genclient:method=CreateAdmissionSimulation,verb=create,subresource=admissionsimulation,input=sigs.k8s.io/kueue/apis/visibility/v1beta1.AdmissionSimulation,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.AdmissionSimulation
 type ClusterQueue struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -105,9 +107,101 @@ type PendingWorkloadOptions struct {
 	Limit int64 `json:"limit,omitempty"`
 }
 
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+
+// AdmissionSimulation evaluates whether a Workload would be admitted by a
+// ClusterQueue given the current state of the cluster. The simulation never
+// creates the Workload nor modifies the state of the queues.
+type AdmissionSimulation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AdmissionSimulationSpec `json:"spec"`
+
+	// Status is populated by the server with the result of the simulation.
+	Status AdmissionSimulationStatus `json:"status,omitempty"`
+}
+
+// AdmissionSimulationSpec holds the Workload to evaluate.
+type AdmissionSimulationSpec struct {
+	// Workload is the kueue.x-k8s.io/v1beta1 Workload to evaluate.
+	// The namespace of the Workload is used to evaluate the
+	// namespaceSelector of the ClusterQueue and the LimitRanges.
+	Workload runtime.RawExtension `json:"workload"`
+}
+
+// AdmissionSimulationMode describes the outcome of a simulated admission.
+type AdmissionSimulationMode string
+
+const (
+	// AdmissionSimulationFit means that the Workload fits in the available quota.
+	AdmissionSimulationFit AdmissionSimulationMode = "Fit"
+
+	// AdmissionSimulationPreempt means that the Workload could be admitted
+	// after preempting the workloads listed in preemptionTargets.
+	AdmissionSimulationPreempt AdmissionSimulationMode = "Preempt"
+
+	// AdmissionSimulationNoFit means that the Workload can't be admitted
+	// in the current state of the cluster.
+	AdmissionSimulationNoFit AdmissionSimulationMode = "NoFit"
+)
+
+// AdmissionSimulationStatus is the result of a simulated admission.
+type AdmissionSimulationStatus struct {
+	// Mode indicates whether the Workload would fit, would need to preempt
+	// other workloads, or couldn't be admitted.
+	Mode AdmissionSimulationMode `json:"mode"`
+
+	// Borrowing indicates whether the Workload would borrow quota from
+	// the cohort.
+	Borrowing bool `json:"borrowing"`
+
+	// PodSetAssignments holds the flavors that would be assigned to each
+	// PodSet. It's empty when mode is NoFit.
+	PodSetAssignments []PodSetAssignmentSimulation `json:"podSetAssignments,omitempty"`
+
+	// PreemptionTargets lists the workloads that would be preempted to
+	// make room for the Workload.
+	PreemptionTargets []PreemptionTarget `json:"preemptionTargets,omitempty"`
+
+	// Message explains why the Workload can't be admitted or why it
+	// needs to preempt other workloads.
+	Message string `json:"message,omitempty"`
+}
+
+// PodSetAssignmentSimulation holds the flavors that would be assigned to a PodSet.
+type PodSetAssignmentSimulation struct {
+	// Name is the name of the PodSet.
+	Name string `json:"name"`
+
+	// Flavors maps each requested resource to the assigned ResourceFlavor.
+	Flavors map[string]string `json:"flavors,omitempty"`
+
+	// Count is the number of pods that would be admitted, which could be
+	// lower than requested if the Workload allows partial admission.
+	Count int32 `json:"count"`
+}
+
+// PreemptionTarget is a workload that would be preempted.
+type PreemptionTarget struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// ClusterQueueName is the ClusterQueue where the workload is admitted.
+	ClusterQueueName string `json:"clusterQueueName"`
+
+	// Priority indicates the workload's priority.
+	Priority int32 `json:"priority"`
+
+	// Reason is the reason for the preemption, for example InClusterQueue
+	// or InCohortReclamation.
+	Reason string `json:"reason"`
+}
+
 func init() {
 	SchemeBuilder.Register(
 		&PendingWorkloadsSummary{},
 		&PendingWorkloadOptions{},
+		&AdmissionSimulation{},
 	)
 }
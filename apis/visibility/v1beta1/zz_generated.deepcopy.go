@@ -24,6 +24,78 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionSimulation) DeepCopyInto(out *AdmissionSimulation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionSimulation.
+func (in *AdmissionSimulation) DeepCopy() *AdmissionSimulation {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionSimulation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AdmissionSimulation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionSimulationSpec) DeepCopyInto(out *AdmissionSimulationSpec) {
+	*out = *in
+	in.Workload.DeepCopyInto(&out.Workload)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionSimulationSpec.
+func (in *AdmissionSimulationSpec) DeepCopy() *AdmissionSimulationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionSimulationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionSimulationStatus) DeepCopyInto(out *AdmissionSimulationStatus) {
+	*out = *in
+	if in.PodSetAssignments != nil {
+		in, out := &in.PodSetAssignments, &out.PodSetAssignments
+		*out = make([]PodSetAssignmentSimulation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreemptionTargets != nil {
+		in, out := &in.PreemptionTargets, &out.PreemptionTargets
+		*out = make([]PreemptionTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionSimulationStatus.
+func (in *AdmissionSimulationStatus) DeepCopy() *AdmissionSimulationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionSimulationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterQueue) DeepCopyInto(out *ClusterQueue) {
 	*out = *in
@@ -211,3 +283,41 @@ func (in *PendingWorkloadsSummary) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSetAssignmentSimulation) DeepCopyInto(out *PodSetAssignmentSimulation) {
+	*out = *in
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSetAssignmentSimulation.
+func (in *PodSetAssignmentSimulation) DeepCopy() *PodSetAssignmentSimulation {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSetAssignmentSimulation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreemptionTarget) DeepCopyInto(out *PreemptionTarget) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreemptionTarget.
+func (in *PreemptionTarget) DeepCopy() *PreemptionTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PreemptionTarget)
+	in.DeepCopyInto(out)
+	return out
+}
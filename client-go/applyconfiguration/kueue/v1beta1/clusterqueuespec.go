@@ -28,13 +28,18 @@ type ClusterQueueSpecApplyConfiguration struct {
 	ResourceGroups          []ResourceGroupApplyConfiguration          `json:"resourceGroups,omitempty"`
 	Cohort                  *string                                    `json:"cohort,omitempty"`
 	QueueingStrategy        *kueuev1beta1.QueueingStrategy             `json:"queueingStrategy,omitempty"`
+	OrderingExpression      *string                                    `json:"orderingExpression,omitempty"`
+	Backfill                *BackfillApplyConfiguration                `json:"backfill,omitempty"`
 	NamespaceSelector       *v1.LabelSelectorApplyConfiguration        `json:"namespaceSelector,omitempty"`
 	FlavorFungibility       *FlavorFungibilityApplyConfiguration       `json:"flavorFungibility,omitempty"`
+	FlavorSelectionPolicy   *kueuev1beta1.FlavorSelectionPolicy        `json:"flavorSelectionPolicy,omitempty"`
 	Preemption              *ClusterQueuePreemptionApplyConfiguration  `json:"preemption,omitempty"`
 	AdmissionChecks         []string                                   `json:"admissionChecks,omitempty"`
 	AdmissionChecksStrategy *AdmissionChecksStrategyApplyConfiguration `json:"admissionChecksStrategy,omitempty"`
 	StopPolicy              *kueuev1beta1.StopPolicy                   `json:"stopPolicy,omitempty"`
 	FairSharing             *FairSharingApplyConfiguration             `json:"fairSharing,omitempty"`
+	LocalQueueFairSharing   *LocalQueueFairSharingApplyConfiguration   `json:"localQueueFairSharing,omitempty"`
+	PriorityAging           *PriorityAgingApplyConfiguration           `json:"priorityAging,omitempty"`
 }
 
 // ClusterQueueSpecApplyConfiguration constructs a declarative configuration of the ClusterQueueSpec type for use with
@@ -72,6 +77,22 @@ func (b *ClusterQueueSpecApplyConfiguration) WithQueueingStrategy(value kueuev1b
 	return b
 }
 
+// WithOrderingExpression sets the OrderingExpression field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OrderingExpression field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithOrderingExpression(value string) *ClusterQueueSpecApplyConfiguration {
+	b.OrderingExpression = &value
+	return b
+}
+
+// WithBackfill sets the Backfill field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Backfill field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithBackfill(value *BackfillApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	b.Backfill = value
+	return b
+}
+
 // WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the NamespaceSelector field is set to the value of the last call.
@@ -88,6 +109,14 @@ func (b *ClusterQueueSpecApplyConfiguration) WithFlavorFungibility(value *Flavor
 	return b
 }
 
+// WithFlavorSelectionPolicy sets the FlavorSelectionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FlavorSelectionPolicy field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithFlavorSelectionPolicy(value kueuev1beta1.FlavorSelectionPolicy) *ClusterQueueSpecApplyConfiguration {
+	b.FlavorSelectionPolicy = &value
+	return b
+}
+
 // WithPreemption sets the Preemption field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Preemption field is set to the value of the last call.
@@ -129,3 +158,19 @@ func (b *ClusterQueueSpecApplyConfiguration) WithFairSharing(value *FairSharingA
 	b.FairSharing = value
 	return b
 }
+
+// WithLocalQueueFairSharing sets the LocalQueueFairSharing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LocalQueueFairSharing field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithLocalQueueFairSharing(value *LocalQueueFairSharingApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	b.LocalQueueFairSharing = value
+	return b
+}
+
+// WithPriorityAging sets the PriorityAging field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityAging field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithPriorityAging(value *PriorityAgingApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	b.PriorityAging = value
+	return b
+}
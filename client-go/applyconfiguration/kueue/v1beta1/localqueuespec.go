@@ -26,6 +26,7 @@ import (
 type LocalQueueSpecApplyConfiguration struct {
 	ClusterQueue *v1beta1.ClusterQueueReference `json:"clusterQueue,omitempty"`
 	StopPolicy   *v1beta1.StopPolicy            `json:"stopPolicy,omitempty"`
+	FairSharing  *FairSharingApplyConfiguration `json:"fairSharing,omitempty"`
 }
 
 // LocalQueueSpecApplyConfiguration constructs a declarative configuration of the LocalQueueSpec type for use with
@@ -49,3 +50,11 @@ func (b *LocalQueueSpecApplyConfiguration) WithStopPolicy(value v1beta1.StopPoli
 	b.StopPolicy = &value
 	return b
 }
+
+// WithFairSharing sets the FairSharing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FairSharing field is set to the value of the last call.
+func (b *LocalQueueSpecApplyConfiguration) WithFairSharing(value *FairSharingApplyConfiguration) *LocalQueueSpecApplyConfiguration {
+	b.FairSharing = value
+	return b
+}
@@ -25,11 +25,12 @@ import (
 // PodSetAssignmentApplyConfiguration represents a declarative configuration of the PodSetAssignment type for use
 // with apply.
 type PodSetAssignmentApplyConfiguration struct {
-	Name               *string                                             `json:"name,omitempty"`
-	Flavors            map[v1.ResourceName]v1beta1.ResourceFlavorReference `json:"flavors,omitempty"`
-	ResourceUsage      *v1.ResourceList                                    `json:"resourceUsage,omitempty"`
-	Count              *int32                                              `json:"count,omitempty"`
-	TopologyAssignment *TopologyAssignmentApplyConfiguration               `json:"topologyAssignment,omitempty"`
+	Name                   *string                                             `json:"name,omitempty"`
+	Flavors                map[v1.ResourceName]v1beta1.ResourceFlavorReference `json:"flavors,omitempty"`
+	ResourceUsage          *v1.ResourceList                                    `json:"resourceUsage,omitempty"`
+	Count                  *int32                                              `json:"count,omitempty"`
+	TopologyAssignment     *TopologyAssignmentApplyConfiguration               `json:"topologyAssignment,omitempty"`
+	DelayedTopologyRequest *v1beta1.DelayedTopologyRequestState                `json:"delayedTopologyRequest,omitempty"`
 }
 
 // PodSetAssignmentApplyConfiguration constructs a declarative configuration of the PodSetAssignment type for use with
@@ -83,3 +84,11 @@ func (b *PodSetAssignmentApplyConfiguration) WithTopologyAssignment(value *Topol
 	b.TopologyAssignment = value
 	return b
 }
+
+// WithDelayedTopologyRequest sets the DelayedTopologyRequest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DelayedTopologyRequest field is set to the value of the last call.
+func (b *PodSetAssignmentApplyConfiguration) WithDelayedTopologyRequest(value v1beta1.DelayedTopologyRequestState) *PodSetAssignmentApplyConfiguration {
+	b.DelayedTopologyRequest = &value
+	return b
+}
@@ -19,6 +19,7 @@ package v1beta1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
 	v1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 )
 
@@ -29,6 +30,7 @@ type ResourceFlavorSpecApplyConfiguration struct {
 	NodeTaints   []v1.Taint                 `json:"nodeTaints,omitempty"`
 	Tolerations  []v1.Toleration            `json:"tolerations,omitempty"`
 	TopologyName *v1beta1.TopologyReference `json:"topologyName,omitempty"`
+	Cost         *resource.Quantity         `json:"cost,omitempty"`
 }
 
 // ResourceFlavorSpecApplyConfiguration constructs a declarative configuration of the ResourceFlavorSpec type for use with
@@ -78,3 +80,11 @@ func (b *ResourceFlavorSpecApplyConfiguration) WithTopologyName(value v1beta1.To
 	b.TopologyName = &value
 	return b
 }
+
+// WithCost sets the Cost field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cost field is set to the value of the last call.
+func (b *ResourceFlavorSpecApplyConfiguration) WithCost(value resource.Quantity) *ResourceFlavorSpecApplyConfiguration {
+	b.Cost = &value
+	return b
+}
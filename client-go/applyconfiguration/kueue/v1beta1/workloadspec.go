@@ -17,16 +17,22 @@ limitations under the License.
 
 package v1beta1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // WorkloadSpecApplyConfiguration represents a declarative configuration of the WorkloadSpec type for use
 // with apply.
 type WorkloadSpecApplyConfiguration struct {
-	PodSets                     []PodSetApplyConfiguration `json:"podSets,omitempty"`
-	QueueName                   *string                    `json:"queueName,omitempty"`
-	PriorityClassName           *string                    `json:"priorityClassName,omitempty"`
-	Priority                    *int32                     `json:"priority,omitempty"`
-	PriorityClassSource         *string                    `json:"priorityClassSource,omitempty"`
-	Active                      *bool                      `json:"active,omitempty"`
-	MaximumExecutionTimeSeconds *int32                     `json:"maximumExecutionTimeSeconds,omitempty"`
+	PodSets                     []PodSetApplyConfiguration             `json:"podSets,omitempty"`
+	QueueName                   *string                                `json:"queueName,omitempty"`
+	PriorityClassName           *string                                `json:"priorityClassName,omitempty"`
+	Priority                    *int32                                 `json:"priority,omitempty"`
+	PriorityClassSource         *string                                `json:"priorityClassSource,omitempty"`
+	Active                      *bool                                  `json:"active,omitempty"`
+	MaximumExecutionTimeSeconds *int32                                 `json:"maximumExecutionTimeSeconds,omitempty"`
+	CompletionDeadline          *v1.Time                               `json:"completionDeadline,omitempty"`
+	Dependencies                []WorkloadDependencyApplyConfiguration `json:"dependencies,omitempty"`
 }
 
 // WorkloadSpecApplyConfiguration constructs a declarative configuration of the WorkloadSpec type for use with
@@ -95,3 +101,24 @@ func (b *WorkloadSpecApplyConfiguration) WithMaximumExecutionTimeSeconds(value i
 	b.MaximumExecutionTimeSeconds = &value
 	return b
 }
+
+// WithCompletionDeadline sets the CompletionDeadline field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CompletionDeadline field is set to the value of the last call.
+func (b *WorkloadSpecApplyConfiguration) WithCompletionDeadline(value v1.Time) *WorkloadSpecApplyConfiguration {
+	b.CompletionDeadline = &value
+	return b
+}
+
+// WithDependencies adds the given value to the Dependencies field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Dependencies field.
+func (b *WorkloadSpecApplyConfiguration) WithDependencies(values ...*WorkloadDependencyApplyConfiguration) *WorkloadSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDependencies")
+		}
+		b.Dependencies = append(b.Dependencies, *values[i])
+	}
+	return b
+}
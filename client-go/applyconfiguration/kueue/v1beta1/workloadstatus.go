@@ -31,6 +31,8 @@ type WorkloadStatusApplyConfiguration struct {
 	AdmissionChecks                      []AdmissionCheckStateApplyConfiguration `json:"admissionChecks,omitempty"`
 	ResourceRequests                     []PodSetRequestApplyConfiguration       `json:"resourceRequests,omitempty"`
 	AccumulatedPastExexcutionTimeSeconds *int32                                  `json:"accumulatedPastExexcutionTimeSeconds,omitempty"`
+	LastSchedulingAttempt                *SchedulingAttemptApplyConfiguration    `json:"lastSchedulingAttempt,omitempty"`
+	EffectivePriority                    *int32                                  `json:"effectivePriority,omitempty"`
 }
 
 // WorkloadStatusApplyConfiguration constructs a declarative configuration of the WorkloadStatus type for use with
@@ -114,3 +116,19 @@ func (b *WorkloadStatusApplyConfiguration) WithAccumulatedPastExexcutionTimeSeco
 	b.AccumulatedPastExexcutionTimeSeconds = &value
 	return b
 }
+
+// WithLastSchedulingAttempt sets the LastSchedulingAttempt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastSchedulingAttempt field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithLastSchedulingAttempt(value *SchedulingAttemptApplyConfiguration) *WorkloadStatusApplyConfiguration {
+	b.LastSchedulingAttempt = value
+	return b
+}
+
+// WithEffectivePriority sets the EffectivePriority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EffectivePriority field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithEffectivePriority(value int32) *WorkloadStatusApplyConfiguration {
+	b.EffectivePriority = &value
+	return b
+}
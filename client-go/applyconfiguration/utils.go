@@ -35,6 +35,26 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=kueue.x-k8s.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("QuotaSchedule"):
+		return &kueuev1alpha1.QuotaScheduleApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("QuotaScheduleSpec"):
+		return &kueuev1alpha1.QuotaScheduleSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("QuotaScheduleStatus"):
+		return &kueuev1alpha1.QuotaScheduleStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("QuotaWindow"):
+		return &kueuev1alpha1.QuotaWindowApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Reservation"):
+		return &kueuev1alpha1.ReservationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ReservationSpec"):
+		return &kueuev1alpha1.ReservationSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ReservationStatus"):
+		return &kueuev1alpha1.ReservationStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ReservedFlavor"):
+		return &kueuev1alpha1.ReservedFlavorApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScheduledFlavorQuotas"):
+		return &kueuev1alpha1.ScheduledFlavorQuotasApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScheduledResourceQuota"):
+		return &kueuev1alpha1.ScheduledResourceQuotaApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Topology"):
 		return &kueuev1alpha1.TopologyApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("TopologyLevel"):
@@ -59,6 +79,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.AdmissionCheckStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheckStrategyRule"):
 		return &kueuev1beta1.AdmissionCheckStrategyRuleApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AdmissionFairSharingStatus"):
+		return &kueuev1beta1.AdmissionFairSharingStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("Backfill"):
+		return &kueuev1beta1.BackfillApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("BorrowWithinCohort"):
 		return &kueuev1beta1.BorrowWithinCohortApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueue"):
@@ -81,12 +105,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.FlavorFungibilityApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FlavorQuotas"):
 		return &kueuev1beta1.FlavorQuotasApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FlavorSchedulingAttempt"):
+		return &kueuev1beta1.FlavorSchedulingAttemptApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FlavorUsage"):
 		return &kueuev1beta1.FlavorUsageApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("KubeConfig"):
 		return &kueuev1beta1.KubeConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueue"):
 		return &kueuev1beta1.LocalQueueApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueFairSharing"):
+		return &kueuev1beta1.LocalQueueFairSharingApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueFlavorStatus"):
 		return &kueuev1beta1.LocalQueueFlavorStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueFlavorUsage"):
@@ -113,10 +141,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.PodSetAssignmentApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetRequest"):
 		return &kueuev1beta1.PodSetRequestApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PodSetSchedulingAttempt"):
+		return &kueuev1beta1.PodSetSchedulingAttemptApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetTopologyRequest"):
 		return &kueuev1beta1.PodSetTopologyRequestApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetUpdate"):
 		return &kueuev1beta1.PodSetUpdateApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PriorityAging"):
+		return &kueuev1beta1.PriorityAgingApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestConfig"):
 		return &kueuev1beta1.ProvisioningRequestConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestConfigSpec"):
@@ -135,14 +167,20 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.ResourceGroupApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceQuota"):
 		return &kueuev1beta1.ResourceQuotaApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ResourceSchedulingAttempt"):
+		return &kueuev1beta1.ResourceSchedulingAttemptApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceUsage"):
 		return &kueuev1beta1.ResourceUsageApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SchedulingAttempt"):
+		return &kueuev1beta1.SchedulingAttemptApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("TopologyAssignment"):
 		return &kueuev1beta1.TopologyAssignmentApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("TopologyDomainAssignment"):
 		return &kueuev1beta1.TopologyDomainAssignmentApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("Workload"):
 		return &kueuev1beta1.WorkloadApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkloadDependency"):
+		return &kueuev1beta1.WorkloadDependencyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkloadPriorityClass"):
 		return &kueuev1beta1.WorkloadPriorityClassApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkloadSpec"):
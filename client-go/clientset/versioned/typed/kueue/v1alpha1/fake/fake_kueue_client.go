@@ -27,6 +27,14 @@ type FakeKueueV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeKueueV1alpha1) QuotaSchedules() v1alpha1.QuotaScheduleInterface {
+	return &FakeQuotaSchedules{c}
+}
+
+func (c *FakeKueueV1alpha1) Reservations() v1alpha1.ReservationInterface {
+	return &FakeReservations{c}
+}
+
 func (c *FakeKueueV1alpha1) Topologies() v1alpha1.TopologyInterface {
 	return &FakeTopologies{c}
 }
@@ -17,4 +17,8 @@ limitations under the License.
 
 package v1alpha1
 
+type QuotaScheduleExpansion interface{}
+
+type ReservationExpansion interface{}
+
 type TopologyExpansion interface{}
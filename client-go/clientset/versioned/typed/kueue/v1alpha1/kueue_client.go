@@ -27,6 +27,8 @@ import (
 
 type KueueV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	QuotaSchedulesGetter
+	ReservationsGetter
 	TopologiesGetter
 }
 
@@ -35,6 +37,14 @@ type KueueV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *KueueV1alpha1Client) QuotaSchedules() QuotaScheduleInterface {
+	return newQuotaSchedules(c)
+}
+
+func (c *KueueV1alpha1Client) Reservations() ReservationInterface {
+	return newReservations(c)
+}
+
 func (c *KueueV1alpha1Client) Topologies() TopologyInterface {
 	return newTopologies(c)
 }
@@ -47,6 +47,7 @@ type ClusterQueueInterface interface {
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ClusterQueue, err error)
 	Apply(ctx context.Context, clusterQueue *visibilityv1beta1.ClusterQueueApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.ClusterQueue, err error)
 	GetPendingWorkloadsSummary(ctx context.Context, clusterQueueName string, options v1.GetOptions) (*v1beta1.PendingWorkloadsSummary, error)
+	CreateAdmissionSimulation(ctx context.Context, clusterQueueName string, admissionSimulation *v1beta1.AdmissionSimulation, opts v1.CreateOptions) (*v1beta1.AdmissionSimulation, error)
 
 	ClusterQueueExpansion
 }
@@ -81,3 +82,17 @@ func (c *clusterQueues) GetPendingWorkloadsSummary(ctx context.Context, clusterQ
 		Into(result)
 	return
 }
+
+// CreateAdmissionSimulation takes the representation of a admissionSimulation and creates it.  Returns the server's representation of the admissionSimulation, and an error, if there is any.
+func (c *clusterQueues) CreateAdmissionSimulation(ctx context.Context, clusterQueueName string, admissionSimulation *v1beta1.AdmissionSimulation, opts v1.CreateOptions) (result *v1beta1.AdmissionSimulation, err error) {
+	result = &v1beta1.AdmissionSimulation{}
+	err = c.GetClient().Post().
+		Resource("clusterqueues").
+		Name(clusterQueueName).
+		SubResource("admissionsimulation").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(admissionSimulation).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -159,3 +159,14 @@ func (c *FakeClusterQueues) GetPendingWorkloadsSummary(ctx context.Context, clus
 	}
 	return obj.(*v1beta1.PendingWorkloadsSummary), err
 }
+
+// CreateAdmissionSimulation takes the representation of a admissionSimulation and creates it.  Returns the server's representation of the admissionSimulation, and an error, if there is any.
+func (c *FakeClusterQueues) CreateAdmissionSimulation(ctx context.Context, clusterQueueName string, admissionSimulation *v1beta1.AdmissionSimulation, opts v1.CreateOptions) (result *v1beta1.AdmissionSimulation, err error) {
+	emptyResult := &v1beta1.AdmissionSimulation{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateSubresourceActionWithOptions(clusterqueuesResource, clusterQueueName, "admissionsimulation", admissionSimulation, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1beta1.AdmissionSimulation), err
+}
@@ -17,6 +17,14 @@ limitations under the License.
 
 package v1alpha1
 
+// QuotaScheduleListerExpansion allows custom methods to be added to
+// QuotaScheduleLister.
+type QuotaScheduleListerExpansion interface{}
+
+// ReservationListerExpansion allows custom methods to be added to
+// ReservationLister.
+type ReservationListerExpansion interface{}
+
 // TopologyListerExpansion allows custom methods to be added to
 // TopologyLister.
 type TopologyListerExpansion interface{}
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	zaplog "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueue-sim/simulator"
+)
+
+var (
+	scenarioFile = flag.String("scenario", "", "YAML file describing the ResourceFlavors, Cohorts, ClusterQueues, LocalQueues and, optionally, the workloads to simulate")
+
+	traceFile = flag.String("trace", "", "YAML list of Workloads recorded in a cluster, as output by `kubectl get workloads -A -o yaml`, replayed instead of the workloads of the scenario")
+
+	configFile = flag.String("config", "", "Kueue configuration file, for the fair sharing and scheduling settings")
+
+	output = flag.String("output", "table", "format of the statistics: table, json or yaml")
+
+	featureGates = flag.String("featureGates", "", "comma separated list of feature gates to set, e.g. ParallelCohortScheduling=true")
+)
+
+var (
+	scheme = runtime.NewScheme()
+)
+
+func init() {
+	utilruntime.Must(configapi.AddToScheme(scheme))
+}
+
+func main() {
+	os.Exit(mainWithExitCode())
+}
+
+func mainWithExitCode() int {
+	opts := zap.Options{
+		TimeEncoder: zapcore.RFC3339NanoTimeEncoder,
+		ZapOpts:     []zaplog.Option{zaplog.AddCaller()},
+		Development: true,
+		Level:       zaplog.NewAtomicLevelAt(zapcore.ErrorLevel),
+	}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+	log := zap.New(zap.UseFlagOptions(&opts))
+	ctrl.SetLogger(log)
+
+	if *scenarioFile == "" {
+		fmt.Fprintln(os.Stderr, "The --scenario flag is required")
+		flag.Usage()
+		return 2
+	}
+	switch *output {
+	case "table", "json", "yaml":
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown output format %q\n", *output)
+		return 2
+	}
+	if *featureGates != "" {
+		if err := utilfeature.DefaultMutableFeatureGate.Set(*featureGates); err != nil {
+			log.Error(err, "Unable to set the feature gates")
+			return 1
+		}
+	}
+	cfg, err := loadConfig(*configFile)
+	if err != nil {
+		log.Error(err, "Unable to load the configuration")
+		return 1
+	}
+	scenario, err := simulator.LoadScenario(*scenarioFile)
+	if err != nil {
+		log.Error(err, "Unable to load the scenario")
+		return 1
+	}
+	arrivals := scenario.Arrivals()
+	if *traceFile != "" {
+		var skipped int
+		arrivals, skipped, err = simulator.LoadTrace(*traceFile)
+		if err != nil {
+			log.Error(err, "Unable to load the trace")
+			return 1
+		}
+		if skipped > 0 {
+			fmt.Fprintf(os.Stderr, "Skipped %d workloads which didn't finish\n", skipped)
+		}
+	}
+
+	ctx := ctrl.LoggerInto(context.Background(), log)
+	sim, err := simulator.New(ctx, cfg, scenario, arrivals)
+	if err != nil {
+		log.Error(err, "Unable to set up the simulation")
+		return 1
+	}
+	report, err := sim.Run(ctx)
+	if err != nil {
+		log.Error(err, "Simulation failed")
+		return 1
+	}
+	if err := printReport(report); err != nil {
+		log.Error(err, "Unable to print the statistics")
+		return 1
+	}
+	return 0
+}
+
+// loadConfig decodes and defaults the configuration. Unlike the controller
+// manager, the simulator doesn't validate the sections it doesn't use, such as
+// the integrations.
+func loadConfig(path string) (*configapi.Configuration, error) {
+	cfg := &configapi.Configuration{}
+	if path == "" {
+		scheme.Default(cfg)
+		return cfg, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	codecs := serializer.NewCodecFactory(scheme, serializer.EnableStrict)
+	if err := runtime.DecodeInto(codecs.UniversalDecoder(), content, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func printReport(report *simulator.Report) error {
+	switch *output {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case "yaml":
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	default:
+		return report.PrintTable(os.Stdout)
+	}
+}
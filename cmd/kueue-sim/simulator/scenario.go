@@ -0,0 +1,274 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/webhooks"
+)
+
+const defaultNamespace = "default"
+
+// Scenario describes the queues of a simulation and, optionally, the
+// workloads submitted to them.
+type Scenario struct {
+	ResourceFlavors []kueue.ResourceFlavor `json:"resourceFlavors,omitempty"`
+	Cohorts         []kueuealpha.Cohort    `json:"cohorts,omitempty"`
+	ClusterQueues   []kueue.ClusterQueue   `json:"clusterQueues,omitempty"`
+	// LocalQueues default to a LocalQueue per ClusterQueue, with the same
+	// name, in the default namespace.
+	LocalQueues []kueue.LocalQueue `json:"localQueues,omitempty"`
+	Workloads   []WorkloadsSet     `json:"workloads,omitempty"`
+}
+
+// WorkloadsSet describes identical workloads submitted at a regular interval.
+type WorkloadsSet struct {
+	// Name is the prefix of the names of the workloads, which are suffixed
+	// with their index in the set.
+	Name string `json:"name"`
+	// Namespace defaults to the default namespace.
+	Namespace string `json:"namespace,omitempty"`
+	// QueueName is the name of the LocalQueue.
+	QueueName string `json:"queueName"`
+	Priority  int32  `json:"priority,omitempty"`
+	// Arrival is the submission time of the first workload, since the start
+	// of the simulation.
+	Arrival metav1.Duration `json:"arrival,omitempty"`
+	// Duration is the running time of the workloads once admitted.
+	Duration metav1.Duration `json:"duration"`
+	// Count defaults to 1.
+	Count int32 `json:"count,omitempty"`
+	// Interval is the time between the submissions of two workloads.
+	Interval metav1.Duration `json:"interval,omitempty"`
+	PodSets  []PodSet        `json:"podSets"`
+}
+
+// PodSet describes the pods of a workload.
+type PodSet struct {
+	// Name defaults to main.
+	Name string `json:"name,omitempty"`
+	// Count defaults to 1.
+	Count    int32               `json:"count,omitempty"`
+	Requests corev1.ResourceList `json:"requests"`
+}
+
+// Arrival is a workload submitted during the simulation.
+type Arrival struct {
+	Workload *kueue.Workload
+	// Time is the submission time, since the start of the simulation.
+	Time time.Duration
+	// Duration is the running time of the workload once admitted.
+	Duration time.Duration
+}
+
+// LoadScenario reads a scenario from a YAML file, and applies the defaults
+// which the API server would apply to the queues.
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Scenario{}
+	if err := yaml.UnmarshalStrict(data, s); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	if err := s.complete(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *Scenario) complete() error {
+	var errs []error
+	for i := range s.ResourceFlavors {
+		errs = append(errs, webhooks.ValidateResourceFlavor(&s.ResourceFlavors[i]).ToAggregate())
+	}
+	for i := range s.ClusterQueues {
+		cq := &s.ClusterQueues[i]
+		setClusterQueueDefaults(cq)
+		errs = append(errs, webhooks.ValidateClusterQueue(cq).ToAggregate())
+	}
+	if len(s.LocalQueues) == 0 {
+		for _, cq := range s.ClusterQueues {
+			s.LocalQueues = append(s.LocalQueues, kueue.LocalQueue{
+				ObjectMeta: metav1.ObjectMeta{Name: cq.Name, Namespace: defaultNamespace},
+				Spec:       kueue.LocalQueueSpec{ClusterQueue: kueue.ClusterQueueReference(cq.Name)},
+			})
+		}
+	}
+	for i := range s.LocalQueues {
+		lq := &s.LocalQueues[i]
+		lq.Namespace = cmp.Or(lq.Namespace, defaultNamespace)
+		if lq.Spec.StopPolicy == nil {
+			lq.Spec.StopPolicy = ptr.To(kueue.None)
+		}
+	}
+	for i, set := range s.Workloads {
+		if set.Name == "" || set.QueueName == "" {
+			errs = append(errs, fmt.Errorf("workloads[%d]: name and queueName are required", i))
+		}
+		if set.Count < 0 || set.Duration.Duration < 0 || set.Arrival.Duration < 0 || set.Interval.Duration < 0 {
+			errs = append(errs, fmt.Errorf("workloads[%d]: count, arrival, duration and interval must not be negative", i))
+		}
+		if len(set.PodSets) == 0 {
+			errs = append(errs, fmt.Errorf("workloads[%d]: at least one pod set is required", i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// setClusterQueueDefaults applies the defaults of the CRD, which aren't
+// applied when decoding the ClusterQueues outside of the API server.
+func setClusterQueueDefaults(cq *kueue.ClusterQueue) {
+	if cq.Spec.QueueingStrategy == "" {
+		cq.Spec.QueueingStrategy = kueue.BestEffortFIFO
+	}
+	if cq.Spec.FlavorFungibility == nil {
+		cq.Spec.FlavorFungibility = &kueue.FlavorFungibility{}
+	}
+	if cq.Spec.FlavorFungibility.WhenCanBorrow == "" {
+		cq.Spec.FlavorFungibility.WhenCanBorrow = kueue.Borrow
+	}
+	if cq.Spec.FlavorFungibility.WhenCanPreempt == "" {
+		cq.Spec.FlavorFungibility.WhenCanPreempt = kueue.TryNextFlavor
+	}
+	if cq.Spec.Preemption == nil {
+		cq.Spec.Preemption = &kueue.ClusterQueuePreemption{}
+	}
+	if cq.Spec.Preemption.ReclaimWithinCohort == "" {
+		cq.Spec.Preemption.ReclaimWithinCohort = kueue.PreemptionPolicyNever
+	}
+	if cq.Spec.Preemption.WithinClusterQueue == "" {
+		cq.Spec.Preemption.WithinClusterQueue = kueue.PreemptionPolicyNever
+	}
+	if cq.Spec.Preemption.BorrowWithinCohort == nil {
+		cq.Spec.Preemption.BorrowWithinCohort = &kueue.BorrowWithinCohort{}
+	}
+	if cq.Spec.Preemption.BorrowWithinCohort.Policy == "" {
+		cq.Spec.Preemption.BorrowWithinCohort.Policy = kueue.BorrowWithinCohortPolicyNever
+	}
+	if cq.Spec.StopPolicy == nil {
+		cq.Spec.StopPolicy = ptr.To(kueue.None)
+	}
+}
+
+// Arrivals returns the workloads of the scenario, ordered by submission time.
+func (s *Scenario) Arrivals() []Arrival {
+	var arrivals []Arrival
+	for _, set := range s.Workloads {
+		for i := range max(set.Count, 1) {
+			wl := &kueue.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-%d", set.Name, i),
+					Namespace: cmp.Or(set.Namespace, defaultNamespace),
+				},
+				Spec: kueue.WorkloadSpec{
+					QueueName: set.QueueName,
+					Priority:  ptr.To(set.Priority),
+				},
+			}
+			for _, ps := range set.PodSets {
+				wl.Spec.PodSets = append(wl.Spec.PodSets, kueue.PodSet{
+					Name:  cmp.Or(ps.Name, kueue.DefaultPodSetName),
+					Count: max(ps.Count, 1),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyNever,
+							Containers: []corev1.Container{{
+								Name:      "c",
+								Resources: corev1.ResourceRequirements{Requests: ps.Requests},
+							}},
+						},
+					},
+				})
+			}
+			arrivals = append(arrivals, Arrival{
+				Workload: wl,
+				Time:     set.Arrival.Duration + time.Duration(i)*set.Interval.Duration,
+				Duration: set.Duration.Duration,
+			})
+		}
+	}
+	sortArrivals(arrivals)
+	return arrivals
+}
+
+// LoadTrace reads the workloads recorded in a cluster, as listed by
+// `kubectl get workloads -A -o yaml`. The submission time of a workload is
+// its creation time, and its running time is the time between its last
+// quota reservation and its completion. The workloads which didn't finish
+// are skipped, as their running time is unknown.
+func LoadTrace(path string) ([]Arrival, int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	list := &kueue.WorkloadList{}
+	if err := yaml.Unmarshal(data, list); err != nil {
+		return nil, 0, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	var start time.Time
+	for _, wl := range list.Items {
+		if start.IsZero() || wl.CreationTimestamp.Time.Before(start) {
+			start = wl.CreationTimestamp.Time
+		}
+	}
+	var arrivals []Arrival
+	skipped := 0
+	for _, wl := range list.Items {
+		reserved := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
+		finished := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadFinished)
+		if reserved == nil || finished == nil || finished.Status != metav1.ConditionTrue {
+			skipped++
+			continue
+		}
+		arrivals = append(arrivals, Arrival{
+			Workload: &kueue.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      wl.Name,
+					Namespace: wl.Namespace,
+					Labels:    wl.Labels,
+				},
+				Spec: *wl.Spec.DeepCopy(),
+			},
+			Time:     wl.CreationTimestamp.Sub(start),
+			Duration: max(finished.LastTransitionTime.Sub(reserved.LastTransitionTime.Time), 0),
+		})
+	}
+	sortArrivals(arrivals)
+	return arrivals, skipped, nil
+}
+
+func sortArrivals(arrivals []Arrival) {
+	slices.SortStableFunc(arrivals, func(a, b Arrival) int {
+		return cmp.Compare(a.Time, b.Time)
+	})
+}
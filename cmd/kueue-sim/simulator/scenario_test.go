@@ -0,0 +1,315 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+func TestLoadScenario(t *testing.T) {
+	cases := map[string]struct {
+		content          string
+		wantClusterQueue *kueue.ClusterQueueSpec
+		wantLocalQueues  []string
+		wantArrivalTimes map[string]time.Duration
+		wantErr          bool
+	}{
+		"defaults": {
+			content: `
+resourceFlavors:
+- metadata:
+    name: default
+clusterQueues:
+- metadata:
+    name: cq
+  spec:
+    namespaceSelector: {}
+    resourceGroups:
+    - coveredResources: ["cpu"]
+      flavors:
+      - name: default
+        resources:
+        - name: cpu
+          nominalQuota: 4
+workloads:
+- name: wl
+  queueName: cq
+  arrival: 1m
+  count: 3
+  interval: 30s
+  duration: 5m
+  podSets:
+  - requests:
+      cpu: 1
+`,
+			wantClusterQueue: &kueue.ClusterQueueSpec{
+				NamespaceSelector: &metav1.LabelSelector{},
+				QueueingStrategy:  kueue.BestEffortFIFO,
+				FlavorFungibility: &kueue.FlavorFungibility{
+					WhenCanBorrow:  kueue.Borrow,
+					WhenCanPreempt: kueue.TryNextFlavor,
+				},
+				Preemption: &kueue.ClusterQueuePreemption{
+					ReclaimWithinCohort: kueue.PreemptionPolicyNever,
+					WithinClusterQueue:  kueue.PreemptionPolicyNever,
+					BorrowWithinCohort:  &kueue.BorrowWithinCohort{Policy: kueue.BorrowWithinCohortPolicyNever},
+				},
+				StopPolicy: ptr.To(kueue.None),
+			},
+			wantLocalQueues: []string{"default/cq"},
+			wantArrivalTimes: map[string]time.Duration{
+				"default/wl-0": time.Minute,
+				"default/wl-1": time.Minute + 30*time.Second,
+				"default/wl-2": 2 * time.Minute,
+			},
+		},
+		"explicit LocalQueues": {
+			content: `
+clusterQueues:
+- metadata:
+    name: cq
+  spec:
+    namespaceSelector: {}
+localQueues:
+- metadata:
+    name: lq
+    namespace: team
+  spec:
+    clusterQueue: cq
+`,
+			wantClusterQueue: &kueue.ClusterQueueSpec{
+				NamespaceSelector: &metav1.LabelSelector{},
+				QueueingStrategy:  kueue.BestEffortFIFO,
+				FlavorFungibility: &kueue.FlavorFungibility{
+					WhenCanBorrow:  kueue.Borrow,
+					WhenCanPreempt: kueue.TryNextFlavor,
+				},
+				Preemption: &kueue.ClusterQueuePreemption{
+					ReclaimWithinCohort: kueue.PreemptionPolicyNever,
+					WithinClusterQueue:  kueue.PreemptionPolicyNever,
+					BorrowWithinCohort:  &kueue.BorrowWithinCohort{Policy: kueue.BorrowWithinCohortPolicyNever},
+				},
+				StopPolicy: ptr.To(kueue.None),
+			},
+			wantLocalQueues: []string{"team/lq"},
+		},
+		"unknown field": {
+			content: `
+clusterQueue:
+- metadata:
+    name: cq
+`,
+			wantErr: true,
+		},
+		"invalid ClusterQueue": {
+			content: `
+clusterQueues:
+- metadata:
+    name: cq
+  spec:
+    namespaceSelector: {}
+    resourceGroups:
+    - coveredResources: ["cpu"]
+      flavors:
+      - name: default
+        resources:
+        - name: cpu
+          nominalQuota: -1
+`,
+			wantErr: true,
+		},
+		"workloads without queue": {
+			content: `
+workloads:
+- name: wl
+  duration: 5m
+  podSets:
+  - requests:
+      cpu: 1
+`,
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "scenario.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0o644); err != nil {
+				t.Fatalf("Unexpected error writing the scenario: %v", err)
+			}
+			got, err := LoadScenario(path)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error (want error: %t): %v", tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.wantClusterQueue, &got.ClusterQueues[0].Spec, cmpopts.IgnoreFields(kueue.ClusterQueueSpec{}, "ResourceGroups")); diff != "" {
+				t.Errorf("Unexpected ClusterQueue spec (-want,+got):\n%s", diff)
+			}
+			var gotLocalQueues []string
+			for _, lq := range got.LocalQueues {
+				gotLocalQueues = append(gotLocalQueues, lq.Namespace+"/"+lq.Name)
+			}
+			if diff := cmp.Diff(tc.wantLocalQueues, gotLocalQueues); diff != "" {
+				t.Errorf("Unexpected LocalQueues (-want,+got):\n%s", diff)
+			}
+			gotArrivalTimes := make(map[string]time.Duration)
+			for _, a := range got.Arrivals() {
+				gotArrivalTimes[a.Workload.Namespace+"/"+a.Workload.Name] = a.Time
+			}
+			if diff := cmp.Diff(tc.wantArrivalTimes, gotArrivalTimes, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected arrival times (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadTrace(t *testing.T) {
+	content := `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: Workload
+  metadata:
+    name: first
+    namespace: ns
+    creationTimestamp: "2024-12-02T10:00:00Z"
+  spec:
+    queueName: lq
+    podSets:
+    - name: main
+      count: 1
+      template:
+        spec:
+          containers:
+          - name: c
+            resources:
+              requests:
+                cpu: 1
+  status:
+    conditions:
+    - type: QuotaReserved
+      status: "True"
+      reason: QuotaReserved
+      message: ""
+      lastTransitionTime: "2024-12-02T10:01:00Z"
+    - type: Finished
+      status: "True"
+      reason: Succeeded
+      message: ""
+      lastTransitionTime: "2024-12-02T10:11:00Z"
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: Workload
+  metadata:
+    name: second
+    namespace: ns
+    creationTimestamp: "2024-12-02T10:05:00Z"
+  spec:
+    queueName: lq
+    podSets:
+    - name: main
+      count: 1
+      template:
+        spec:
+          containers:
+          - name: c
+            resources:
+              requests:
+                cpu: 1
+  status:
+    conditions:
+    - type: QuotaReserved
+      status: "True"
+      reason: QuotaReserved
+      message: ""
+      lastTransitionTime: "2024-12-02T10:05:00Z"
+    - type: Finished
+      status: "True"
+      reason: Succeeded
+      message: ""
+      lastTransitionTime: "2024-12-02T10:35:00Z"
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: Workload
+  metadata:
+    name: running
+    namespace: ns
+    creationTimestamp: "2024-12-02T10:06:00Z"
+  spec:
+    queueName: lq
+    podSets:
+    - name: main
+      count: 1
+      template:
+        spec:
+          containers:
+          - name: c
+  status:
+    conditions:
+    - type: QuotaReserved
+      status: "True"
+      reason: QuotaReserved
+      message: ""
+      lastTransitionTime: "2024-12-02T10:06:00Z"
+`
+	path := filepath.Join(t.TempDir(), "trace.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Unexpected error writing the trace: %v", err)
+	}
+	arrivals, skipped, err := LoadTrace(path)
+	if err != nil {
+		t.Fatalf("Unexpected error loading the trace: %v", err)
+	}
+	if skipped != 1 {
+		t.Errorf("Unexpected number of skipped workloads, want 1, got %d", skipped)
+	}
+	type arrival struct {
+		Name      string
+		QueueName string
+		Time      time.Duration
+		Duration  time.Duration
+	}
+	var got []arrival
+	for _, a := range arrivals {
+		if a.Workload.Status.Conditions != nil {
+			t.Errorf("Workload %s kept its status", a.Workload.Name)
+		}
+		got = append(got, arrival{
+			Name:      a.Workload.Name,
+			QueueName: a.Workload.Spec.QueueName,
+			Time:      a.Time,
+			Duration:  a.Duration,
+		})
+	}
+	want := []arrival{
+		{Name: "first", QueueName: "lq", Duration: 10 * time.Minute},
+		{Name: "second", QueueName: "lq", Time: 5 * time.Minute, Duration: 30 * time.Minute},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected arrivals (-want,+got):\n%s", diff)
+	}
+}
@@ -0,0 +1,419 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulator replays a timeline of workloads against the scheduler of
+// Kueue, the queue manager and the cache, with a fake clock and a fake
+// client, to evaluate the effect of a configuration on the wait time of the
+// workloads, the utilization of the quotas and the fairness between the
+// ClusterQueues.
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	"sigs.k8s.io/kueue/pkg/scheduler/framework"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// start is the time at which the simulations start. The simulations don't
+// depend on the current time, so that they are reproducible.
+var start = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Simulator runs the scheduler against a timeline of workloads.
+type Simulator struct {
+	clock     *testingclock.FakeClock
+	client    client.Client
+	cache     *cache.Cache
+	queues    *queue.Manager
+	scheduler *scheduler.Scheduler
+
+	arrivals []Arrival
+	// workloads holds the state of the submitted workloads, by key.
+	workloads map[string]*workloadState
+	stats     *stats
+
+	// patched holds the keys of the workloads whose status was patched,
+	// by the scheduler, since the last scheduling cycle.
+	patchedMu sync.Mutex
+	patched   sets.Set[string]
+}
+
+type workloadState struct {
+	key          types.NamespacedName
+	clusterQueue string
+	duration     time.Duration
+	pendingSince time.Time
+	waitTime     time.Duration
+	// finishTime is zero unless the workload reserved quota.
+	finishTime time.Time
+	admission  *kueue.Admission
+}
+
+func (w *workloadState) running() bool {
+	return !w.finishTime.IsZero()
+}
+
+// New prepares a simulation of the queues of the scenario, configured as in
+// cfg, to which the arrivals are submitted.
+func New(ctx context.Context, cfg *configapi.Configuration, scenario *Scenario, arrivals []Arrival) (*Simulator, error) {
+	s := &Simulator{
+		clock:     testingclock.NewFakeClock(start),
+		arrivals:  arrivals,
+		workloads: make(map[string]*workloadState, len(arrivals)),
+		stats:     newStats(scenario.ClusterQueues),
+		patched:   sets.New[string](),
+	}
+	s.client = utiltesting.NewClientBuilder().
+		WithStatusSubresource(&kueue.Workload{}).
+		WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: s.recordPatch}).
+		Build()
+	recorder := &record.FakeRecorder{}
+
+	cacheOptions := []cache.Option{cache.WithSimulationClock(s.clock)}
+	queueOptions := []queue.Option{queue.WithClock(s.clock)}
+	schedulerOptions := []scheduler.Option{scheduler.WithSimulationClock(s.clock)}
+	if cfg.Resources != nil && len(cfg.Resources.ExcludeResourcePrefixes) > 0 {
+		cacheOptions = append(cacheOptions, cache.WithExcludedResourcePrefixes(cfg.Resources.ExcludeResourcePrefixes))
+		queueOptions = append(queueOptions, queue.WithExcludedResourcePrefixes(cfg.Resources.ExcludeResourcePrefixes))
+	}
+	if features.Enabled(features.ConfigurableResourceTransformations) && cfg.Resources != nil && len(cfg.Resources.Transformations) > 0 {
+		cacheOptions = append(cacheOptions, cache.WithResourceTransformations(cfg.Resources.Transformations))
+		queueOptions = append(queueOptions, queue.WithResourceTransformations(cfg.Resources.Transformations))
+	}
+	if cfg.FairSharing != nil {
+		cacheOptions = append(cacheOptions, cache.WithFairSharing(cfg.FairSharing.Enable))
+		schedulerOptions = append(schedulerOptions, scheduler.WithFairSharing(cfg.FairSharing))
+	}
+	fw, err := framework.New(cfg.Scheduling, framework.Handle{Client: s.client, Recorder: recorder})
+	if err != nil {
+		return nil, fmt.Errorf("creating the scheduling framework: %w", err)
+	}
+	queueOptions = append(queueOptions, queue.WithWorkloadComparer(fw.CompareWorkloads))
+	schedulerOptions = append(schedulerOptions, scheduler.WithFramework(fw))
+	if cfg.Scheduling != nil && cfg.Scheduling.MaxAdmissionsPerCycle != nil {
+		schedulerOptions = append(schedulerOptions, scheduler.WithMaxAdmissionsPerCycle(*cfg.Scheduling.MaxAdmissionsPerCycle))
+	}
+	s.cache = cache.New(s.client, cacheOptions...)
+	s.queues = queue.NewManager(s.client, s.cache, queueOptions...)
+	s.scheduler = scheduler.New(s.queues, s.cache, s.client, recorder, schedulerOptions...)
+
+	if err := s.setupQueues(ctx, scenario); err != nil {
+		return nil, err
+	}
+	for _, a := range arrivals {
+		key := workload.Key(a.Workload)
+		if _, found := s.workloads[key]; found {
+			return nil, fmt.Errorf("workload %s is submitted more than once", key)
+		}
+		cqName, found := s.queues.ClusterQueueFromLocalQueue(workload.QueueKey(a.Workload))
+		if !found {
+			return nil, fmt.Errorf("workload %s is submitted to the missing LocalQueue %s", key, a.Workload.Spec.QueueName)
+		}
+		s.workloads[key] = &workloadState{
+			key:          client.ObjectKeyFromObject(a.Workload),
+			clusterQueue: cqName,
+			duration:     a.Duration,
+		}
+		s.stats.submitted(cqName)
+	}
+	return s, nil
+}
+
+func (s *Simulator) setupQueues(ctx context.Context, scenario *Scenario) error {
+	for i := range scenario.ResourceFlavors {
+		rf := scenario.ResourceFlavors[i].DeepCopy()
+		if err := s.client.Create(ctx, rf); err != nil {
+			return fmt.Errorf("creating ResourceFlavor %s: %w", rf.Name, err)
+		}
+		s.cache.AddOrUpdateResourceFlavor(rf)
+	}
+	for i := range scenario.Cohorts {
+		cohort := scenario.Cohorts[i].DeepCopy()
+		if err := s.client.Create(ctx, cohort); err != nil {
+			return fmt.Errorf("creating Cohort %s: %w", cohort.Name, err)
+		}
+		if err := s.cache.AddOrUpdateCohort(cohort); err != nil {
+			return fmt.Errorf("adding Cohort %s: %w", cohort.Name, err)
+		}
+		s.queues.AddOrUpdateCohort(ctx, cohort)
+	}
+	for i := range scenario.ClusterQueues {
+		cq := scenario.ClusterQueues[i].DeepCopy()
+		if err := s.client.Create(ctx, cq); err != nil {
+			return fmt.Errorf("creating ClusterQueue %s: %w", cq.Name, err)
+		}
+		if err := s.cache.AddClusterQueue(ctx, cq); err != nil {
+			return fmt.Errorf("adding ClusterQueue %s: %w", cq.Name, err)
+		}
+		if err := s.queues.AddClusterQueue(ctx, cq); err != nil {
+			return fmt.Errorf("adding ClusterQueue %s: %w", cq.Name, err)
+		}
+	}
+	namespaces := sets.New[string]()
+	for _, a := range s.arrivals {
+		namespaces.Insert(a.Workload.Namespace)
+	}
+	for i := range scenario.LocalQueues {
+		namespaces.Insert(scenario.LocalQueues[i].Namespace)
+	}
+	for _, ns := range sets.List(namespaces) {
+		if err := s.client.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}}); err != nil {
+			return fmt.Errorf("creating Namespace %s: %w", ns, err)
+		}
+	}
+	for i := range scenario.LocalQueues {
+		lq := scenario.LocalQueues[i].DeepCopy()
+		if err := s.client.Create(ctx, lq); err != nil {
+			return fmt.Errorf("creating LocalQueue %s/%s: %w", lq.Namespace, lq.Name, err)
+		}
+		if err := s.cache.AddLocalQueue(lq); err != nil {
+			return fmt.Errorf("adding LocalQueue %s/%s: %w", lq.Namespace, lq.Name, err)
+		}
+		if err := s.queues.AddLocalQueue(ctx, lq); err != nil {
+			return fmt.Errorf("adding LocalQueue %s/%s: %w", lq.Namespace, lq.Name, err)
+		}
+	}
+	return nil
+}
+
+// recordPatch applies the status patches of the scheduler, which are server
+// side apply patches, and records the patched workloads to process the
+// admissions and the preemptions after the cycle, as the controllers would.
+func (s *Simulator) recordPatch(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if err := utiltesting.TreatSSAAsStrategicMerge(ctx, c, subResourceName, obj, patch, opts...); err != nil {
+		return err
+	}
+	if wl, isWorkload := obj.(*kueue.Workload); isWorkload {
+		s.patchedMu.Lock()
+		s.patched.Insert(workload.Key(wl))
+		s.patchedMu.Unlock()
+	}
+	return nil
+}
+
+// Run submits the workloads at their arrival time, and runs scheduling
+// cycles until no workload is running and no more workloads are to be
+// submitted. The workloads which are still pending at the end of the
+// simulation couldn't be admitted.
+func (s *Simulator) Run(ctx context.Context) (*Report, error) {
+	log := ctrl.LoggerFrom(ctx)
+	next := 0
+	for {
+		now := s.clock.Now()
+		for ; next < len(s.arrivals) && !start.Add(s.arrivals[next].Time).After(now); next++ {
+			if err := s.submit(ctx, s.arrivals[next].Workload); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.finishWorkloads(ctx); err != nil {
+			return nil, err
+		}
+		if err := s.schedule(ctx); err != nil {
+			return nil, err
+		}
+
+		nextTime := s.nextFinishTime()
+		if next < len(s.arrivals) {
+			if arrival := start.Add(s.arrivals[next].Time); nextTime.IsZero() || arrival.Before(nextTime) {
+				nextTime = arrival
+			}
+		}
+		if nextTime.IsZero() {
+			break
+		}
+		log.V(3).Info("Advancing the clock", "time", nextTime)
+		s.stats.advance(nextTime.Sub(now))
+		s.clock.SetTime(nextTime)
+	}
+	return s.stats.report(s.clock.Since(start)), nil
+}
+
+func (s *Simulator) submit(ctx context.Context, wl *kueue.Workload) error {
+	wl = wl.DeepCopy()
+	wl.CreationTimestamp = metav1.NewTime(s.clock.Now())
+	// The UIDs break the ties when ordering the preemption candidates.
+	wl.UID = types.UID(workload.Key(wl))
+	if err := s.client.Create(ctx, wl); err != nil {
+		return fmt.Errorf("creating Workload %s: %w", workload.Key(wl), err)
+	}
+	s.workloads[workload.Key(wl)].pendingSince = s.clock.Now()
+	return s.queues.AddOrUpdateWorkload(wl)
+}
+
+// schedule runs scheduling cycles until the queues are empty, or until the
+// cycles stop making progress: the heads which are neither admitted nor
+// inadmissible, for instance in the StrictFIFO ClusterQueues, would be
+// evaluated again and again until the next event.
+func (s *Simulator) schedule(ctx context.Context) error {
+	lastActive := -1
+	for s.scheduler.RunCycle(ctx) {
+		s.stats.cycles++
+		progress, err := s.syncWorkloads(ctx)
+		if err != nil {
+			return err
+		}
+		if progress {
+			lastActive = -1
+			continue
+		}
+		active := 0
+		for _, keys := range s.queues.Dump() {
+			active += len(keys)
+		}
+		if lastActive >= 0 && active >= lastActive {
+			return nil
+		}
+		lastActive = active
+	}
+	return nil
+}
+
+// syncWorkloads processes the admissions and the preemptions of the last
+// cycle. It returns whether any workload was admitted or preempted.
+func (s *Simulator) syncWorkloads(ctx context.Context) (bool, error) {
+	s.patchedMu.Lock()
+	keys := sets.List(s.patched)
+	s.patched.Clear()
+	s.patchedMu.Unlock()
+
+	progress := false
+	for _, key := range keys {
+		state := s.workloads[key]
+		wl := &kueue.Workload{}
+		if err := s.client.Get(ctx, state.key, wl); err != nil {
+			return false, err
+		}
+		switch reserved := workload.HasQuotaReservation(wl); {
+		case reserved && !state.running():
+			if err := s.admit(ctx, state, wl); err != nil {
+				return false, err
+			}
+			progress = true
+		case reserved && workload.IsEvicted(wl):
+			if err := s.evict(ctx, state, wl); err != nil {
+				return false, err
+			}
+			progress = true
+		}
+	}
+	return progress, nil
+}
+
+func (s *Simulator) admit(ctx context.Context, state *workloadState, wl *kueue.Workload) error {
+	now := s.clock.Now()
+	state.waitTime += now.Sub(state.pendingSince)
+	state.finishTime = now.Add(state.duration)
+	state.admission = wl.Status.Admission
+	s.stats.admitted(state.clusterQueue, state.admission)
+
+	// The conditions are set with the current time, rather than the time of
+	// the simulation, which orders the preemption candidates.
+	for _, condType := range []string{kueue.WorkloadQuotaReserved, kueue.WorkloadAdmitted} {
+		if cond := apimeta.FindStatusCondition(wl.Status.Conditions, condType); cond != nil {
+			cond.LastTransitionTime = metav1.NewTime(now)
+		}
+	}
+	if err := s.client.Status().Update(ctx, wl); err != nil {
+		return fmt.Errorf("updating Workload %s: %w", workload.Key(wl), err)
+	}
+	// The workload controller replaces the assumed workload by the admitted
+	// one.
+	s.cache.AddOrUpdateWorkload(wl)
+	return nil
+}
+
+// evict releases the quota of a preempted workload and requeues it, as the
+// job and workload controllers would once its pods are terminated.
+func (s *Simulator) evict(ctx context.Context, state *workloadState, wl *kueue.Workload) error {
+	now := s.clock.Now()
+	s.stats.evicted(state.clusterQueue, state.admission)
+	state.finishTime = time.Time{}
+	state.admission = nil
+	state.pendingSince = now
+
+	evicted := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadEvicted)
+	workload.UnsetQuotaReservationWithCondition(wl, "Pending", evicted.Message, now)
+	if err := s.client.Status().Update(ctx, wl); err != nil {
+		return fmt.Errorf("releasing the quota of Workload %s: %w", workload.Key(wl), err)
+	}
+	s.queues.QueueAssociatedInadmissibleWorkloadsAfter(ctx, wl, func() {
+		_ = s.cache.DeleteWorkload(wl)
+		_ = s.queues.AddOrUpdateWorkloadWithoutLock(wl)
+	})
+	return nil
+}
+
+// finishWorkloads removes the workloads which completed their running time.
+func (s *Simulator) finishWorkloads(ctx context.Context) error {
+	now := s.clock.Now()
+	var finished []*workloadState
+	for _, state := range s.workloads {
+		if state.running() && !state.finishTime.After(now) {
+			finished = append(finished, state)
+		}
+	}
+	slices.SortFunc(finished, func(a, b *workloadState) int {
+		return a.finishTime.Compare(b.finishTime)
+	})
+	for _, state := range finished {
+		wl := &kueue.Workload{}
+		if err := s.client.Get(ctx, state.key, wl); err != nil {
+			return err
+		}
+		if err := s.client.Delete(ctx, wl); err != nil {
+			return fmt.Errorf("deleting Workload %s: %w", workload.Key(wl), err)
+		}
+		s.stats.finished(state.clusterQueue, state.admission, state.waitTime)
+		state.finishTime = time.Time{}
+		state.admission = nil
+		s.queues.QueueAssociatedInadmissibleWorkloadsAfter(ctx, wl, func() {
+			_ = s.cache.DeleteWorkload(wl)
+		})
+	}
+	return nil
+}
+
+func (s *Simulator) nextFinishTime() time.Time {
+	var next time.Time
+	for _, state := range s.workloads {
+		if state.running() && (next.IsZero() || state.finishTime.Before(next)) {
+			next = state.finishTime
+		}
+	}
+	return next
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestRun(t *testing.T) {
+	cpuWorkloads := func(name, queue string, arrival time.Duration, count int32, duration time.Duration) WorkloadsSet {
+		return WorkloadsSet{
+			Name:      name,
+			QueueName: queue,
+			Arrival:   metav1.Duration{Duration: arrival},
+			Count:     count,
+			Duration:  metav1.Duration{Duration: duration},
+			PodSets:   []PodSet{{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}}},
+		}
+	}
+	minutes := func(m int) metav1.Duration {
+		return metav1.Duration{Duration: time.Duration(m) * time.Minute}
+	}
+
+	cases := map[string]struct {
+		clusterQueues []kueue.ClusterQueue
+		workloads     []WorkloadsSet
+		want          *Report
+	}{
+		"workloads wait for the quota of the ClusterQueue": {
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("cq").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+			},
+			workloads: []WorkloadsSet{cpuWorkloads("wl", "cq", 0, 2, 10*time.Minute)},
+			want: &Report{
+				Duration:      minutes(20),
+				FairnessIndex: 1,
+				Total: ClusterQueueReport{
+					Submitted: 2,
+					Finished:  2,
+					WaitTime: WaitTimeReport{
+						Mean: minutes(5),
+						P90:  minutes(10),
+						P99:  minutes(10),
+						Max:  minutes(10),
+					},
+					Utilization: map[corev1.ResourceName]float64{corev1.ResourceCPU: 1},
+				},
+				ClusterQueues: []ClusterQueueReport{{
+					Name:      "cq",
+					Submitted: 2,
+					Finished:  2,
+					WaitTime: WaitTimeReport{
+						Mean: minutes(5),
+						P90:  minutes(10),
+						P99:  minutes(10),
+						Max:  minutes(10),
+					},
+					Utilization: map[corev1.ResourceName]float64{corev1.ResourceCPU: 1},
+					Share:       1,
+				}},
+			},
+		},
+		"borrowed quota is reclaimed within the cohort": {
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("cq-a").
+					Cohort("cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("cq-b").
+					Cohort("cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+					Preemption(kueue.ClusterQueuePreemption{ReclaimWithinCohort: kueue.PreemptionPolicyAny}).
+					Obj(),
+			},
+			// cq-a borrows the quota of cq-b until cq-b reclaims it, from
+			// 10m to 20m, which delays the preempted workload until 80m.
+			workloads: []WorkloadsSet{
+				cpuWorkloads("a", "cq-a", 0, 2, time.Hour),
+				cpuWorkloads("b", "cq-b", 10*time.Minute, 1, 10*time.Minute),
+			},
+			want: &Report{
+				Duration:      minutes(80),
+				FairnessIndex: 1.75 * 1.75 / (2 * (1.625*1.625 + 0.125*0.125)),
+				Total: ClusterQueueReport{
+					Submitted:   3,
+					Finished:    3,
+					Preemptions: 1,
+					WaitTime: WaitTimeReport{
+						Mean: metav1.Duration{Duration: 10 * time.Minute / 3},
+						P90:  minutes(10),
+						P99:  minutes(10),
+						Max:  minutes(10),
+					},
+					Utilization: map[corev1.ResourceName]float64{corev1.ResourceCPU: 0.875},
+				},
+				ClusterQueues: []ClusterQueueReport{
+					{
+						Name:        "cq-a",
+						Submitted:   2,
+						Finished:    2,
+						Preemptions: 1,
+						WaitTime: WaitTimeReport{
+							Mean: minutes(5),
+							P90:  minutes(10),
+							P99:  minutes(10),
+							Max:  minutes(10),
+						},
+						Utilization: map[corev1.ResourceName]float64{corev1.ResourceCPU: 1.625},
+						Share:       1.625,
+					},
+					{
+						Name:        "cq-b",
+						Submitted:   1,
+						Finished:    1,
+						Utilization: map[corev1.ResourceName]float64{corev1.ResourceCPU: 0.125},
+						Share:       0.125,
+					},
+				},
+			},
+		},
+		"workloads which never fit remain pending": {
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("cq").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+					Obj(),
+			},
+			workloads: []WorkloadsSet{cpuWorkloads("wl", "cq", time.Minute, 1, 10*time.Minute)},
+			want: &Report{
+				Duration:      minutes(1),
+				FairnessIndex: 1,
+				Total: ClusterQueueReport{
+					Submitted:   1,
+					Pending:     1,
+					Utilization: map[corev1.ResourceName]float64{corev1.ResourceCPU: 0},
+				},
+				ClusterQueues: []ClusterQueueReport{{
+					Name:        "cq",
+					Submitted:   1,
+					Pending:     1,
+					Utilization: map[corev1.ResourceName]float64{corev1.ResourceCPU: 0},
+				}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			scenario := &Scenario{
+				ResourceFlavors: []kueue.ResourceFlavor{*utiltesting.MakeResourceFlavor("default").Obj()},
+				ClusterQueues:   tc.clusterQueues,
+				Workloads:       tc.workloads,
+			}
+			if err := scenario.complete(); err != nil {
+				t.Fatalf("Invalid scenario: %v", err)
+			}
+			sim, err := New(ctx, &configapi.Configuration{}, scenario, scenario.Arrivals())
+			if err != nil {
+				t.Fatalf("Unexpected error setting up the simulation: %v", err)
+			}
+			got, err := sim.Run(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error running the simulation: %v", err)
+			}
+			opts := []cmp.Option{
+				cmpopts.IgnoreFields(Report{}, "Cycles"),
+				cmpopts.EquateApprox(0, 1e-9),
+			}
+			if diff := cmp.Diff(tc.want, got, opts...); diff != "" {
+				t.Errorf("Unexpected report (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// Report holds the statistics of a simulation.
+type Report struct {
+	// Duration is the simulated time, until the last workload finished.
+	Duration metav1.Duration `json:"duration"`
+	// Cycles is the number of scheduling cycles.
+	Cycles int64 `json:"cycles"`
+	// FairnessIndex is the Jain's fairness index of the shares of the
+	// ClusterQueues to which workloads were submitted. It ranges from 1/n,
+	// when a single ClusterQueue used its quota, to 1, when all of them used
+	// the same fraction of their quota.
+	FairnessIndex float64              `json:"fairnessIndex"`
+	Total         ClusterQueueReport   `json:"total"`
+	ClusterQueues []ClusterQueueReport `json:"clusterQueues"`
+}
+
+// ClusterQueueReport holds the statistics of the workloads of a ClusterQueue.
+type ClusterQueueReport struct {
+	Name      string `json:"name,omitempty"`
+	Submitted int    `json:"submitted"`
+	Finished  int    `json:"finished"`
+	// Pending is the number of workloads which were never admitted, or which
+	// weren't admitted again after being preempted.
+	Pending     int `json:"pending"`
+	Preemptions int `json:"preemptions"`
+	// WaitTime is the distribution of the times spent pending by the
+	// finished workloads, including after being preempted.
+	WaitTime WaitTimeReport `json:"waitTime"`
+	// Utilization is the average usage of each resource, as a fraction of
+	// the nominal quota. It exceeds 1 when the ClusterQueue borrows.
+	Utilization map[corev1.ResourceName]float64 `json:"utilization,omitempty"`
+	// Share is the average of the dominant share of the ClusterQueue: the
+	// highest utilization among the resources.
+	Share float64 `json:"share,omitempty"`
+}
+
+// WaitTimeReport describes a distribution of wait times.
+type WaitTimeReport struct {
+	Mean metav1.Duration `json:"mean"`
+	P50  metav1.Duration `json:"p50"`
+	P90  metav1.Duration `json:"p90"`
+	P99  metav1.Duration `json:"p99"`
+	Max  metav1.Duration `json:"max"`
+}
+
+// PrintTable writes the report as a table.
+func (r *Report) PrintTable(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "Simulated time: %s, scheduling cycles: %d, fairness index: %.2f\n\n", r.Duration.Duration, r.Cycles, r.FairnessIndex); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "CLUSTERQUEUE\tSUBMITTED\tFINISHED\tPENDING\tPREEMPTIONS\tWAIT MEAN\tWAIT P50\tWAIT P90\tWAIT MAX\tSHARE\tUTILIZATION")
+	for _, cq := range slices.Concat(r.ClusterQueues, []ClusterQueueReport{r.Total}) {
+		name, share := cq.Name, fmt.Sprintf("%.0f%%", 100*cq.Share)
+		if name == "" {
+			name, share = "(total)", "-"
+		}
+		var utilization []string
+		for _, res := range slices.Sorted(maps.Keys(cq.Utilization)) {
+			utilization = append(utilization, fmt.Sprintf("%s=%.0f%%", res, 100*cq.Utilization[res]))
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			name, cq.Submitted, cq.Finished, cq.Pending, cq.Preemptions,
+			cq.WaitTime.Mean.Duration, cq.WaitTime.P50.Duration, cq.WaitTime.P90.Duration, cq.WaitTime.Max.Duration,
+			share, strings.Join(utilization, ","))
+	}
+	return tw.Flush()
+}
+
+// stats integrates the usage of the ClusterQueues over the simulated time.
+type stats struct {
+	cycles        int64
+	clusterQueues []*clusterQueueStats
+	byName        map[string]*clusterQueueStats
+}
+
+type clusterQueueStats struct {
+	name        string
+	submitted   int
+	finished    int
+	preemptions int
+	waitTimes   []time.Duration
+
+	nominal map[corev1.ResourceName]float64
+	usage   map[corev1.ResourceName]float64
+	// usageTime is the integral of the usage over time, in seconds.
+	usageTime map[corev1.ResourceName]float64
+	// shareTime is the integral of the dominant share over time, in seconds.
+	shareTime float64
+}
+
+func newStats(clusterQueues []kueue.ClusterQueue) *stats {
+	s := &stats{byName: make(map[string]*clusterQueueStats, len(clusterQueues))}
+	for _, cq := range clusterQueues {
+		cqs := &clusterQueueStats{
+			name:      cq.Name,
+			nominal:   make(map[corev1.ResourceName]float64),
+			usage:     make(map[corev1.ResourceName]float64),
+			usageTime: make(map[corev1.ResourceName]float64),
+		}
+		for _, rg := range cq.Spec.ResourceGroups {
+			for _, fq := range rg.Flavors {
+				for _, r := range fq.Resources {
+					cqs.nominal[r.Name] += r.NominalQuota.AsApproximateFloat64()
+				}
+			}
+		}
+		s.clusterQueues = append(s.clusterQueues, cqs)
+		s.byName[cq.Name] = cqs
+	}
+	return s
+}
+
+func (s *stats) submitted(cq string) {
+	s.byName[cq].submitted++
+}
+
+func (s *stats) admitted(cq string, admission *kueue.Admission) {
+	s.byName[cq].addUsage(admission, 1)
+}
+
+func (s *stats) evicted(cq string, admission *kueue.Admission) {
+	cqs := s.byName[cq]
+	cqs.preemptions++
+	cqs.addUsage(admission, -1)
+}
+
+func (s *stats) finished(cq string, admission *kueue.Admission, waitTime time.Duration) {
+	cqs := s.byName[cq]
+	cqs.finished++
+	cqs.waitTimes = append(cqs.waitTimes, waitTime)
+	cqs.addUsage(admission, -1)
+}
+
+func (c *clusterQueueStats) addUsage(admission *kueue.Admission, sign float64) {
+	for _, psa := range admission.PodSetAssignments {
+		for r, q := range psa.ResourceUsage {
+			c.usage[r] += sign * q.AsApproximateFloat64()
+		}
+	}
+}
+
+// advance accounts for the usage of the ClusterQueues during d.
+func (s *stats) advance(d time.Duration) {
+	seconds := d.Seconds()
+	for _, cqs := range s.clusterQueues {
+		for r, u := range cqs.usage {
+			cqs.usageTime[r] += u * seconds
+		}
+		cqs.shareTime += cqs.dominantShare() * seconds
+	}
+}
+
+func (c *clusterQueueStats) dominantShare() float64 {
+	var share float64
+	for r, u := range c.usage {
+		if nominal := c.nominal[r]; nominal > 0 {
+			share = max(share, u/nominal)
+		}
+	}
+	return share
+}
+
+func (s *stats) report(d time.Duration) *Report {
+	r := &Report{
+		Duration: metav1.Duration{Duration: d},
+		Cycles:   s.cycles,
+	}
+	total := &clusterQueueStats{
+		nominal:   make(map[corev1.ResourceName]float64),
+		usageTime: make(map[corev1.ResourceName]float64),
+	}
+	var shares []float64
+	for _, cqs := range s.clusterQueues {
+		cqReport := cqs.report(d)
+		r.ClusterQueues = append(r.ClusterQueues, cqReport)
+		if cqs.submitted > 0 {
+			shares = append(shares, cqReport.Share)
+		}
+		total.submitted += cqs.submitted
+		total.finished += cqs.finished
+		total.preemptions += cqs.preemptions
+		total.waitTimes = append(total.waitTimes, cqs.waitTimes...)
+		for res, n := range cqs.nominal {
+			total.nominal[res] += n
+		}
+		for res, u := range cqs.usageTime {
+			total.usageTime[res] += u
+		}
+	}
+	r.Total = total.report(d)
+	r.FairnessIndex = jainIndex(shares)
+	return r
+}
+
+func (c *clusterQueueStats) report(d time.Duration) ClusterQueueReport {
+	r := ClusterQueueReport{
+		Name:        c.name,
+		Submitted:   c.submitted,
+		Finished:    c.finished,
+		Pending:     c.submitted - c.finished,
+		Preemptions: c.preemptions,
+		WaitTime:    waitTimeReport(c.waitTimes),
+	}
+	if seconds := d.Seconds(); seconds > 0 {
+		r.Share = c.shareTime / seconds
+		for res, n := range c.nominal {
+			if n > 0 {
+				if r.Utilization == nil {
+					r.Utilization = make(map[corev1.ResourceName]float64, len(c.nominal))
+				}
+				r.Utilization[res] = c.usageTime[res] / seconds / n
+			}
+		}
+	}
+	return r
+}
+
+func waitTimeReport(waitTimes []time.Duration) WaitTimeReport {
+	if len(waitTimes) == 0 {
+		return WaitTimeReport{}
+	}
+	sorted := slices.Clone(waitTimes)
+	slices.Sort(sorted)
+	var sum time.Duration
+	for _, w := range sorted {
+		sum += w
+	}
+	return WaitTimeReport{
+		Mean: metav1.Duration{Duration: sum / time.Duration(len(sorted))},
+		P50:  metav1.Duration{Duration: percentile(sorted, 50)},
+		P90:  metav1.Duration{Duration: percentile(sorted, 90)},
+		P99:  metav1.Duration{Duration: percentile(sorted, 99)},
+		Max:  metav1.Duration{Duration: sorted[len(sorted)-1]},
+	}
+}
+
+// percentile returns the nearest-rank percentile p of the sorted values.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// jainIndex returns the Jain's fairness index of the values, or 1 if all the
+// values are zero.
+func jainIndex(values []float64) float64 {
+	var sum, sumSquares float64
+	for _, v := range values {
+		sum += v
+		sumSquares += v * v
+	}
+	if sumSquares == 0 {
+		return 1
+	}
+	return sum * sum / (float64(len(values)) * sumSquares)
+}
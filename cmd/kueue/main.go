@@ -41,6 +41,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
@@ -62,6 +63,8 @@ import (
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/scheduler"
+	"sigs.k8s.io/kueue/pkg/scheduler/framework"
+	"sigs.k8s.io/kueue/pkg/scheduler/trace"
 	"sigs.k8s.io/kueue/pkg/util/cert"
 	"sigs.k8s.io/kueue/pkg/util/kubeversion"
 	"sigs.k8s.io/kueue/pkg/util/useragent"
@@ -195,6 +198,18 @@ func main() {
 	if cfg.FairSharing != nil {
 		cacheOptions = append(cacheOptions, cache.WithFairSharing(cfg.FairSharing.Enable))
 	}
+	if features.Enabled(features.AdmissionFairSharing) && cfg.AdmissionFairSharing != nil {
+		cacheOptions = append(cacheOptions, cache.WithAdmissionFairSharing(cfg.AdmissionFairSharing))
+	}
+	schedulingFramework, err := framework.New(cfg.Scheduling, framework.Handle{
+		Client:   mgr.GetClient(),
+		Recorder: mgr.GetEventRecorderFor(constants.AdmissionName),
+	})
+	if err != nil {
+		setupLog.Error(err, "Unable to create the scheduling framework")
+		os.Exit(1)
+	}
+	queueOptions = append(queueOptions, queue.WithWorkloadComparer(schedulingFramework.CompareWorkloads))
 	cCache := cache.New(mgr.GetClient(), cacheOptions...)
 	queues := queue.NewManager(mgr.GetClient(), cCache, queueOptions...)
 
@@ -203,7 +218,12 @@ func main() {
 		setupLog.Error(err, "Unable to setup indexes")
 		os.Exit(1)
 	}
-	debugger.NewDumper(cCache, queues).ListenForSignal(ctx)
+	dumper := debugger.NewDumper(cCache, queues)
+	dumper.ListenForSignal(ctx)
+	if err := mgr.AddMetricsServerExtraHandler(debugger.HandlerPath, dumper); err != nil {
+		setupLog.Error(err, "Unable to serve the cache and queues dumps")
+		os.Exit(1)
+	}
 
 	serverVersionFetcher := setupServerVersionFetcher(mgr, kubeConfig)
 
@@ -216,12 +236,12 @@ func main() {
 	go queues.CleanUpOnContext(ctx)
 	go cCache.CleanUpOnContext(ctx)
 
+	sched := setupScheduler(mgr, cCache, queues, &cfg, schedulingFramework)
+
 	if features.Enabled(features.VisibilityOnDemand) {
-		go visibility.CreateAndStartVisibilityServer(ctx, queues)
+		go visibility.CreateAndStartVisibilityServer(ctx, queues, sched)
 	}
 
-	setupScheduler(mgr, cCache, queues, &cfg)
-
 	setupLog.Info("Starting manager")
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "Could not run manager")
@@ -375,19 +395,56 @@ func setupProbeEndpoints(mgr ctrl.Manager, certsReady <-chan struct{}) {
 	}
 }
 
-func setupScheduler(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager, cfg *configapi.Configuration) {
+func setupScheduler(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager, cfg *configapi.Configuration, fw *framework.Framework) *scheduler.Scheduler {
+	opts := []scheduler.Option{
+		scheduler.WithPodsReadyRequeuingTimestamp(podsReadyRequeuingTimestamp(cfg)),
+		scheduler.WithFairSharing(cfg.FairSharing),
+		scheduler.WithFramework(fw),
+	}
+	if cfg.Scheduling != nil && cfg.Scheduling.MaxAdmissionsPerCycle != nil {
+		opts = append(opts, scheduler.WithMaxAdmissionsPerCycle(*cfg.Scheduling.MaxAdmissionsPerCycle))
+	}
+	if features.Enabled(features.AdmissionFairSharing) {
+		opts = append(opts, scheduler.WithAdmissionFairSharing(cfg.AdmissionFairSharing))
+	}
+	if cfg.Scheduling != nil && cfg.Scheduling.Trace != nil {
+		opts = append(opts, scheduler.WithTraceRecorder(setupSchedulingTrace(mgr, cfg.Scheduling.Trace)))
+	}
 	sched := scheduler.New(
 		queues,
 		cCache,
 		mgr.GetClient(),
 		mgr.GetEventRecorderFor(constants.AdmissionName),
-		scheduler.WithPodsReadyRequeuingTimestamp(podsReadyRequeuingTimestamp(cfg)),
-		scheduler.WithFairSharing(cfg.FairSharing),
+		opts...,
 	)
 	if err := mgr.Add(sched); err != nil {
 		setupLog.Error(err, "Unable to add scheduler to manager")
 		os.Exit(1)
 	}
+	return sched
+}
+
+// setupSchedulingTrace creates the recorder of the scheduling cycles, which
+// are served on the metrics server, and closes the trace file, if any, when
+// the manager stops.
+func setupSchedulingTrace(mgr ctrl.Manager, cfg *configapi.SchedulingTrace) *trace.Recorder {
+	recorder, err := trace.NewRecorder(cfg)
+	if err != nil {
+		setupLog.Error(err, "Unable to create the scheduling trace recorder")
+		os.Exit(1)
+	}
+	if err := mgr.AddMetricsServerExtraHandler(trace.HandlerPath, recorder); err != nil {
+		setupLog.Error(err, "Unable to serve the scheduling trace")
+		os.Exit(1)
+	}
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return recorder.Close()
+	})); err != nil {
+		setupLog.Error(err, "Unable to add the scheduling trace recorder to manager")
+		os.Exit(1)
+	}
+	return recorder
 }
 
 func setupServerVersionFetcher(mgr ctrl.Manager, kubeConfig *rest.Config) *kubeversion.ServerVersionFetcher {
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"maps"
+	"math"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// consumedResources tracks the historical resource consumption of a queue,
+// in resource-seconds, decayed with the configured half-life time. The values
+// use the same units as resources.FlavorResourceQuantities.
+type consumedResources struct {
+	values     map[corev1.ResourceName]float64
+	lastUpdate time.Time
+}
+
+// consumedResourcesFromStatus restores the consumed resources persisted in
+// the fair sharing status of a queue.
+func consumedResourcesFromStatus(status *kueue.FairSharingStatus) consumedResources {
+	cr := consumedResources{values: make(map[corev1.ResourceName]float64)}
+	if status == nil || status.AdmissionFairSharingStatus == nil {
+		return cr
+	}
+	afs := status.AdmissionFairSharingStatus
+	for name, q := range afs.ConsumedResources {
+		cr.values[name] = float64(resources.ResourceValue(name, q))
+	}
+	cr.lastUpdate = afs.LastUpdate.Time
+	return cr
+}
+
+// sample decays the consumption accumulated so far by the time elapsed since
+// the last sample, and adds the usage held during that time.
+func (cr *consumedResources) sample(usage resources.FlavorResourceQuantities, now time.Time, halfLife time.Duration) {
+	if cr.values == nil {
+		cr.values = make(map[corev1.ResourceName]float64)
+	}
+	if cr.lastUpdate.IsZero() {
+		cr.lastUpdate = now
+		return
+	}
+	elapsed := now.Sub(cr.lastUpdate).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	decay := math.Pow(0.5, elapsed/halfLife.Seconds())
+	for name := range cr.values {
+		cr.values[name] *= decay
+	}
+	for fr, q := range usage {
+		cr.values[fr.Resource] += float64(q) * elapsed
+	}
+	cr.lastUpdate = now
+}
+
+func (cr *consumedResources) toAPI() *kueue.AdmissionFairSharingStatus {
+	if cr.lastUpdate.IsZero() {
+		return nil
+	}
+	status := &kueue.AdmissionFairSharingStatus{
+		ConsumedResources: make(corev1.ResourceList, len(cr.values)),
+		LastUpdate:        metav1.NewTime(cr.lastUpdate),
+	}
+	for name, v := range cr.values {
+		status.ConsumedResources[name] = resources.ResourceQuantity(name, int64(v))
+	}
+	return status
+}
+
+func (cr *consumedResources) snapshot() map[corev1.ResourceName]float64 {
+	if len(cr.values) == 0 {
+		return nil
+	}
+	return maps.Clone(cr.values)
+}
+
+// SampleConsumedResources updates the historical resource consumption of
+// all the ClusterQueues and LocalQueues with their current usage.
+func (c *Cache) SampleConsumedResources(now time.Time) {
+	if c.admissionFairSharing == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.generation++
+	halfLife := c.admissionFairSharing.UsageHalfLifeTime.Duration
+	for _, cq := range c.hm.ClusterQueues {
+		cq.consumedResources.sample(cq.resourceNode.Usage, now, halfLife)
+		for _, q := range cq.localQueues {
+			q.consumedResources.sample(q.usage, now, halfLife)
+		}
+	}
+}
+
+// ConsumedShare returns the maximum, among the resources, of the ratio of
+// the historical consumption of the ClusterQueue to the capacity of its
+// Cohort tree, divided by the fair sharing weight of the ClusterQueue.
+func (c *ClusterQueueSnapshot) ConsumedShare() float64 {
+	if len(c.ConsumedResources) == 0 {
+		return 0
+	}
+	if c.FairWeight.IsZero() {
+		return math.MaxFloat64
+	}
+	subtreeQuota := c.ResourceNode.SubtreeQuota
+	if c.HasParent() {
+		subtreeQuota = c.Parent().Root().ResourceNode.SubtreeQuota
+	}
+	capacity := make(map[corev1.ResourceName]int64)
+	for fr, q := range subtreeQuota {
+		capacity[fr.Resource] += q
+	}
+	var share float64
+	for name, consumed := range c.ConsumedResources {
+		if capacity[name] == 0 {
+			continue
+		}
+		share = max(share, consumed/float64(capacity[name]))
+	}
+	return share / c.FairWeight.AsApproximateFloat64()
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestSampleConsumedResources(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	afs := &config.AdmissionFairSharing{
+		UsageHalfLifeTime:     &metav1.Duration{Duration: time.Hour},
+		UsageSamplingInterval: &metav1.Duration{Duration: time.Minute},
+	}
+	cases := map[string]struct {
+		cqStatus     *kueue.FairSharingStatus
+		workloads    []*kueue.Workload
+		samples      []time.Duration
+		wantCQStatus *kueue.AdmissionFairSharingStatus
+		wantLQStatus *kueue.AdmissionFairSharingStatus
+	}{
+		"not sampled yet": {
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("wl", "ns").Queue("lq").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+					Obj(),
+			},
+		},
+		"first sample only starts tracking": {
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("wl", "ns").Queue("lq").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+					Obj(),
+			},
+			samples: []time.Duration{0},
+			wantCQStatus: &kueue.AdmissionFairSharingStatus{
+				ConsumedResources: corev1.ResourceList{},
+				LastUpdate:        metav1.NewTime(now),
+			},
+			wantLQStatus: &kueue.AdmissionFairSharingStatus{
+				ConsumedResources: corev1.ResourceList{},
+				LastUpdate:        metav1.NewTime(now),
+			},
+		},
+		"usage is accumulated and decayed": {
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("wl", "ns").Queue("lq").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+					Obj(),
+				utiltesting.MakeWorkload("other", "other-ns").Queue("lq").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+					Obj(),
+			},
+			samples: []time.Duration{0, time.Hour, 2 * time.Hour},
+			wantCQStatus: &kueue.AdmissionFairSharingStatus{
+				ConsumedResources: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("16200"),
+				},
+				LastUpdate: metav1.NewTime(now.Add(2 * time.Hour)),
+			},
+			wantLQStatus: &kueue.AdmissionFairSharingStatus{
+				ConsumedResources: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("10800"),
+				},
+				LastUpdate: metav1.NewTime(now.Add(2 * time.Hour)),
+			},
+		},
+		"consumption is restored from the status": {
+			cqStatus: &kueue.FairSharingStatus{
+				AdmissionFairSharingStatus: &kueue.AdmissionFairSharingStatus{
+					ConsumedResources: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("100"),
+					},
+					LastUpdate: metav1.NewTime(now),
+				},
+			},
+			samples: []time.Duration{time.Hour},
+			wantCQStatus: &kueue.AdmissionFairSharingStatus{
+				ConsumedResources: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("50"),
+				},
+				LastUpdate: metav1.NewTime(now.Add(time.Hour)),
+			},
+			wantLQStatus: &kueue.AdmissionFairSharingStatus{
+				ConsumedResources: corev1.ResourceList{},
+				LastUpdate:        metav1.NewTime(now.Add(time.Hour)),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cache := New(utiltesting.NewFakeClient(), WithAdmissionFairSharing(afs))
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+				Obj()
+			cq.Status.FairSharing = tc.cqStatus
+			if err := cache.AddClusterQueue(context.Background(), cq); err != nil {
+				t.Fatalf("Adding ClusterQueue: %v", err)
+			}
+			lq := utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj()
+			if err := cache.AddLocalQueue(lq); err != nil {
+				t.Fatalf("Adding LocalQueue: %v", err)
+			}
+			for _, wl := range tc.workloads {
+				cache.AddOrUpdateWorkload(wl)
+			}
+			for _, d := range tc.samples {
+				cache.SampleConsumedResources(now.Add(d))
+			}
+
+			cqStats, err := cache.Usage(cq)
+			if err != nil {
+				t.Fatalf("Getting ClusterQueue usage: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantCQStatus, cqStats.AdmissionFairSharing); diff != "" {
+				t.Errorf("Unexpected ClusterQueue consumed resources (-want,+got):\n%s", diff)
+			}
+			lqStats, err := cache.LocalQueueUsage(lq)
+			if err != nil {
+				t.Fatalf("Getting LocalQueue usage: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantLQStatus, lqStats.AdmissionFairSharing); diff != "" {
+				t.Errorf("Unexpected LocalQueue consumed resources (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConsumedShare(t *testing.T) {
+	cases := map[string]struct {
+		consumed   map[corev1.ResourceName]float64
+		fairWeight resource.Quantity
+		want       float64
+	}{
+		"nothing consumed": {
+			fairWeight: oneQuantity,
+		},
+		"dominant resource is used": {
+			consumed: map[corev1.ResourceName]float64{
+				corev1.ResourceCPU:    10_000 * 60,
+				corev1.ResourceMemory: 1,
+			},
+			fairWeight: oneQuantity,
+			want:       30,
+		},
+		"divided by the weight": {
+			consumed: map[corev1.ResourceName]float64{
+				corev1.ResourceCPU: 10_000 * 60,
+			},
+			fairWeight: resource.MustParse("2"),
+			want:       15,
+		},
+		"resources without capacity are ignored": {
+			consumed: map[corev1.ResourceName]float64{
+				"example.com/gpu": 100,
+			},
+			fairWeight: oneQuantity,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cache := New(utiltesting.NewFakeClient())
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			clusterQueues := []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq").
+					Cohort("cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").
+						Resource(corev1.ResourceMemory, "10Gi").Obj()).
+					Obj(),
+				utiltesting.MakeClusterQueue("other").
+					Cohort("cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").
+						Resource(corev1.ResourceMemory, "10Gi").Obj()).
+					Obj(),
+			}
+			for _, cq := range clusterQueues {
+				if err := cache.AddClusterQueue(context.Background(), cq); err != nil {
+					t.Fatalf("Adding ClusterQueue: %v", err)
+				}
+			}
+			snapshot, err := cache.Snapshot(context.Background())
+			if err != nil {
+				t.Fatalf("Building snapshot: %v", err)
+			}
+			cq := snapshot.ClusterQueues["cq"]
+			cq.ConsumedResources = tc.consumed
+			cq.FairWeight = tc.fairWeight
+			if got := cq.ConsumedShare(); got != tc.want {
+				t.Errorf("Unexpected consumed share, want=%v, got=%v", tc.want, got)
+			}
+		})
+	}
+}
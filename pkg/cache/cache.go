@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"testing"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +31,8 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -41,12 +44,15 @@ import (
 	"sigs.k8s.io/kueue/pkg/hierarchy"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/resources"
+	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
 	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 var (
 	ErrCqNotFound          = errors.New("cluster queue not found")
+	ErrCohortNotFound      = errors.New("cohort not found")
+	errCohortHasCycle      = errors.New("cohort has a cycle")
 	errQNotFound           = errors.New("queue not found")
 	errWorkloadNotAdmitted = errors.New("workload not admitted by a ClusterQueue")
 )
@@ -58,9 +64,11 @@ const (
 )
 
 type options struct {
-	workloadInfoOptions []workload.InfoOption
-	podsReadyTracking   bool
-	fairSharingEnabled  bool
+	workloadInfoOptions  []workload.InfoOption
+	podsReadyTracking    bool
+	fairSharingEnabled   bool
+	admissionFairSharing *config.AdmissionFairSharing
+	clock                clock.Clock
 }
 
 // Option configures the reconciler.
@@ -94,7 +102,36 @@ func WithFairSharing(enabled bool) Option {
 	}
 }
 
-var defaultOptions = options{}
+// WithAdmissionFairSharing enables tracking the historical resource
+// consumption of the ClusterQueues and LocalQueues.
+func WithAdmissionFairSharing(afs *config.AdmissionFairSharing) Option {
+	return func(o *options) {
+		o.admissionFairSharing = afs
+	}
+}
+
+// WithClock allows to specify a custom clock, used to determine the
+// Reservations holding quota.
+func WithClock(_ testing.TB, c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// WithSimulationClock sets the clock of an offline simulation of the
+// scheduler, which drives time, like WithClock does in the tests. The
+// controllers always use the real clock.
+func WithSimulationClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+var realClock = clock.RealClock{}
+
+var defaultOptions = options{
+	clock: realClock,
+}
 
 // Cache keeps track of the Workloads that got admitted through ClusterQueues.
 type Cache struct {
@@ -108,10 +145,24 @@ type Cache struct {
 	admissionChecks     map[string]AdmissionCheck
 	workloadInfoOptions []workload.InfoOption
 	fairSharingEnabled  bool
+	// admissionFairSharing is nil when the tracking of the historical
+	// resource consumption is disabled.
+	admissionFairSharing *config.AdmissionFairSharing
+	reservations         map[string]*reservation
+	quotaSchedules       map[string]*quotaSchedule
+	clock                clock.Clock
 
 	hm hierarchy.Manager[*clusterQueue, *cohort]
 
 	tasCache TASCache
+
+	// generation is increased by the changes which can affect the snapshot
+	// of any ClusterQueue. The changes of the workloads only increase the
+	// generation of their ClusterQueue.
+	generation int64
+	// snapshots holds the snapshots of the ClusterQueues reused by the
+	// incremental snapshots.
+	snapshots clusterQueueSnapshots
 }
 
 func New(client client.Client, opts ...Option) *Cache {
@@ -120,15 +171,20 @@ func New(client client.Client, opts ...Option) *Cache {
 		opt(&options)
 	}
 	c := &Cache{
-		client:              client,
-		assumedWorkloads:    make(map[string]string),
-		resourceFlavors:     make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor),
-		admissionChecks:     make(map[string]AdmissionCheck),
-		podsReadyTracking:   options.podsReadyTracking,
-		workloadInfoOptions: options.workloadInfoOptions,
-		fairSharingEnabled:  options.fairSharingEnabled,
-		hm:                  hierarchy.NewManager[*clusterQueue, *cohort](newCohort),
-		tasCache:            NewTASCache(client),
+		client:               client,
+		assumedWorkloads:     make(map[string]string),
+		resourceFlavors:      make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor),
+		admissionChecks:      make(map[string]AdmissionCheck),
+		podsReadyTracking:    options.podsReadyTracking,
+		workloadInfoOptions:  options.workloadInfoOptions,
+		fairSharingEnabled:   options.fairSharingEnabled,
+		admissionFairSharing: options.admissionFairSharing,
+		reservations:         make(map[string]*reservation),
+		quotaSchedules:       make(map[string]*quotaSchedule),
+		clock:                options.clock,
+		hm:                   hierarchy.NewManager[*clusterQueue, *cohort](newCohort),
+		tasCache:             NewTASCache(client),
+		snapshots:            clusterQueueSnapshots{entries: make(map[string]cachedClusterQueueSnapshot)},
 	}
 	c.podsReadyCond.L = &c.RWMutex
 	return c
@@ -145,6 +201,8 @@ func (c *Cache) newClusterQueue(cq *kueue.ClusterQueue) (*clusterQueue, error) {
 		AdmittedUsage:       make(resources.FlavorResourceQuantities),
 		resourceNode:        NewResourceNode(),
 		tasCache:            &c.tasCache,
+		consumedResources:   consumedResourcesFromStatus(cq.Status.FairSharing),
+		quotaOverrides:      c.quotaOverridesFor(cq.Name),
 	}
 	c.hm.AddClusterQueue(cqImpl)
 	c.hm.UpdateClusterQueueEdge(cq.Name, cq.Spec.Cohort)
@@ -191,6 +249,12 @@ func (c *Cache) PodsReadyForAllAdmittedWorkloads(log logr.Logger) bool {
 	return c.podsReadyForAllAdmittedWorkloads(log)
 }
 
+// PodsReadyTracking returns true if the admission of new workloads can be
+// blocked until all admitted workloads are in the PodsReady condition.
+func (c *Cache) PodsReadyTracking() bool {
+	return c.podsReadyTracking
+}
+
 func (c *Cache) podsReadyForAllAdmittedWorkloads(log logr.Logger) bool {
 	for _, cq := range c.hm.ClusterQueues {
 		if len(cq.WorkloadsNotReady) > 0 {
@@ -249,6 +313,7 @@ func (c *Cache) TASCache() *TASCache {
 func (c *Cache) AddOrUpdateResourceFlavor(rf *kueue.ResourceFlavor) sets.Set[string] {
 	c.Lock()
 	defer c.Unlock()
+	c.generation++
 	c.resourceFlavors[kueue.ResourceFlavorReference(rf.Name)] = rf
 	return c.updateClusterQueues()
 }
@@ -256,6 +321,7 @@ func (c *Cache) AddOrUpdateResourceFlavor(rf *kueue.ResourceFlavor) sets.Set[str
 func (c *Cache) DeleteResourceFlavor(rf *kueue.ResourceFlavor) sets.Set[string] {
 	c.Lock()
 	defer c.Unlock()
+	c.generation++
 	delete(c.resourceFlavors, kueue.ResourceFlavorReference(rf.Name))
 	return c.updateClusterQueues()
 }
@@ -263,6 +329,7 @@ func (c *Cache) DeleteResourceFlavor(rf *kueue.ResourceFlavor) sets.Set[string]
 func (c *Cache) AddOrUpdateTopologyForFlavor(topology *kueuealpha.Topology, flv *kueue.ResourceFlavor) sets.Set[string] {
 	c.Lock()
 	defer c.Unlock()
+	c.generation++
 	levels := utiltas.Levels(topology)
 	tasInfo := c.tasCache.NewTASFlavorCache(kueue.TopologyReference(topology.Name), levels, flv.Spec.NodeLabels, flv.Spec.Tolerations)
 	c.tasCache.Set(kueue.ResourceFlavorReference(flv.Name), tasInfo)
@@ -272,6 +339,7 @@ func (c *Cache) AddOrUpdateTopologyForFlavor(topology *kueuealpha.Topology, flv
 func (c *Cache) DeleteTopologyForFlavor(flv kueue.ResourceFlavorReference) sets.Set[string] {
 	c.Lock()
 	defer c.Unlock()
+	c.generation++
 	c.tasCache.Delete(flv)
 	return c.updateClusterQueues()
 }
@@ -279,6 +347,7 @@ func (c *Cache) DeleteTopologyForFlavor(flv kueue.ResourceFlavorReference) sets.
 func (c *Cache) AddOrUpdateAdmissionCheck(ac *kueue.AdmissionCheck) sets.Set[string] {
 	c.Lock()
 	defer c.Unlock()
+	c.generation++
 
 	newAC := AdmissionCheck{
 		Active:     apimeta.IsStatusConditionTrue(ac.Status.Conditions, kueue.AdmissionCheckActive),
@@ -299,6 +368,7 @@ func (c *Cache) AddOrUpdateAdmissionCheck(ac *kueue.AdmissionCheck) sets.Set[str
 func (c *Cache) DeleteAdmissionCheck(ac *kueue.AdmissionCheck) sets.Set[string] {
 	c.Lock()
 	defer c.Unlock()
+	c.generation++
 	delete(c.admissionChecks, ac.Name)
 	return c.updateClusterQueues()
 }
@@ -355,6 +425,7 @@ func (c *Cache) clusterQueueInStatus(name string, status metrics.ClusterQueueSta
 func (c *Cache) TerminateClusterQueue(name string) {
 	c.Lock()
 	defer c.Unlock()
+	c.generation++
 	if cq, exists := c.hm.ClusterQueues[name]; exists {
 		cq.Status = terminating
 		metrics.ReportClusterQueueStatus(cq.Name, cq.Status)
@@ -377,6 +448,7 @@ func (c *Cache) ClusterQueueEmpty(name string) bool {
 func (c *Cache) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) error {
 	c.Lock()
 	defer c.Unlock()
+	c.generation++
 
 	if _, ok := c.hm.ClusterQueues[cq.Name]; ok {
 		return errors.New("ClusterQueue already exists")
@@ -400,8 +472,10 @@ func (c *Cache) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) err
 			reservingWorkloads: 0,
 			admittedWorkloads:  0,
 			//TODO: rename this to better distinguish between reserved and in use quantities
-			usage:         make(resources.FlavorResourceQuantities),
-			admittedUsage: make(resources.FlavorResourceQuantities),
+			usage:             make(resources.FlavorResourceQuantities),
+			admittedUsage:     make(resources.FlavorResourceQuantities),
+			consumedResources: consumedResourcesFromStatus(q.Status.FairSharing),
+			fairWeight:        localQueueFairWeight(&q),
 		}
 		qImpl.resetFlavorsAndResources(cqImpl.resourceNode.Usage, cqImpl.AdmittedUsage)
 		cqImpl.localQueues[qKey] = qImpl
@@ -423,6 +497,7 @@ func (c *Cache) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) err
 func (c *Cache) UpdateClusterQueue(cq *kueue.ClusterQueue) error {
 	c.Lock()
 	defer c.Unlock()
+	c.generation++
 	cqImpl, ok := c.hm.ClusterQueues[cq.Name]
 	if !ok {
 		return ErrCqNotFound
@@ -444,6 +519,7 @@ func (c *Cache) UpdateClusterQueue(cq *kueue.ClusterQueue) error {
 func (c *Cache) DeleteClusterQueue(cq *kueue.ClusterQueue) {
 	c.Lock()
 	defer c.Unlock()
+	c.generation++
 	_, ok := c.hm.ClusterQueues[cq.Name]
 	if !ok {
 		return
@@ -460,6 +536,7 @@ func (c *Cache) DeleteClusterQueue(cq *kueue.ClusterQueue) {
 func (c *Cache) AddOrUpdateCohort(apiCohort *kueuealpha.Cohort) error {
 	c.Lock()
 	defer c.Unlock()
+	c.generation++
 	c.hm.AddCohort(apiCohort.Name)
 	cohort := c.hm.Cohorts[apiCohort.Name]
 	oldParent := cohort.Parent()
@@ -470,6 +547,7 @@ func (c *Cache) AddOrUpdateCohort(apiCohort *kueuealpha.Cohort) error {
 func (c *Cache) DeleteCohort(cohortName string) {
 	c.Lock()
 	defer c.Unlock()
+	c.generation++
 	c.hm.DeleteCohort(cohortName)
 
 	// If the cohort still exists after deletion, it means
@@ -501,11 +579,16 @@ func (c *Cache) DeleteLocalQueue(q *kueue.LocalQueue) {
 }
 
 func (c *Cache) UpdateLocalQueue(oldQ, newQ *kueue.LocalQueue) error {
+	c.Lock()
+	defer c.Unlock()
 	if oldQ.Spec.ClusterQueue == newQ.Spec.ClusterQueue {
+		if cq, ok := c.hm.ClusterQueues[string(newQ.Spec.ClusterQueue)]; ok {
+			if qImpl, ok := cq.localQueues[queueKey(newQ)]; ok {
+				qImpl.fairWeight = localQueueFairWeight(newQ)
+			}
+		}
 		return nil
 	}
-	c.Lock()
-	defer c.Unlock()
 	cq, ok := c.hm.ClusterQueues[string(oldQ.Spec.ClusterQueue)]
 	if ok {
 		cq.deleteLocalQueue(oldQ)
@@ -660,6 +743,8 @@ type ClusterQueueUsageStats struct {
 	AdmittedResources  []kueue.FlavorUsage
 	AdmittedWorkloads  int
 	WeightedShare      int64
+	// AdmissionFairSharing is nil when admission fair sharing is disabled.
+	AdmissionFairSharing *kueue.AdmissionFairSharingStatus
 }
 
 // Usage reports the reserved and admitted resources and number of workloads holding them in the ClusterQueue.
@@ -683,6 +768,9 @@ func (c *Cache) Usage(cqObj *kueue.ClusterQueue) (*ClusterQueueUsageStats, error
 		weightedShare, _ := dominantResourceShare(cq, nil, 0)
 		stats.WeightedShare = int64(weightedShare)
 	}
+	if c.admissionFairSharing != nil {
+		stats.AdmissionFairSharing = cq.consumedResources.toAPI()
+	}
 
 	return stats, nil
 }
@@ -722,12 +810,141 @@ func getUsage(frq resources.FlavorResourceQuantities, cq *clusterQueue) []kueue.
 	return usage
 }
 
+type CohortUsageStats struct {
+	FlavorsUsage  []kueuealpha.CohortFlavorUsage
+	WeightedShare int64
+}
+
+// CohortUsage reports the quota and usage aggregated over the subtree of the Cohort.
+func (c *Cache) CohortUsage(name string) (*CohortUsageStats, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	cohort := c.hm.Cohorts[name]
+	if cohort == nil {
+		return nil, ErrCohortNotFound
+	}
+	if c.hm.CycleChecker.HasCycle(cohort) {
+		return nil, errCohortHasCycle
+	}
+
+	nominal := make(resources.FlavorResourceQuantities)
+	total := make(resources.FlavorResourceQuantities)
+	accumulateSubtreeNominalAndUsage(cohort, nominal, total)
+
+	frs := sets.New[resources.FlavorResource]()
+	for _, frq := range []resources.FlavorResourceQuantities{nominal, total, cohort.resourceNode.SubtreeQuota} {
+		for fr := range frq {
+			frs.Insert(fr)
+		}
+	}
+	resourcesByFlavor := make(map[kueue.ResourceFlavorReference][]kueuealpha.CohortResourceUsage)
+	for fr := range frs {
+		rUsage := kueuealpha.CohortResourceUsage{
+			Name:         fr.Resource,
+			NominalQuota: resources.ResourceQuantity(fr.Resource, nominal[fr]),
+			Total:        resources.ResourceQuantity(fr.Resource, total[fr]),
+			Lendable:     resources.ResourceQuantity(fr.Resource, cohort.resourceNode.SubtreeQuota[fr]-cohort.resourceNode.guaranteedQuota(fr)),
+		}
+		// Enforce `borrowed=0` if the Cohort doesn't have a parent.
+		if cohort.HasParent() {
+			if borrowed := cohort.resourceNode.Usage[fr] - cohort.resourceNode.SubtreeQuota[fr]; borrowed > 0 {
+				rUsage.Borrowed = resources.ResourceQuantity(fr.Resource, borrowed)
+			}
+		}
+		resourcesByFlavor[fr.Flavor] = append(resourcesByFlavor[fr.Flavor], rUsage)
+	}
+
+	stats := &CohortUsageStats{
+		FlavorsUsage: make([]kueuealpha.CohortFlavorUsage, 0, len(resourcesByFlavor)),
+	}
+	// The flavors and resources should be in a stable order to avoid endless creation of update events.
+	for _, fName := range utilmaps.SortedKeys(resourcesByFlavor) {
+		rUsages := resourcesByFlavor[fName]
+		sort.Slice(rUsages, func(i, j int) bool {
+			return rUsages[i].Name < rUsages[j].Name
+		})
+		stats.FlavorsUsage = append(stats.FlavorsUsage, kueuealpha.CohortFlavorUsage{
+			Name:      fName,
+			Resources: rUsages,
+		})
+	}
+
+	if c.fairSharingEnabled {
+		weightedShare, _ := dominantResourceShare(cohort, nil, 0)
+		stats.WeightedShare = int64(weightedShare)
+	}
+
+	return stats, nil
+}
+
+// accumulateSubtreeNominalAndUsage sums the nominal quotas of all the nodes
+// in the Cohort subtree, and the usage of all its ClusterQueues.
+func accumulateSubtreeNominalAndUsage(cohort *cohort, nominal, usage resources.FlavorResourceQuantities) {
+	for fr, quota := range cohort.resourceNode.Quotas {
+		nominal[fr] += quota.Nominal
+	}
+	for _, cq := range cohort.ChildCQs() {
+		for fr, quota := range cq.resourceNode.Quotas {
+			nominal[fr] += quota.Nominal
+		}
+		for fr, q := range cq.resourceNode.Usage {
+			usage[fr] += q
+		}
+	}
+	for _, child := range cohort.ChildCohorts() {
+		accumulateSubtreeNominalAndUsage(child, nominal, usage)
+	}
+}
+
+// CohortAncestry returns the names of the explicit Cohorts on the path from
+// the named Cohort, inclusive, up to the root of its Cohort tree.
+func (c *Cache) CohortAncestry(name string) []string {
+	c.RLock()
+	defer c.RUnlock()
+	return c.cohortAncestryUnsafe(c.hm.Cohorts[name])
+}
+
+// ClusterQueueCohortAncestry returns the names of the explicit Cohorts on
+// the path from the parent of the named ClusterQueue up to the root of its
+// Cohort tree.
+func (c *Cache) ClusterQueueCohortAncestry(name string) []string {
+	c.RLock()
+	defer c.RUnlock()
+	cq := c.hm.ClusterQueues[name]
+	if cq == nil || !cq.HasParent() {
+		return nil
+	}
+	return c.cohortAncestryUnsafe(cq.Parent())
+}
+
+func (c *Cache) cohortAncestryUnsafe(cohort *cohort) []string {
+	var ancestry []string
+	visited := sets.New[string]()
+	for cohort != nil && !visited.Has(cohort.Name) {
+		visited.Insert(cohort.Name)
+		if cohort.IsExplicit() {
+			ancestry = append(ancestry, cohort.Name)
+		}
+		if !cohort.HasParent() {
+			break
+		}
+		cohort = cohort.Parent()
+	}
+	return ancestry
+}
+
 type LocalQueueUsageStats struct {
 	ReservedResources  []kueue.LocalQueueFlavorUsage
 	ReservingWorkloads int
 	AdmittedResources  []kueue.LocalQueueFlavorUsage
 	AdmittedWorkloads  int
 	Flavors            []kueue.LocalQueueFlavorStatus
+	// WeightedShare is nil unless the ClusterQueue has fair sharing between
+	// its LocalQueues enabled.
+	WeightedShare *int64
+	// AdmissionFairSharing is nil when admission fair sharing is disabled.
+	AdmissionFairSharing *kueue.AdmissionFairSharingStatus
 }
 
 func (c *Cache) LocalQueueUsage(qObj *kueue.LocalQueue) (*LocalQueueUsageStats, error) {
@@ -771,13 +988,20 @@ func (c *Cache) LocalQueueUsage(qObj *kueue.LocalQueue) (*LocalQueueUsageStats,
 		}
 	}
 
-	return &LocalQueueUsageStats{
+	stats := &LocalQueueUsageStats{
 		ReservedResources:  filterLocalQueueUsage(qImpl.usage, cqImpl.ResourceGroups),
 		ReservingWorkloads: qImpl.reservingWorkloads,
 		AdmittedResources:  filterLocalQueueUsage(qImpl.admittedUsage, cqImpl.ResourceGroups),
 		AdmittedWorkloads:  qImpl.admittedWorkloads,
 		Flavors:            flavors,
-	}, nil
+	}
+	if cqImpl.localQueueFairSharing != nil {
+		stats.WeightedShare = ptr.To(cqImpl.localQueueShare(qImpl, c.localQueueUsageMode(cqImpl)))
+	}
+	if c.admissionFairSharing != nil {
+		stats.AdmissionFairSharing = qImpl.consumedResources.toAPI()
+	}
+	return stats, nil
 }
 
 func filterLocalQueueUsage(orig resources.FlavorResourceQuantities, resourceGroups []ResourceGroup) []kueue.LocalQueueFlavorUsage {
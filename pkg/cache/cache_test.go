@@ -34,6 +34,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/features"
@@ -2061,6 +2062,104 @@ func TestClusterQueueUsage(t *testing.T) {
 	}
 }
 
+func TestCohortUsage(t *testing.T) {
+	cohorts := []*kueuealpha.Cohort{
+		utiltesting.MakeCohort("root").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+		utiltesting.MakeCohort("child").
+			Parent("root").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+			Obj(),
+	}
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq-a").
+			Cohort("child").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5", "", "4").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("cq-b").
+			Cohort("root").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+			Obj(),
+	}
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("wl", "").
+			Request(corev1.ResourceCPU, "9").
+			ReserveQuota(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "default", "9").Obj()).
+			Obj(),
+	}
+	cases := map[string]struct {
+		cohort      string
+		fairSharing bool
+		wantStats   *CohortUsageStats
+		wantErr     error
+	}{
+		"root cohort": {
+			cohort: "root",
+			wantStats: &CohortUsageStats{
+				FlavorsUsage: []kueuealpha.CohortFlavorUsage{{
+					Name: "default",
+					Resources: []kueuealpha.CohortResourceUsage{{
+						Name:         corev1.ResourceCPU,
+						NominalQuota: resource.MustParse("22"),
+						Total:        resource.MustParse("9"),
+						Lendable:     resource.MustParse("21"),
+					}},
+				}},
+			},
+		},
+		"child cohort borrowing from the root": {
+			cohort:      "child",
+			fairSharing: true,
+			wantStats: &CohortUsageStats{
+				FlavorsUsage: []kueuealpha.CohortFlavorUsage{{
+					Name: "default",
+					Resources: []kueuealpha.CohortResourceUsage{{
+						Name:         corev1.ResourceCPU,
+						NominalQuota: resource.MustParse("7"),
+						Total:        resource.MustParse("9"),
+						Borrowed:     resource.MustParse("2"),
+						Lendable:     resource.MustParse("6"),
+					}},
+				}},
+				WeightedShare: 95,
+			},
+		},
+		"cohort not found": {
+			cohort:  "missing",
+			wantErr: ErrCohortNotFound,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cache := New(utiltesting.NewFakeClient(), WithFairSharing(tc.fairSharing))
+			ctx := context.Background()
+			for _, cohort := range cohorts {
+				if err := cache.AddOrUpdateCohort(cohort); err != nil {
+					t.Fatalf("Adding Cohort: %v", err)
+				}
+			}
+			for _, cq := range clusterQueues {
+				if err := cache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Adding ClusterQueue: %v", err)
+				}
+			}
+			for _, w := range workloads {
+				if added := cache.AddOrUpdateWorkload(w); !added {
+					t.Fatalf("Workload %s was not added", workload.Key(w))
+				}
+			}
+			stats, err := cache.CohortUsage(tc.cohort)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Unexpected error (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStats, stats); diff != "" {
+				t.Errorf("Unexpected stats (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestLocalQueueUsage(t *testing.T) {
 	cq := *utiltesting.MakeClusterQueue("foo").
 		ResourceGroup(
@@ -2733,7 +2832,7 @@ func TestCacheQueueOperations(t *testing.T) {
 					cacheQueues[qKey] = cacheQ
 				}
 			}
-			if diff := cmp.Diff(tc.wantLocalQueues, cacheQueues, cmp.AllowUnexported(queue{}), cmpopts.EquateEmpty()); diff != "" {
+			if diff := cmp.Diff(tc.wantLocalQueues, cacheQueues, cmp.AllowUnexported(queue{}, consumedResources{}), cmpopts.EquateEmpty()); diff != "" {
 				t.Errorf("Unexpected localQueues (-want,+got):\n%s", diff)
 			}
 		})
@@ -60,6 +60,8 @@ type clusterQueue struct {
 	Preemption        kueue.ClusterQueuePreemption
 	FairWeight        resource.Quantity
 	FlavorFungibility kueue.FlavorFungibility
+	// FlavorSelectionPolicy is empty for the DeclarationOrder policy.
+	FlavorSelectionPolicy kueue.FlavorSelectionPolicy
 	// Aggregates AdmissionChecks from both .spec.AdmissionChecks and .spec.AdmissionCheckStrategy
 	// Sets hold ResourceFlavors to which an AdmissionCheck should apply.
 	// In case its empty, it means an AdmissionCheck should apply to all ResourceFlavor
@@ -68,6 +70,10 @@ type clusterQueue struct {
 	// AllocatableResourceGeneration will be increased when some admitted workloads are
 	// deleted, or the resource groups are changed.
 	AllocatableResourceGeneration int64
+	// generation is increased when a workload is added or deleted, which
+	// invalidates the snapshot of the ClusterQueue reused by the incremental
+	// snapshots.
+	generation int64
 
 	AdmittedUsage resources.FlavorResourceQuantities
 	// localQueues by (namespace/name).
@@ -90,6 +96,23 @@ type clusterQueue struct {
 	hierarchy.ClusterQueue[*cohort]
 
 	tasCache *TASCache
+
+	// localQueueFairSharing is nil unless the fair sharing between the
+	// LocalQueues of the ClusterQueue is enabled.
+	localQueueFairSharing *kueue.LocalQueueFairSharing
+
+	// backfillMaxCandidates is the maximum number of workloads considered
+	// for backfilling in a scheduling cycle, or 0 if backfill is disabled.
+	backfillMaxCandidates int32
+
+	// consumedResources is the historical resource consumption used by
+	// the admission fair sharing.
+	consumedResources consumedResources
+
+	// specQuotas are the quotas from the ClusterQueue spec, before the
+	// quotaOverrides of the active QuotaSchedule windows are applied.
+	specQuotas     map[resources.FlavorResource]ResourceQuota
+	quotaOverrides map[resources.FlavorResource]QuotaOverride
 }
 
 func (c *clusterQueue) GetName() string {
@@ -119,6 +142,12 @@ type queue struct {
 	//TODO: rename this to better distinguish between reserved and "in use" quantities
 	usage         resources.FlavorResourceQuantities
 	admittedUsage resources.FlavorResourceQuantities
+	// consumedResources is the historical resource consumption used by
+	// the admission fair sharing.
+	consumedResources consumedResources
+	// fairWeight is the weight of the LocalQueue in the fair sharing between
+	// the LocalQueues of the ClusterQueue, nil for the default weight.
+	fairWeight *resource.Quantity
 }
 
 func (c *clusterQueue) Active() bool {
@@ -130,6 +159,8 @@ var defaultPreemption = kueue.ClusterQueuePreemption{
 	WithinClusterQueue:  kueue.PreemptionPolicyNever,
 }
 
+const defaultBackfillMaxCandidates = 10
+
 var defaultFlavorFungibility = kueue.FlavorFungibility{WhenCanBorrow: kueue.Borrow, WhenCanPreempt: kueue.TryNextFlavor}
 
 func (c *clusterQueue) updateClusterQueue(cycleChecker hierarchy.CycleChecker, in *kueue.ClusterQueue, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, admissionChecks map[string]AdmissionCheck, oldParent *cohort) error {
@@ -181,10 +212,21 @@ func (c *clusterQueue) updateClusterQueue(cycleChecker hierarchy.CycleChecker, i
 		c.FlavorFungibility = defaultFlavorFungibility
 	}
 
+	c.FlavorSelectionPolicy = ptr.Deref(in.Spec.FlavorSelectionPolicy, "")
+	if c.FlavorSelectionPolicy == kueue.DeclarationOrder {
+		c.FlavorSelectionPolicy = ""
+	}
+
 	c.FairWeight = oneQuantity
 	if fs := in.Spec.FairSharing; fs != nil && fs.Weight != nil {
 		c.FairWeight = *fs.Weight
 	}
+	c.localQueueFairSharing = in.Spec.LocalQueueFairSharing
+
+	c.backfillMaxCandidates = 0
+	if in.Spec.Backfill != nil && in.Spec.QueueingStrategy == kueue.StrictFIFO {
+		c.backfillMaxCandidates = ptr.Deref(in.Spec.Backfill.MaxCandidates, defaultBackfillMaxCandidates)
+	}
 
 	return nil
 }
@@ -209,7 +251,8 @@ func (c *clusterQueue) updateQuotasAndResourceGroups(in []kueue.ResourceGroup) b
 	oldRG := c.ResourceGroups
 	oldQuotas := c.resourceNode.Quotas
 	c.ResourceGroups = createdResourceGroups(in)
-	c.resourceNode.Quotas = createResourceQuotas(in)
+	c.specQuotas = createResourceQuotas(in)
+	c.resourceNode.Quotas = overrideQuotas(c.specQuotas, c.quotaOverrides)
 
 	// Start at 1, for backwards compatibility.
 	return c.AllocatableResourceGeneration == 0 ||
@@ -288,22 +331,6 @@ func (c *clusterQueue) inactiveReason() (string, string) {
 		}
 
 		if features.Enabled(features.TopologyAwareScheduling) && len(c.tasFlavors) > 0 {
-			if c.HasParent() {
-				reasons = append(reasons, kueue.ClusterQueueActiveReasonNotSupportedWithTopologyAwareScheduling)
-				messages = append(messages, "TAS is not supported for cohorts")
-			}
-			if c.Preemption.WithinClusterQueue != kueue.PreemptionPolicyNever {
-				reasons = append(reasons, kueue.ClusterQueueActiveReasonNotSupportedWithTopologyAwareScheduling)
-				messages = append(messages, "TAS is not supported for preemption within cluster queue")
-			}
-			if len(c.multiKueueAdmissionChecks) > 0 {
-				reasons = append(reasons, kueue.ClusterQueueActiveReasonNotSupportedWithTopologyAwareScheduling)
-				messages = append(messages, "TAS is not supported with MultiKueue admission check")
-			}
-			if len(c.provisioningAdmissionChecks) > 0 {
-				reasons = append(reasons, kueue.ClusterQueueActiveReasonNotSupportedWithTopologyAwareScheduling)
-				messages = append(messages, "TAS is not supported with ProvisioningRequest admission check")
-			}
 			for tasFlavor, topology := range c.tasFlavors {
 				if c.tasCache.Get(tasFlavor) == nil {
 					reasons = append(reasons, kueue.ClusterQueueActiveReasonTopologyNotFound)
@@ -330,10 +357,7 @@ func (c *clusterQueue) isTASViolated() bool {
 			return true
 		}
 	}
-	return c.HasParent() ||
-		c.Preemption.WithinClusterQueue != kueue.PreemptionPolicyNever ||
-		len(c.multiKueueAdmissionChecks) > 0 ||
-		len(c.provisioningAdmissionChecks) > 0
+	return false
 }
 
 // UpdateWithFlavors updates a ClusterQueue based on the passed ResourceFlavors set.
@@ -483,6 +507,7 @@ func (c *clusterQueue) addWorkload(w *kueue.Workload) error {
 	}
 	wi := workload.NewInfo(w, c.workloadInfoOptions...)
 	c.Workloads[k] = wi
+	c.generation++
 	c.updateWorkloadUsage(wi, 1)
 	if c.podsReadyTracking && !apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadPodsReady) {
 		c.WorkloadsNotReady.Insert(k)
@@ -497,6 +522,7 @@ func (c *clusterQueue) deleteWorkload(w *kueue.Workload) {
 	if !exist {
 		return
 	}
+	c.generation++
 	c.updateWorkloadUsage(wi, -1)
 	if c.podsReadyTracking && !apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadPodsReady) {
 		c.WorkloadsNotReady.Delete(k)
@@ -590,6 +616,8 @@ func (c *clusterQueue) addLocalQueue(q *kueue.LocalQueue) error {
 		key:                qKey,
 		reservingWorkloads: 0,
 		usage:              make(resources.FlavorResourceQuantities),
+		consumedResources:  consumedResourcesFromStatus(q.Status.FairSharing),
+		fairWeight:         localQueueFairWeight(q),
 	}
 	qImpl.resetFlavorsAndResources(c.resourceNode.Usage, c.AdmittedUsage)
 	for _, wl := range c.Workloads {
@@ -666,6 +694,10 @@ func (c *clusterQueue) resourceGroups() []ResourceGroup {
 	return c.ResourceGroups
 }
 
+func (c *clusterQueue) netQuota() resources.FlavorResourceQuantities {
+	return remainingQuota(c)
+}
+
 // DominantResourceShare returns a value from 0 to 1,000,000 representing the maximum of the ratios
 // of usage above nominal quota to the lendable resources in the cohort, among all the resources
 // provided by the ClusterQueue, and divided by the weight.
@@ -688,8 +720,9 @@ type dominantResourceShareNode interface {
 	HasParent() bool
 	parentResources() ResourceNode
 	fairWeight() *resource.Quantity
-
-	netQuotaNode
+	// netQuota returns the remaining quota of the node for each
+	// FlavorResource. A negative value implies that the node is borrowing.
+	netQuota() resources.FlavorResourceQuantities
 }
 
 func dominantResourceShare(node dominantResourceShareNode, wlReq resources.FlavorResourceQuantities, m int64) (int, corev1.ResourceName) {
@@ -701,7 +734,7 @@ func dominantResourceShare(node dominantResourceShareNode, wlReq resources.Flavo
 	}
 
 	borrowing := make(map[corev1.ResourceName]int64)
-	for fr, quota := range remainingQuota(node) {
+	for fr, quota := range node.netQuota() {
 		b := m*wlReq[fr] - quota
 		if b > 0 {
 			borrowing[fr.Resource] += b
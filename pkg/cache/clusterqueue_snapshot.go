@@ -17,6 +17,8 @@ limitations under the License.
 package cache
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/labels"
@@ -38,6 +40,8 @@ type ClusterQueueSnapshot struct {
 	Preemption        kueue.ClusterQueuePreemption
 	FairWeight        resource.Quantity
 	FlavorFungibility kueue.FlavorFungibility
+	// FlavorSelectionPolicy is empty for the DeclarationOrder policy.
+	FlavorSelectionPolicy kueue.FlavorSelectionPolicy
 	// Aggregates AdmissionChecks from both .spec.AdmissionChecks and .spec.AdmissionCheckStrategy
 	// Sets hold ResourceFlavors to which an AdmissionCheck should apply.
 	// In case its empty, it means an AdmissionCheck should apply to all ResourceFlavor
@@ -51,6 +55,45 @@ type ClusterQueueSnapshot struct {
 	hierarchy.ClusterQueue[*CohortSnapshot]
 
 	TASFlavors map[kueue.ResourceFlavorReference]*TASFlavorSnapshot
+
+	// ProvisioningAdmissionChecks holds the names of the AdmissionChecks
+	// managed by the ProvisioningRequest controller.
+	ProvisioningAdmissionChecks sets.Set[string]
+	// MultiKueueAdmissionChecks holds the names of the AdmissionChecks
+	// managed by the MultiKueue controller.
+	MultiKueueAdmissionChecks sets.Set[string]
+
+	// ConsumedResources is the historical resource consumption of the
+	// ClusterQueue, in resource-seconds. It is nil when admission fair
+	// sharing is disabled or nothing was consumed yet.
+	ConsumedResources map[corev1.ResourceName]float64
+
+	// BackfillMaxCandidates is the maximum number of workloads queued behind
+	// a blocked head which are considered for backfilling in a scheduling
+	// cycle. It is 0 if backfill is disabled.
+	BackfillMaxCandidates int32
+
+	// sharedWorkloads is true while the Workloads are shared with the
+	// snapshot of a previous scheduling cycle.
+	sharedWorkloads bool
+}
+
+// HasMultiKueueAdmissionCheck returns true if the ClusterQueue dispatches
+// the workloads to the MultiKueue worker clusters.
+func (c *ClusterQueueSnapshot) HasMultiKueueAdmissionCheck() bool {
+	return len(c.MultiKueueAdmissionChecks) > 0
+}
+
+// HasProvRequestAdmissionCheck returns true if the ClusterQueue has a
+// ProvisioningRequest AdmissionCheck which applies to the flavor.
+func (c *ClusterQueueSnapshot) HasProvRequestAdmissionCheck(flavor kueue.ResourceFlavorReference) bool {
+	for acName := range c.ProvisioningAdmissionChecks {
+		flavors, found := c.AdmissionChecks[acName]
+		if found && (flavors.Len() == 0 || flavors.Has(flavor)) {
+			return true
+		}
+	}
+	return false
 }
 
 // RGByResource returns the ResourceGroup which contains capacity
@@ -70,12 +113,78 @@ func (c *ClusterQueueSnapshot) AddUsage(frq resources.FlavorResourceQuantities)
 	}
 }
 
-func (c *ClusterQueueSnapshot) removeUsage(frq resources.FlavorResourceQuantities) {
+func (c *ClusterQueueSnapshot) RemoveUsage(frq resources.FlavorResourceQuantities) {
 	for fr, q := range frq {
 		removeUsage(c, fr, q)
 	}
 }
 
+// AddTASUsage accounts for the topology usage in the TAS flavors of the
+// ClusterQueue.
+func (c *ClusterQueueSnapshot) AddTASUsage(tasUsage map[kueue.ResourceFlavorReference][]workload.TopologyDomainRequests) {
+	for tasFlavor, topologyRequests := range tasUsage {
+		if s := c.TASFlavors[tasFlavor]; s != nil {
+			s.AddTASUsage(topologyRequests)
+		}
+	}
+}
+
+func (c *ClusterQueueSnapshot) removeTASUsage(tasUsage map[kueue.ResourceFlavorReference][]workload.TopologyDomainRequests) {
+	for tasFlavor, topologyRequests := range tasUsage {
+		if s := c.TASFlavors[tasFlavor]; s != nil {
+			s.RemoveTASUsage(topologyRequests)
+		}
+	}
+}
+
+// FitsTAS returns true if the topology usage still fits in the TAS flavors
+// of the ClusterQueue.
+func (c *ClusterQueueSnapshot) FitsTAS(tasUsage map[kueue.ResourceFlavorReference][]workload.TopologyDomainRequests) bool {
+	for tasFlavor, topologyRequests := range tasUsage {
+		s := c.TASFlavors[tasFlavor]
+		if s == nil || !s.FitsTASUsage(topologyRequests) {
+			return false
+		}
+	}
+	return true
+}
+
+// TASPodSetRequests holds the requests of a PodSet which requires Topology
+// Aware Scheduling in the given flavor.
+type TASPodSetRequests struct {
+	PodSet            *kueue.PodSet
+	SinglePodRequests resources.Requests
+	Count             int32
+	Flavor            kueue.ResourceFlavorReference
+}
+
+// FindTopologyAssignmentsForWorkload finds the topology assignments for all
+// the PodSets of a workload in the TAS flavors of the ClusterQueue. The
+// capacity assigned to a PodSet is not available for the following PodSets
+// using the same flavor. It returns the assignments by PodSet name, or the
+// reason if any of the PodSets doesn't fit.
+func (c *ClusterQueueSnapshot) FindTopologyAssignmentsForWorkload(tasRequests []TASPodSetRequests) (map[string]*kueue.TopologyAssignment, string) {
+	result := make(map[string]*kueue.TopologyAssignment, len(tasRequests))
+	tasUsage := make(map[kueue.ResourceFlavorReference][]workload.TopologyDomainRequests)
+	defer c.removeTASUsage(tasUsage)
+	for _, tr := range tasRequests {
+		s := c.TASFlavors[tr.Flavor]
+		if s == nil {
+			return nil, fmt.Sprintf("no TAS information for flavor %q", tr.Flavor)
+		}
+		assignment, reason := s.FindTopologyAssignment(tr.PodSet.TopologyRequest,
+			tr.SinglePodRequests, tr.Count, tr.PodSet.Template.Spec.Tolerations)
+		if assignment == nil {
+			return nil, reason
+		}
+		result[tr.PodSet.Name] = assignment
+		usage := workload.TopologyDomainRequestsFor(assignment, tr.SinglePodRequests)
+		s.AddTASUsage(usage)
+		tasUsage[tr.Flavor] = append(tasUsage[tr.Flavor], usage...)
+	}
+	return result, ""
+}
+
 func (c *ClusterQueueSnapshot) Fits(frq resources.FlavorResourceQuantities) bool {
 	for fr, q := range frq {
 		if c.Available(fr) < q {
@@ -127,6 +236,10 @@ func (c *ClusterQueueSnapshot) resourceGroups() []ResourceGroup {
 	return c.ResourceGroups
 }
 
+func (c *ClusterQueueSnapshot) netQuota() resources.FlavorResourceQuantities {
+	return remainingQuota(c)
+}
+
 func (c *ClusterQueueSnapshot) parentResources() ResourceNode {
 	return c.Parent().ResourceNode
 }
@@ -995,7 +995,7 @@ func TestClusterQueueReadinessWithTAS(t *testing.T) {
 			wantMessage: "Can admit new workloads",
 		},
 		{
-			name: "TAS do not support Cohorts",
+			name: "TAS CQ in cohort goes active state",
 			cq: utiltesting.MakeClusterQueue("cq").
 				ResourceGroup(
 					utiltesting.MakeFlavorQuotas("tas-flavor").
@@ -1008,11 +1008,34 @@ func TestClusterQueueReadinessWithTAS(t *testing.T) {
 						ResourceQuotaWrapper("example.com/gpu").NominalQuota("5").Append().
 						FlavorQuotas,
 				).Cohort("some-cohort").Obj(),
-			wantReason:  kueue.ClusterQueueActiveReasonNotSupportedWithTopologyAwareScheduling,
-			wantMessage: "Can't admit new workloads: TAS is not supported for cohorts.",
+			wantReason:  "Ready",
+			wantMessage: "Can admit new workloads",
 		},
 		{
-			name: "TAS do not support Preemption",
+			name: "TAS CQ with reclaim within cohort goes active state",
+			cq: utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("tas-flavor").
+						ResourceQuotaWrapper("example.com/gpu").NominalQuota("5").Append().
+						FlavorQuotas,
+				).Obj(),
+			updatedCq: utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(
+					utiltesting.MakeFlavorQuotas("tas-flavor").
+						ResourceQuotaWrapper("example.com/gpu").NominalQuota("5").Append().
+						FlavorQuotas,
+				).
+				Cohort("some-cohort").
+				Preemption(kueue.ClusterQueuePreemption{
+					WithinClusterQueue:  kueue.PreemptionPolicyNever,
+					ReclaimWithinCohort: kueue.PreemptionPolicyAny,
+				}).
+				Obj(),
+			wantReason:  "Ready",
+			wantMessage: "Can admit new workloads",
+		},
+		{
+			name: "TAS CQ with preemption goes active state",
 			cq: utiltesting.MakeClusterQueue("cq").
 				ResourceGroup(
 					utiltesting.MakeFlavorQuotas("tas-flavor").
@@ -1032,11 +1055,11 @@ func TestClusterQueueReadinessWithTAS(t *testing.T) {
 					WhenCanPreempt: kueue.Preempt,
 				}).
 				Obj(),
-			wantReason:  kueue.ClusterQueueActiveReasonNotSupportedWithTopologyAwareScheduling,
-			wantMessage: "Can't admit new workloads: TAS is not supported for preemption within cluster queue.",
+			wantReason:  "Ready",
+			wantMessage: "Can admit new workloads",
 		},
 		{
-			name: "TAS do not support MultiKueue AdmissionCheck",
+			name: "TAS supports MultiKueue AdmissionCheck",
 			cq: utiltesting.MakeClusterQueue("cq").
 				ResourceGroup(
 					utiltesting.MakeFlavorQuotas("tas-flavor").
@@ -1049,11 +1072,11 @@ func TestClusterQueueReadinessWithTAS(t *testing.T) {
 						ResourceQuotaWrapper("example.com/gpu").NominalQuota("5").Append().
 						FlavorQuotas,
 				).AdmissionChecks("mk-check").Obj(),
-			wantReason:  kueue.ClusterQueueActiveReasonNotSupportedWithTopologyAwareScheduling,
-			wantMessage: "Can't admit new workloads: TAS is not supported with MultiKueue admission check.",
+			wantReason:  kueue.ClusterQueueActiveReasonReady,
+			wantMessage: "Can admit new workloads",
 		},
 		{
-			name: "TAS do not support ProvisioningRequest AdmissionCheck",
+			name: "TAS supports ProvisioningRequest AdmissionCheck",
 			cq: utiltesting.MakeClusterQueue("cq").
 				ResourceGroup(
 					utiltesting.MakeFlavorQuotas("tas-flavor").
@@ -1066,8 +1089,8 @@ func TestClusterQueueReadinessWithTAS(t *testing.T) {
 						ResourceQuotaWrapper("example.com/gpu").NominalQuota("5").Append().
 						FlavorQuotas,
 				).AdmissionChecks("pr-check").Obj(),
-			wantReason:  kueue.ClusterQueueActiveReasonNotSupportedWithTopologyAwareScheduling,
-			wantMessage: "Can't admit new workloads: TAS is not supported with ProvisioningRequest admission check.",
+			wantReason:  kueue.ClusterQueueActiveReasonReady,
+			wantMessage: "Can admit new workloads",
 		},
 		{
 			name:         "Referenced TAS flavor without topology",
@@ -17,8 +17,11 @@ limitations under the License.
 package cache
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
+
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
+	"sigs.k8s.io/kueue/pkg/resources"
 )
 
 // cohort is a set of ClusterQueues that can borrow resources from each other.
@@ -27,18 +30,24 @@ type cohort struct {
 	hierarchy.Cohort[*clusterQueue, *cohort]
 
 	resourceNode ResourceNode
+	FairWeight   resource.Quantity
 }
 
 func newCohort(name string) *cohort {
 	return &cohort{
-		name,
-		hierarchy.NewCohort[*clusterQueue, *cohort](),
-		NewResourceNode(),
+		Name:         name,
+		Cohort:       hierarchy.NewCohort[*clusterQueue, *cohort](),
+		resourceNode: NewResourceNode(),
+		FairWeight:   oneQuantity,
 	}
 }
 
 func (c *cohort) updateCohort(cycleChecker hierarchy.CycleChecker, apiCohort *kueuealpha.Cohort, oldParent *cohort) error {
 	c.resourceNode.Quotas = createResourceQuotas(apiCohort.Spec.ResourceGroups)
+	c.FairWeight = oneQuantity
+	if fs := apiCohort.Spec.FairSharing; fs != nil && fs.Weight != nil {
+		c.FairWeight = *fs.Weight
+	}
 	if oldParent != nil && oldParent != c.Parent() {
 		// ignore error when old Cohort has cycle.
 		_ = updateCohortTreeResources(oldParent, cycleChecker)
@@ -72,3 +81,24 @@ func (c *cohort) parentHRN() hierarchicalResourceNode {
 func (c *cohort) CCParent() hierarchy.CycleCheckable {
 	return c.Parent()
 }
+
+// The methods below implement dominantResourceShareNode interface.
+
+func (c *cohort) parentResources() ResourceNode {
+	return c.Parent().resourceNode
+}
+
+func (c *cohort) fairWeight() *resource.Quantity {
+	return &c.FairWeight
+}
+
+// netQuota returns the quota of the Cohort subtree remaining after its
+// usage. A negative value implies that the subtree is borrowing from its
+// parent.
+func (c *cohort) netQuota() resources.FlavorResourceQuantities {
+	netQuota := make(resources.FlavorResourceQuantities, len(c.resourceNode.SubtreeQuota))
+	for fr, quota := range c.resourceNode.SubtreeQuota {
+		netQuota[fr] = quota - c.resourceNode.Usage[fr]
+	}
+	return netQuota
+}
@@ -16,13 +16,21 @@ limitations under the License.
 
 package cache
 
-import "sigs.k8s.io/kueue/pkg/hierarchy"
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/kueue/pkg/hierarchy"
+	"sigs.k8s.io/kueue/pkg/resources"
+)
 
 type CohortSnapshot struct {
 	Name string
 
 	ResourceNode ResourceNode
 	hierarchy.Cohort[*ClusterQueueSnapshot, *CohortSnapshot]
+
+	FairWeight resource.Quantity
 }
 
 func (c *CohortSnapshot) GetName() string {
@@ -70,3 +78,35 @@ func (c *CohortSnapshot) getResourceNode() ResourceNode {
 func (c *CohortSnapshot) parentHRN() hierarchicalResourceNode {
 	return c.Parent()
 }
+
+// DominantResourceShare returns the share of the Cohort subtree within its
+// parent Cohort. See ClusterQueueSnapshot.DominantResourceShare.
+func (c *CohortSnapshot) DominantResourceShare() (int, corev1.ResourceName) {
+	return dominantResourceShare(c, nil, 0)
+}
+
+func (c *CohortSnapshot) DominantResourceShareWith(wlReq resources.FlavorResourceQuantities) (int, corev1.ResourceName) {
+	return dominantResourceShare(c, wlReq, 1)
+}
+
+func (c *CohortSnapshot) DominantResourceShareWithout(wlReq resources.FlavorResourceQuantities) (int, corev1.ResourceName) {
+	return dominantResourceShare(c, wlReq, -1)
+}
+
+// The methods below implement dominantResourceShareNode interface.
+
+func (c *CohortSnapshot) parentResources() ResourceNode {
+	return c.Parent().ResourceNode
+}
+
+func (c *CohortSnapshot) fairWeight() *resource.Quantity {
+	return &c.FairWeight
+}
+
+func (c *CohortSnapshot) netQuota() resources.FlavorResourceQuantities {
+	netQuota := make(resources.FlavorResourceQuantities, len(c.ResourceNode.SubtreeQuota))
+	for fr, quota := range c.ResourceNode.SubtreeQuota {
+		netQuota[fr] = quota - c.ResourceNode.Usage[fr]
+	}
+	return netQuota
+}
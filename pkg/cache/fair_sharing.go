@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// FairSharingNode is a node of the Cohort tree, a ClusterQueue or a Cohort,
+// which competes with its siblings for the resources of its parent Cohort.
+type FairSharingNode interface {
+	GetName() string
+	DominantResourceShare() (int, corev1.ResourceName)
+	DominantResourceShareWith(wlReq resources.FlavorResourceQuantities) (int, corev1.ResourceName)
+	DominantResourceShareWithout(wlReq resources.FlavorResourceQuantities) (int, corev1.ResourceName)
+}
+
+var _ FairSharingNode = (*ClusterQueueSnapshot)(nil)
+var _ FairSharingNode = (*CohortSnapshot)(nil)
+
+// FairSharingPath returns the nodes on the path from the root of the Cohort
+// tree down to the ClusterQueue, inclusive. It expects that no cycles exist
+// in the Cohort graph.
+func (c *ClusterQueueSnapshot) FairSharingPath() []FairSharingNode {
+	var path []FairSharingNode
+	if c.HasParent() {
+		path = c.Parent().fairSharingPath()
+	}
+	return append(path, c)
+}
+
+func (c *CohortSnapshot) fairSharingPath() []FairSharingNode {
+	var path []FairSharingNode
+	if c.HasParent() {
+		path = c.Parent().fairSharingPath()
+	}
+	return append(path, c)
+}
+
+// FairSharingSiblings returns the nodes competing for resources on behalf of
+// the ClusterQueues a and b: the ancestors of a and b, or the ClusterQueues
+// themselves, which are children of the lowest common ancestor Cohort.
+// When a and b don't share a Cohort tree, a and b are returned.
+func FairSharingSiblings(a, b *ClusterQueueSnapshot) (FairSharingNode, FairSharingNode) {
+	pathA := a.FairSharingPath()
+	pathB := b.FairSharingPath()
+	if pathA[0] != pathB[0] {
+		return a, b
+	}
+	i := DivergingLevel(pathA, pathB)
+	return pathA[min(i, len(pathA)-1)], pathB[min(i, len(pathB)-1)]
+}
+
+// DivergingLevel returns the index of the first level at which the paths,
+// returned by FairSharingPath, point to different nodes.
+func DivergingLevel(pathA, pathB []FairSharingNode) int {
+	i := 0
+	for i < len(pathA) && i < len(pathB) && pathA[i] == pathB[i] {
+		i++
+	}
+	return i
+}
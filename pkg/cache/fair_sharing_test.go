@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestFairSharingSiblings(t *testing.T) {
+	cohorts := []*kueuealpha.Cohort{
+		utiltesting.MakeCohort("root").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+		utiltesting.MakeCohort("org-a").
+			Parent("root").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+			Obj(),
+		utiltesting.MakeCohort("org-b").Parent("root").FairWeight(resource.MustParse("2")).Obj(),
+		utiltesting.MakeCohort("other").Obj(),
+	}
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("a1").
+			Cohort("org-a").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("a2").
+			Cohort("org-a").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("b1").
+			Cohort("org-b").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("c1").
+			Cohort("root").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("o1").
+			Cohort("other").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+			Obj(),
+	}
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("a1", "").
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuota(utiltesting.MakeAdmission("a1").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+			Obj(),
+		utiltesting.MakeWorkload("a2", "").
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuota(utiltesting.MakeAdmission("a2").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+			Obj(),
+		utiltesting.MakeWorkload("b1", "").
+			Request(corev1.ResourceCPU, "6").
+			ReserveQuota(utiltesting.MakeAdmission("b1").Assignment(corev1.ResourceCPU, "default", "6").Obj()).
+			Obj(),
+	}
+	ctx := context.Background()
+	cache := New(utiltesting.NewFakeClient())
+	cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	for _, cohort := range cohorts {
+		if err := cache.AddOrUpdateCohort(cohort); err != nil {
+			t.Fatalf("Adding Cohort: %v", err)
+		}
+	}
+	for _, cq := range clusterQueues {
+		if err := cache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Adding ClusterQueue: %v", err)
+		}
+	}
+	for _, w := range workloads {
+		if added := cache.AddOrUpdateWorkload(w); !added {
+			t.Fatalf("Workload %s was not added", workload.Key(w))
+		}
+	}
+	snapshot, err := cache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+
+	cases := map[string]struct {
+		a, b      string
+		wantNodes [2]string
+		// wantShares are the shares of the returned nodes.
+		wantShares [2]int
+	}{
+		"same Cohort": {
+			a:          "a1",
+			b:          "a2",
+			wantNodes:  [2]string{"a1", "a2"},
+			wantShares: [2]int{1000, 1000},
+		},
+		"sibling Cohorts, weighted": {
+			a:          "a1",
+			b:          "b1",
+			wantNodes:  [2]string{"org-a", "org-b"},
+			wantShares: [2]int{166, 250},
+		},
+		"Cohort and ClusterQueue": {
+			a:          "c1",
+			b:          "a2",
+			wantNodes:  [2]string{"c1", "org-a"},
+			wantShares: [2]int{0, 166},
+		},
+		"different Cohort trees": {
+			a:          "a1",
+			b:          "o1",
+			wantNodes:  [2]string{"a1", "o1"},
+			wantShares: [2]int{1000, 0},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nodeA, nodeB := FairSharingSiblings(snapshot.ClusterQueues[tc.a], snapshot.ClusterQueues[tc.b])
+			if gotNodes := [2]string{nodeA.GetName(), nodeB.GetName()}; gotNodes != tc.wantNodes {
+				t.Errorf("Unexpected nodes, got %v, want %v", gotNodes, tc.wantNodes)
+			}
+			shareA, _ := nodeA.DominantResourceShare()
+			shareB, _ := nodeB.DominantResourceShare()
+			if gotShares := [2]int{shareA, shareB}; gotShares != tc.wantShares {
+				t.Errorf("Unexpected shares, got %v, want %v", gotShares, tc.wantShares)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+func localQueueFairWeight(q *kueue.LocalQueue) *resource.Quantity {
+	if q.Spec.FairSharing == nil {
+		return nil
+	}
+	return q.Spec.FairSharing.Weight
+}
+
+// LocalQueueShares returns the shares of the LocalQueues of the ClusterQueue,
+// by LocalQueue key, or nil if the ClusterQueue doesn't have fair sharing
+// between its LocalQueues enabled.
+func (c *Cache) LocalQueueShares(cqName string) map[string]int64 {
+	c.RLock()
+	defer c.RUnlock()
+	cq := c.hm.ClusterQueues[cqName]
+	if cq == nil || cq.localQueueFairSharing == nil {
+		return nil
+	}
+	mode := c.localQueueUsageMode(cq)
+	shares := make(map[string]int64, len(cq.localQueues))
+	for key, q := range cq.localQueues {
+		shares[key] = cq.localQueueShare(q, mode)
+	}
+	return shares
+}
+
+// localQueueUsageMode returns the usage of the LocalQueues which is compared
+// by the fair sharing between the LocalQueues of the ClusterQueue. The
+// historical usage is only sampled with the admission fair sharing, so the
+// current usage is compared instead when it's disabled.
+func (c *Cache) localQueueUsageMode(cq *clusterQueue) kueue.LocalQueueUsageMode {
+	if cq.localQueueFairSharing.UsageMode == kueue.LocalQueueHistoricalUsage && c.admissionFairSharing != nil {
+		return kueue.LocalQueueHistoricalUsage
+	}
+	return kueue.LocalQueueCurrentUsage
+}
+
+// localQueueShare returns the maximum, among the resources, of the ratio of
+// the usage of the LocalQueue, in the given mode, to the nominal quota of the
+// ClusterQueue, divided by the weight of the LocalQueue.
+func (c *clusterQueue) localQueueShare(q *queue, mode kueue.LocalQueueUsageMode) int64 {
+	var usage map[corev1.ResourceName]float64
+	if mode == kueue.LocalQueueHistoricalUsage {
+		usage = q.consumedResources.values
+	} else {
+		usage = make(map[corev1.ResourceName]float64)
+		for fr, v := range q.usage {
+			usage[fr.Resource] += float64(v)
+		}
+	}
+	nominal := make(map[corev1.ResourceName]int64)
+	for fr, quota := range c.resourceNode.Quotas {
+		nominal[fr.Resource] += quota.Nominal
+	}
+	var ratio int64
+	for name, v := range usage {
+		if nominal[name] > 0 && v > 0 {
+			ratio = max(ratio, int64(v*1000/float64(nominal[name])))
+		}
+	}
+	weight := ptr.Deref(q.fairWeight, oneQuantity)
+	if weight.IsZero() || weight.Sign() < 0 {
+		return math.MaxInt64
+	}
+	return ratio * 1000 / weight.MilliValue()
+}
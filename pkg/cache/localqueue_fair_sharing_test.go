@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestLocalQueueShares(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("a1", "ns").Queue("lq-a").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "4").Obj()).
+			Obj(),
+		utiltesting.MakeWorkload("b1", "ns").Queue("lq-b").
+			ReserveQuota(utiltesting.MakeAdmission("cq").
+				Assignment(corev1.ResourceCPU, "default", "1").
+				Assignment(corev1.ResourceMemory, "default", "6Gi").Obj()).
+			Obj(),
+	}
+	cases := map[string]struct {
+		cq         *kueue.ClusterQueue
+		localQueue []*kueue.LocalQueue
+		// samples are taken before the workloads are added.
+		samples                     []time.Duration
+		disableAdmissionFairSharing bool
+		want                        map[string]int64
+	}{
+		"fair sharing between LocalQueues disabled": {
+			cq: utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "10").
+					Resource(corev1.ResourceMemory, "10Gi").Obj()).
+				Obj(),
+			localQueue: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("lq-a", "ns").ClusterQueue("cq").Obj(),
+				utiltesting.MakeLocalQueue("lq-b", "ns").ClusterQueue("cq").Obj(),
+			},
+		},
+		"current usage": {
+			cq: utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "10").
+					Resource(corev1.ResourceMemory, "10Gi").Obj()).
+				LocalQueueFairSharing(kueue.LocalQueueCurrentUsage).
+				Obj(),
+			localQueue: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("lq-a", "ns").ClusterQueue("cq").Obj(),
+				utiltesting.MakeLocalQueue("lq-b", "ns").ClusterQueue("cq").Obj(),
+				utiltesting.MakeLocalQueue("lq-c", "ns").ClusterQueue("cq").Obj(),
+			},
+			want: map[string]int64{
+				"ns/lq-a": 400,
+				"ns/lq-b": 600,
+				"ns/lq-c": 0,
+			},
+		},
+		"current usage with weights": {
+			cq: utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "10").
+					Resource(corev1.ResourceMemory, "10Gi").Obj()).
+				LocalQueueFairSharing(kueue.LocalQueueCurrentUsage).
+				Obj(),
+			localQueue: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("lq-a", "ns").ClusterQueue("cq").FairWeight(resource.MustParse("0.5")).Obj(),
+				utiltesting.MakeLocalQueue("lq-b", "ns").ClusterQueue("cq").FairWeight(resource.MustParse("0")).Obj(),
+			},
+			want: map[string]int64{
+				"ns/lq-a": 800,
+				"ns/lq-b": math.MaxInt64,
+			},
+		},
+		"historical usage": {
+			cq: utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "10").
+					Resource(corev1.ResourceMemory, "10Gi").Obj()).
+				LocalQueueFairSharing(kueue.LocalQueueHistoricalUsage).
+				Obj(),
+			localQueue: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("lq-a", "ns").ClusterQueue("cq").Obj(),
+				utiltesting.MakeLocalQueue("lq-b", "ns").ClusterQueue("cq").Obj(),
+			},
+			samples: []time.Duration{0, time.Second},
+			want: map[string]int64{
+				"ns/lq-a": 400,
+				"ns/lq-b": 600,
+			},
+		},
+		"historical usage without admission fair sharing falls back to the current usage": {
+			cq: utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "10").
+					Resource(corev1.ResourceMemory, "10Gi").Obj()).
+				LocalQueueFairSharing(kueue.LocalQueueHistoricalUsage).
+				Obj(),
+			localQueue: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("lq-a", "ns").ClusterQueue("cq").Obj(),
+				utiltesting.MakeLocalQueue("lq-b", "ns").ClusterQueue("cq").Obj(),
+			},
+			disableAdmissionFairSharing: true,
+			want: map[string]int64{
+				"ns/lq-a": 400,
+				"ns/lq-b": 600,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var afs *config.AdmissionFairSharing
+			if !tc.disableAdmissionFairSharing {
+				afs = &config.AdmissionFairSharing{
+					UsageHalfLifeTime:     &metav1.Duration{Duration: time.Hour},
+					UsageSamplingInterval: &metav1.Duration{Duration: time.Minute},
+				}
+			}
+			cache := New(utiltesting.NewFakeClient(), WithAdmissionFairSharing(afs))
+			if err := cache.AddClusterQueue(context.Background(), tc.cq); err != nil {
+				t.Fatalf("Adding ClusterQueue: %v", err)
+			}
+			for _, lq := range tc.localQueue {
+				if err := cache.AddLocalQueue(lq); err != nil {
+					t.Fatalf("Adding LocalQueue: %v", err)
+				}
+			}
+			for _, wl := range workloads {
+				cache.AddOrUpdateWorkload(wl)
+			}
+			for _, d := range tc.samples {
+				cache.SampleConsumedResources(now.Add(d))
+			}
+			if tc.samples != nil {
+				for _, wl := range workloads {
+					if err := cache.DeleteWorkload(wl); err != nil {
+						t.Fatalf("Deleting workload: %v", err)
+					}
+				}
+			}
+
+			if diff := cmp.Diff(tc.want, cache.LocalQueueShares("cq")); diff != "" {
+				t.Errorf("Unexpected LocalQueue shares (-want,+got):\n%s", diff)
+			}
+			for _, lq := range tc.localQueue {
+				stats, err := cache.LocalQueueUsage(lq)
+				if err != nil {
+					t.Fatalf("Getting LocalQueue usage: %v", err)
+				}
+				var want *int64
+				if share, found := tc.want["ns/"+lq.Name]; found {
+					want = &share
+				}
+				if diff := cmp.Diff(want, stats.WeightedShare); diff != "" {
+					t.Errorf("Unexpected weighted share of %q (-want,+got):\n%s", lq.Name, diff)
+				}
+			}
+		})
+	}
+}
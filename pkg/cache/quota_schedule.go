@@ -0,0 +1,173 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"maps"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/hierarchy"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// QuotaOverride is the quota of a flavor-resource of a ClusterQueue during
+// an active window of a QuotaSchedule. The nil fields keep the value from
+// the ClusterQueue spec.
+type QuotaOverride struct {
+	Nominal        *int64
+	BorrowingLimit *int64
+	LendingLimit   *int64
+}
+
+// quotaSchedule holds the quota overrides of the active window of a
+// QuotaSchedule.
+type quotaSchedule struct {
+	clusterQueue string
+	overrides    map[resources.FlavorResource]QuotaOverride
+}
+
+// SetQuotaScheduleOverrides sets the quota overrides of the active window of
+// the QuotaSchedule, and applies them to the ClusterQueue. Nil overrides
+// restore the quota from the ClusterQueue spec.
+func (c *Cache) SetQuotaScheduleOverrides(name, cqName string, overrides map[resources.FlavorResource]QuotaOverride) error {
+	c.Lock()
+	defer c.Unlock()
+	c.generation++
+	old, found := c.quotaSchedules[name]
+	c.quotaSchedules[name] = &quotaSchedule{
+		clusterQueue: cqName,
+		overrides:    overrides,
+	}
+	if found && old.clusterQueue != cqName {
+		if err := c.applyQuotaOverrides(old.clusterQueue); err != nil {
+			return err
+		}
+	}
+	return c.applyQuotaOverrides(cqName)
+}
+
+// DeleteQuotaSchedule removes the QuotaSchedule, restoring the quota of its
+// ClusterQueue.
+func (c *Cache) DeleteQuotaSchedule(name string) error {
+	c.Lock()
+	defer c.Unlock()
+	c.generation++
+	old, found := c.quotaSchedules[name]
+	if !found {
+		return nil
+	}
+	delete(c.quotaSchedules, name)
+	return c.applyQuotaOverrides(old.clusterQueue)
+}
+
+// QuotaScheduleClusterQueue returns the name of the ClusterQueue of the
+// QuotaSchedule.
+func (c *Cache) QuotaScheduleClusterQueue(name string) (string, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	qs, found := c.quotaSchedules[name]
+	if !found {
+		return "", false
+	}
+	return qs.clusterQueue, true
+}
+
+// quotaOverridesFor merges the overrides of the QuotaSchedules of the
+// ClusterQueue. When several QuotaSchedules override the same
+// flavor-resource, the one with the lowest name takes precedence.
+func (c *Cache) quotaOverridesFor(cqName string) map[resources.FlavorResource]QuotaOverride {
+	names := utilmaps.Keys(c.quotaSchedules)
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	var merged map[resources.FlavorResource]QuotaOverride
+	for _, name := range names {
+		qs := c.quotaSchedules[name]
+		if qs.clusterQueue != cqName || len(qs.overrides) == 0 {
+			continue
+		}
+		if merged == nil {
+			merged = make(map[resources.FlavorResource]QuotaOverride, len(qs.overrides))
+		}
+		maps.Copy(merged, qs.overrides)
+	}
+	return merged
+}
+
+func (c *Cache) applyQuotaOverrides(cqName string) error {
+	cq, found := c.hm.ClusterQueues[cqName]
+	if !found {
+		return nil
+	}
+	return cq.updateQuotaOverrides(c.hm.CycleChecker, c.quotaOverridesFor(cqName))
+}
+
+// updateQuotaOverrides applies the overrides on top of the quotas from the
+// spec, updating the resources of the Cohort tree if they changed.
+func (c *clusterQueue) updateQuotaOverrides(cycleChecker hierarchy.CycleChecker, overrides map[resources.FlavorResource]QuotaOverride) error {
+	oldQuotas := c.resourceNode.Quotas
+	c.quotaOverrides = overrides
+	c.resourceNode.Quotas = overrideQuotas(c.specQuotas, overrides)
+	if equality.Semantic.DeepEqual(oldQuotas, c.resourceNode.Quotas) {
+		return nil
+	}
+	if c.HasParent() {
+		return updateCohortTreeResources(c.Parent(), cycleChecker)
+	}
+	updateClusterQueueResourceNode(c)
+	return nil
+}
+
+// overrideQuotas returns the quotas with the overrides applied. Overrides
+// of flavor-resources not in the quotas are ignored.
+func overrideQuotas(quotas map[resources.FlavorResource]ResourceQuota, overrides map[resources.FlavorResource]QuotaOverride) map[resources.FlavorResource]ResourceQuota {
+	if len(overrides) == 0 {
+		return quotas
+	}
+	result := maps.Clone(quotas)
+	for fr, o := range overrides {
+		quota, found := result[fr]
+		if !found {
+			continue
+		}
+		if o.Nominal != nil {
+			quota.Nominal = *o.Nominal
+		}
+		if o.BorrowingLimit != nil {
+			quota.BorrowingLimit = o.BorrowingLimit
+		}
+		if o.LendingLimit != nil && features.Enabled(features.LendingLimit) {
+			quota.LendingLimit = o.LendingLimit
+		}
+		result[fr] = quota
+	}
+	return result
+}
+
+// ClusterQueueReclaimTargets returns the workloads of the ClusterQueue which
+// need to be evicted so that its usage fits its quota, for instance after
+// the quota was reduced. The targets are removed from the snapshot.
+func (s *Snapshot) ClusterQueueReclaimTargets(cqName string) []*workload.Info {
+	cq, found := s.ClusterQueues[cqName]
+	if !found {
+		return nil
+	}
+	return s.reclaimTargets(cq, utilmaps.Keys(cq.ResourceNode.Quotas), utilmaps.Values(cq.Workloads))
+}
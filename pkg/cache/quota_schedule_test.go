@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestQuotaScheduleOverrides(t *testing.T) {
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	type step struct {
+		set    map[string]map[resources.FlavorResource]QuotaOverride
+		delete []string
+	}
+	cases := map[string]struct {
+		steps             []step
+		wantNominal       int64
+		wantBorrowing     *int64
+		wantCohortSubtree int64
+	}{
+		"no schedule": {
+			wantNominal:       10_000,
+			wantCohortSubtree: 10_000,
+		},
+		"override applied": {
+			steps: []step{{
+				set: map[string]map[resources.FlavorResource]QuotaOverride{
+					"night": {cpu: {Nominal: ptr.To[int64](20_000), BorrowingLimit: ptr.To[int64](5_000)}},
+				},
+			}},
+			wantNominal:       20_000,
+			wantBorrowing:     ptr.To[int64](5_000),
+			wantCohortSubtree: 20_000,
+		},
+		"lowest name takes precedence": {
+			steps: []step{{
+				set: map[string]map[resources.FlavorResource]QuotaOverride{
+					"b": {cpu: {Nominal: ptr.To[int64](20_000)}},
+					"a": {cpu: {Nominal: ptr.To[int64](4_000)}},
+				},
+			}},
+			wantNominal:       4_000,
+			wantCohortSubtree: 4_000,
+		},
+		"nil overrides restore the spec": {
+			steps: []step{
+				{set: map[string]map[resources.FlavorResource]QuotaOverride{
+					"night": {cpu: {Nominal: ptr.To[int64](20_000)}},
+				}},
+				{set: map[string]map[resources.FlavorResource]QuotaOverride{
+					"night": nil,
+				}},
+			},
+			wantNominal:       10_000,
+			wantCohortSubtree: 10_000,
+		},
+		"delete restores the spec": {
+			steps: []step{
+				{set: map[string]map[resources.FlavorResource]QuotaOverride{
+					"night": {cpu: {Nominal: ptr.To[int64](20_000)}},
+				}},
+				{delete: []string{"night"}},
+			},
+			wantNominal:       10_000,
+			wantCohortSubtree: 10_000,
+		},
+		"unknown flavor is ignored": {
+			steps: []step{{
+				set: map[string]map[resources.FlavorResource]QuotaOverride{
+					"night": {{Flavor: "other", Resource: corev1.ResourceCPU}: {Nominal: ptr.To[int64](20_000)}},
+				},
+			}},
+			wantNominal:       10_000,
+			wantCohortSubtree: 10_000,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			cache := New(utiltesting.NewFakeClient())
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			cq := utiltesting.MakeClusterQueue("cq").
+				Cohort("team").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+				Obj()
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Adding ClusterQueue: %v", err)
+			}
+			for _, s := range tc.steps {
+				for qsName, overrides := range s.set {
+					if err := cache.SetQuotaScheduleOverrides(qsName, "cq", overrides); err != nil {
+						t.Fatalf("Setting the overrides: %v", err)
+					}
+				}
+				for _, qsName := range s.delete {
+					if err := cache.DeleteQuotaSchedule(qsName); err != nil {
+						t.Fatalf("Deleting the QuotaSchedule: %v", err)
+					}
+				}
+			}
+
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Taking the snapshot: %v", err)
+			}
+			cqSnapshot := snapshot.ClusterQueues["cq"]
+			quota := cqSnapshot.ResourceNode.Quotas[cpu]
+			if quota.Nominal != tc.wantNominal {
+				t.Errorf("Unexpected nominal quota, want=%d, got=%d", tc.wantNominal, quota.Nominal)
+			}
+			if diff := cmp.Diff(tc.wantBorrowing, quota.BorrowingLimit); diff != "" {
+				t.Errorf("Unexpected borrowing limit (-want,+got):\n%s", diff)
+			}
+			if got := cqSnapshot.Parent().ResourceNode.SubtreeQuota[cpu]; got != tc.wantCohortSubtree {
+				t.Errorf("Unexpected subtree quota of the Cohort, want=%d, got=%d", tc.wantCohortSubtree, got)
+			}
+		})
+	}
+}
+
+func TestClusterQueueReclaimTargets(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	workloads := []*workload.Info{
+		workload.NewInfo(utiltesting.MakeWorkload("low", "ns").
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "3").Obj(), now).
+			Obj()),
+		workload.NewInfo(utiltesting.MakeWorkload("high", "ns").
+			Priority(100).
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "3").Obj(), now).
+			Obj()),
+		workload.NewInfo(utiltesting.MakeWorkload("newer", "ns").
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "3").Obj(), now.Add(time.Minute)).
+			Obj()),
+	}
+	cases := map[string]struct {
+		nominal     *int64
+		wantTargets []string
+	}{
+		"usage within the quota": {},
+		"quota reduced": {
+			nominal:     ptr.To[int64](4_000),
+			wantTargets: []string{"ns/newer", "ns/low"},
+		},
+		"quota slightly reduced": {
+			nominal:     ptr.To[int64](8_000),
+			wantTargets: []string{"ns/newer"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			cache := New(utiltesting.NewFakeClient())
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+				Obj()
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Adding ClusterQueue: %v", err)
+			}
+			for _, wl := range workloads {
+				cache.AddOrUpdateWorkload(wl.Obj)
+			}
+			if tc.nominal != nil {
+				if err := cache.SetQuotaScheduleOverrides("qs", "cq", map[resources.FlavorResource]QuotaOverride{cpu: {Nominal: tc.nominal}}); err != nil {
+					t.Fatalf("Setting the overrides: %v", err)
+				}
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Taking the snapshot: %v", err)
+			}
+			var got []string
+			for _, target := range snapshot.ClusterQueueReclaimTargets("cq") {
+				got = append(got, workload.Key(target.Obj))
+			}
+			if diff := cmp.Diff(tc.wantTargets, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected targets (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// reservation is the cache representation of a Reservation.
+type reservation struct {
+	name         string
+	clusterQueue string
+	cohort       string
+	quota        resources.FlavorResourceQuantities
+	start        time.Time
+	end          time.Time
+	// holdFrom is the time from which the reserved quota is held, that is
+	// the start of the window minus the lead time.
+	holdFrom time.Time
+}
+
+func newReservation(r *kueuealpha.Reservation) *reservation {
+	res := &reservation{
+		name:         r.Name,
+		clusterQueue: string(r.Spec.ClusterQueue),
+		cohort:       r.Spec.Cohort,
+		quota:        make(resources.FlavorResourceQuantities),
+		start:        r.Spec.StartTime.Time,
+		end:          r.Spec.EndTime.Time,
+		holdFrom:     r.Spec.StartTime.Time,
+	}
+	if r.Spec.LeadTime != nil {
+		res.holdFrom = res.start.Add(-r.Spec.LeadTime.Duration)
+	}
+	for _, flv := range r.Spec.Flavors {
+		for name, q := range flv.Resources {
+			res.quota[resources.FlavorResource{Flavor: flv.Name, Resource: name}] = resources.ResourceValue(name, q)
+		}
+	}
+	return res
+}
+
+// holding returns true if the reserved quota is held at the given time.
+func (r *reservation) holding(now time.Time) bool {
+	return !now.Before(r.holdFrom) && now.Before(r.end)
+}
+
+// started returns true if the window of the reservation is ongoing.
+func (r *reservation) started(now time.Time) bool {
+	return !now.Before(r.start) && now.Before(r.end)
+}
+
+// AddOrUpdateReservation adds or updates the Reservation in the cache.
+func (c *Cache) AddOrUpdateReservation(r *kueuealpha.Reservation) {
+	c.Lock()
+	defer c.Unlock()
+	c.reservations[r.Name] = newReservation(r)
+}
+
+// DeleteReservation removes the Reservation from the cache.
+func (c *Cache) DeleteReservation(name string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.reservations, name)
+}
+
+// ReservationClusterQueues returns the names of the ClusterQueues which can
+// use the quota of the Reservation.
+func (c *Cache) ReservationClusterQueues(name string) sets.Set[string] {
+	c.RLock()
+	defer c.RUnlock()
+	cqs := sets.New[string]()
+	r, found := c.reservations[name]
+	if !found {
+		return cqs
+	}
+	if r.clusterQueue != "" {
+		return cqs.Insert(r.clusterQueue)
+	}
+	if cohort, found := c.hm.Cohorts[r.cohort]; found && !c.hm.CycleChecker.HasCycle(cohort) {
+		collectSubtreeClusterQueues(cohort, cqs)
+	}
+	return cqs
+}
+
+func collectSubtreeClusterQueues(cohort *cohort, cqs sets.Set[string]) {
+	for _, cq := range cohort.ChildCQs() {
+		cqs.Insert(cq.Name)
+	}
+	for _, child := range cohort.ChildCohorts() {
+		collectSubtreeClusterQueues(child, cqs)
+	}
+}
+
+// ReservationSnapshot is the state of a Reservation, holding its quota, at
+// the time of the Snapshot.
+type ReservationSnapshot struct {
+	Name string
+	// ClusterQueue or Cohort whose quota is reserved.
+	ClusterQueue string
+	Cohort       string
+	Quota        resources.FlavorResourceQuantities
+	Start        time.Time
+	End          time.Time
+	// Started is true during the window of the Reservation. Before the
+	// window starts, the quota is held but can't be used by any workload.
+	Started bool
+	// Held is the part of the reserved quota which is accounted as usage of
+	// the node of the Reservation, as it isn't used by the workloads which
+	// reference the Reservation.
+	Held resources.FlavorResourceQuantities
+
+	node hierarchicalResourceNode
+}
+
+// addReservation accounts the quota held by the reservation as usage of
+// the ClusterQueue or Cohort it reserves quota from.
+func (s *Snapshot) addReservation(r *reservation, now time.Time) {
+	if !r.holding(now) {
+		return
+	}
+	rs := &ReservationSnapshot{
+		Name:         r.name,
+		ClusterQueue: r.clusterQueue,
+		Cohort:       r.cohort,
+		Quota:        r.quota,
+		Start:        r.start,
+		End:          r.end,
+		Started:      r.started(now),
+		Held:         make(resources.FlavorResourceQuantities, len(r.quota)),
+	}
+	if r.clusterQueue != "" {
+		cq, found := s.ClusterQueues[r.clusterQueue]
+		if !found {
+			return
+		}
+		rs.node = cq
+	} else {
+		cohort, found := s.Cohorts[r.cohort]
+		if !found {
+			return
+		}
+		rs.node = cohort
+	}
+	used := make(resources.FlavorResourceQuantities)
+	if rs.Started {
+		for _, cq := range s.reservationClusterQueues(rs) {
+			for _, wl := range cq.Workloads {
+				if wl.Obj.Labels[kueuealpha.ReservationLabel] != rs.Name {
+					continue
+				}
+				for fr, v := range wl.FlavorResourceUsage() {
+					used[fr] += v
+				}
+			}
+		}
+	}
+	for fr, v := range rs.Quota {
+		held := max(0, v-used[fr])
+		rs.Held[fr] = held
+		addUsage(rs.node, fr, held)
+	}
+	s.Reservations[rs.Name] = rs
+}
+
+// reservationClusterQueues returns the ClusterQueues which can use the
+// quota of the Reservation.
+func (s *Snapshot) reservationClusterQueues(r *ReservationSnapshot) []*ClusterQueueSnapshot {
+	switch node := r.node.(type) {
+	case *ClusterQueueSnapshot:
+		return []*ClusterQueueSnapshot{node}
+	case *CohortSnapshot:
+		return node.SubtreeClusterQueues()
+	}
+	return nil
+}
+
+// ReservationClusterQueues returns the names of the ClusterQueues which can
+// use the quota of the Reservation, if it's holding quota.
+func (s *Snapshot) ReservationClusterQueues(name string) []string {
+	r, found := s.Reservations[name]
+	if !found {
+		return nil
+	}
+	cqs := s.reservationClusterQueues(r)
+	names := make([]string, 0, len(cqs))
+	for _, cq := range cqs {
+		names = append(names, cq.Name)
+	}
+	return names
+}
+
+// covers returns true if the workloads of the ClusterQueue can use the
+// quota of the Reservation.
+func (r *ReservationSnapshot) covers(cq *ClusterQueueSnapshot) bool {
+	if r.ClusterQueue != "" {
+		return r.ClusterQueue == cq.Name
+	}
+	for cohort := cq.Parent(); cohort != nil; cohort = cohort.Parent() {
+		if cohort.Name == r.Cohort {
+			return true
+		}
+	}
+	return false
+}
+
+// ReservationFor returns the Reservation referenced by the workload. If the
+// workload can't be admitted using the Reservation, it returns a message
+// explaining why. It returns nil if the workload doesn't reference any
+// Reservation.
+func (s *Snapshot) ReservationFor(wl *workload.Info) (*ReservationSnapshot, string) {
+	if !features.Enabled(features.AdvanceReservations) {
+		return nil, ""
+	}
+	name, found := wl.Obj.Labels[kueuealpha.ReservationLabel]
+	if !found {
+		return nil, ""
+	}
+	r, found := s.Reservations[name]
+	if !found || !r.Started {
+		return nil, fmt.Sprintf("Reservation %q is not in its window", name)
+	}
+	if cq, found := s.ClusterQueues[wl.ClusterQueue]; !found || !r.covers(cq) {
+		return nil, fmt.Sprintf("Reservation %q doesn't reserve quota for ClusterQueue %q", name, wl.ClusterQueue)
+	}
+	return r, ""
+}
+
+// ReleaseReservation makes the quota held by the Reservation available to
+// the workload referencing it. The returned function holds the quota again,
+// minus the usage of the workload, if it was admitted.
+func (s *Snapshot) ReleaseReservation(r *ReservationSnapshot) func(used resources.FlavorResourceQuantities) {
+	for fr, held := range r.Held {
+		removeUsage(r.node, fr, held)
+	}
+	return func(used resources.FlavorResourceQuantities) {
+		for fr, held := range r.Held {
+			held = max(0, held-used[fr])
+			r.Held[fr] = held
+			addUsage(r.node, fr, held)
+		}
+	}
+}
+
+// ReservationDrainTargets returns the workloads which need to be evicted so
+// that the quota of the started Reservation isn't used by workloads which
+// don't reference it. The targets are removed from the snapshot.
+func (s *Snapshot) ReservationDrainTargets(name string) []*workload.Info {
+	r, found := s.Reservations[name]
+	if !found || !r.Started {
+		return nil
+	}
+	cqs := s.reservationClusterQueues(r)
+	if len(cqs) > 0 && cqs[0].HasParent() {
+		// Workloads borrowing from the reserved quota might be in any
+		// ClusterQueue of the cohort tree.
+		cqs = cqs[0].Parent().Root().SubtreeClusterQueues()
+	}
+	var candidates []*workload.Info
+	for _, cq := range cqs {
+		for _, wl := range cq.Workloads {
+			if wl.Obj.Labels[kueuealpha.ReservationLabel] != r.Name {
+				candidates = append(candidates, wl)
+			}
+		}
+	}
+	return s.reclaimTargets(r.node, utilmaps.Keys(r.Quota), candidates)
+}
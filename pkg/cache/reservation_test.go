@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	testingclock "k8s.io/utils/clock/testing"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestSnapshotReservations(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+	reservation := utiltesting.MakeReservation("maintenance", "cq", now.Add(time.Hour), now.Add(2*time.Hour)).
+		Flavor("default", corev1.ResourceCPU, "4").
+		LeadTime(10 * time.Minute).
+		Obj()
+	low := utiltesting.MakeWorkload("low", "ns").
+		ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "3").Obj(), now).
+		Obj()
+	high := utiltesting.MakeWorkload("high", "ns").
+		Priority(100).
+		ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj(), now).
+		Obj()
+	newer := utiltesting.MakeWorkload("newer", "ns").
+		ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj(), now.Add(time.Minute)).
+		Obj()
+	referencing := utiltesting.MakeWorkload("referencing", "ns").
+		Label(kueuealpha.ReservationLabel, "maintenance").
+		ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").Obj(), now).
+		Obj()
+
+	cases := map[string]struct {
+		now              time.Time
+		workloads        []*kueue.Workload
+		wantReservations map[string]*ReservationSnapshot
+		wantUsage        int64
+		wantDrainTargets []string
+	}{
+		"before the lead time": {
+			now:       now,
+			workloads: []*kueue.Workload{low},
+			wantUsage: 3_000,
+		},
+		"within the lead time": {
+			now:       now.Add(55 * time.Minute),
+			workloads: []*kueue.Workload{low, referencing},
+			wantReservations: map[string]*ReservationSnapshot{
+				"maintenance": {
+					Name:         "maintenance",
+					ClusterQueue: "cq",
+					Quota:        resources.FlavorResourceQuantities{cpu: 4_000},
+					Start:        now.Add(time.Hour),
+					End:          now.Add(2 * time.Hour),
+					Held:         resources.FlavorResourceQuantities{cpu: 4_000},
+				},
+			},
+			wantUsage: 8_000,
+		},
+		"started, the usage of the referencing workloads isn't held": {
+			now:       now.Add(90 * time.Minute),
+			workloads: []*kueue.Workload{low, referencing},
+			wantReservations: map[string]*ReservationSnapshot{
+				"maintenance": {
+					Name:         "maintenance",
+					ClusterQueue: "cq",
+					Quota:        resources.FlavorResourceQuantities{cpu: 4_000},
+					Start:        now.Add(time.Hour),
+					End:          now.Add(2 * time.Hour),
+					Started:      true,
+					Held:         resources.FlavorResourceQuantities{cpu: 3_000},
+				},
+			},
+			wantUsage: 7_000,
+		},
+		"started, the lowest priority and newest workloads are drained": {
+			now:       now.Add(90 * time.Minute),
+			workloads: []*kueue.Workload{low, high, newer, referencing},
+			wantReservations: map[string]*ReservationSnapshot{
+				"maintenance": {
+					Name:         "maintenance",
+					ClusterQueue: "cq",
+					Quota:        resources.FlavorResourceQuantities{cpu: 4_000},
+					Start:        now.Add(time.Hour),
+					End:          now.Add(2 * time.Hour),
+					Started:      true,
+					Held:         resources.FlavorResourceQuantities{cpu: 3_000},
+				},
+			},
+			wantUsage:        11_000,
+			wantDrainTargets: []string{"ns/newer"},
+		},
+		"expired": {
+			now:       now.Add(3 * time.Hour),
+			workloads: []*kueue.Workload{low, high, newer},
+			wantUsage: 7_000,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.AdvanceReservations, true)
+			ctx := context.Background()
+			cache := New(utiltesting.NewFakeClient(), WithClock(t, testingclock.NewFakeClock(tc.now)))
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Adding ClusterQueue: %v", err)
+			}
+			for _, wl := range tc.workloads {
+				cache.AddOrUpdateWorkload(wl)
+			}
+			cache.AddOrUpdateReservation(reservation)
+
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Building snapshot: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantReservations, snapshot.Reservations, cmpopts.EquateEmpty(),
+				cmpopts.IgnoreUnexported(ReservationSnapshot{})); diff != "" {
+				t.Errorf("Unexpected reservations (-want,+got):\n%s", diff)
+			}
+			if got := snapshot.ClusterQueues["cq"].ResourceNode.Usage[cpu]; got != tc.wantUsage {
+				t.Errorf("Unexpected usage, want=%d, got=%d", tc.wantUsage, got)
+			}
+			var gotTargets []string
+			for _, wl := range snapshot.ReservationDrainTargets("maintenance") {
+				gotTargets = append(gotTargets, workload.Key(wl.Obj))
+			}
+			if diff := cmp.Diff(tc.wantDrainTargets, gotTargets); diff != "" {
+				t.Errorf("Unexpected drain targets (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSnapshotReservationFor(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cases := map[string]struct {
+		label        string
+		clusterQueue string
+		wantFound    bool
+		wantMessage  string
+	}{
+		"no reservation referenced": {
+			clusterQueue: "cq-a",
+		},
+		"reservation of the cohort": {
+			label:        "started",
+			clusterQueue: "cq-a",
+			wantFound:    true,
+		},
+		"reservation not in its window": {
+			label:        "pending",
+			clusterQueue: "cq-a",
+			wantMessage:  `Reservation "pending" is not in its window`,
+		},
+		"reservation not found": {
+			label:        "missing",
+			clusterQueue: "cq-a",
+			wantMessage:  `Reservation "missing" is not in its window`,
+		},
+		"reservation of another ClusterQueue": {
+			label:        "other",
+			clusterQueue: "cq-a",
+			wantMessage:  `Reservation "other" doesn't reserve quota for ClusterQueue "cq-a"`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.AdvanceReservations, true)
+			ctx := context.Background()
+			cache := New(utiltesting.NewFakeClient(), WithClock(t, testingclock.NewFakeClock(now)))
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			for _, name := range []string{"cq-a", "cq-b"} {
+				if err := cache.AddClusterQueue(ctx, utiltesting.MakeClusterQueue(name).Cohort("cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj()); err != nil {
+					t.Fatalf("Adding ClusterQueue: %v", err)
+				}
+			}
+			cache.AddOrUpdateReservation(utiltesting.MakeReservation("started", "", now.Add(-time.Hour), now.Add(time.Hour)).
+				Cohort("cohort").Flavor("default", corev1.ResourceCPU, "1").Obj())
+			cache.AddOrUpdateReservation(utiltesting.MakeReservation("pending", "cq-a", now.Add(time.Minute), now.Add(time.Hour)).
+				LeadTime(time.Hour).Flavor("default", corev1.ResourceCPU, "1").Obj())
+			cache.AddOrUpdateReservation(utiltesting.MakeReservation("other", "cq-b", now.Add(-time.Hour), now.Add(time.Hour)).
+				Flavor("default", corev1.ResourceCPU, "1").Obj())
+
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Building snapshot: %v", err)
+			}
+			wl := utiltesting.MakeWorkload("wl", "ns")
+			if tc.label != "" {
+				wl.Label(kueuealpha.ReservationLabel, tc.label)
+			}
+			info := workload.NewInfo(wl.Obj())
+			info.ClusterQueue = tc.clusterQueue
+			got, msg := snapshot.ReservationFor(info)
+			if (got != nil) != tc.wantFound {
+				t.Errorf("Unexpected reservation found=%t, want=%t", got != nil, tc.wantFound)
+			}
+			if msg != tc.wantMessage {
+				t.Errorf("Unexpected message %q, want %q", msg, tc.wantMessage)
+			}
+		})
+	}
+}
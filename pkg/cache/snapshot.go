@@ -20,15 +20,20 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"sort"
+	"time"
 
 	"github.com/go-logr/logr"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
+	"sigs.k8s.io/kueue/pkg/resources"
 	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
+	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -36,22 +41,92 @@ type Snapshot struct {
 	hierarchy.Manager[*ClusterQueueSnapshot, *CohortSnapshot]
 	ResourceFlavors          map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor
 	InactiveClusterQueueSets sets.Set[string]
+	// Reservations holds the Reservations holding quota at the time of
+	// the snapshot, by name.
+	Reservations map[string]*ReservationSnapshot
 }
 
 // RemoveWorkload removes a workload from its corresponding ClusterQueue and
 // updates resource usage.
 func (s *Snapshot) RemoveWorkload(wl *workload.Info) {
 	cq := s.ClusterQueues[wl.ClusterQueue]
+	cq.ownWorkloads()
 	delete(cq.Workloads, workload.Key(wl.Obj))
-	cq.removeUsage(wl.FlavorResourceUsage())
+	cq.RemoveUsage(wl.FlavorResourceUsage())
+	if features.Enabled(features.TopologyAwareScheduling) && wl.IsUsingTAS() {
+		cq.removeTASUsage(wl.TASUsage())
+	}
 }
 
 // AddWorkload adds a workload from its corresponding ClusterQueue and
 // updates resource usage.
 func (s *Snapshot) AddWorkload(wl *workload.Info) {
 	cq := s.ClusterQueues[wl.ClusterQueue]
+	cq.ownWorkloads()
 	cq.Workloads[workload.Key(wl.Obj)] = wl
 	cq.AddUsage(wl.FlavorResourceUsage())
+	if features.Enabled(features.TopologyAwareScheduling) && wl.IsUsingTAS() {
+		cq.AddTASUsage(wl.TASUsage())
+	}
+}
+
+// reclaimTargets returns the candidates which need to be evicted so that
+// the usage of the node fits its quota for the given flavor-resources. The
+// candidates with the lowest priority, and then the most recently admitted,
+// are evicted first; the candidates whose eviction doesn't reduce the
+// overusage are skipped. The targets are removed from the snapshot.
+func (s *Snapshot) reclaimTargets(node hierarchicalResourceNode, frs []resources.FlavorResource, candidates []*workload.Info) []*workload.Info {
+	overused := func() []resources.FlavorResource {
+		var over []resources.FlavorResource
+		for _, fr := range frs {
+			if available(node, fr) < 0 {
+				over = append(over, fr)
+			}
+		}
+		return over
+	}
+	if len(overused()) == 0 {
+		return nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		pi, pj := priority.Effective(candidates[i].Obj), priority.Effective(candidates[j].Obj)
+		if pi != pj {
+			return pi < pj
+		}
+		return quotaReservationTime(candidates[i].Obj).After(quotaReservationTime(candidates[j].Obj))
+	})
+	var targets []*workload.Info
+	for _, wl := range candidates {
+		over := overused()
+		if len(over) == 0 {
+			break
+		}
+		before := make([]int64, len(over))
+		for i, fr := range over {
+			before[i] = available(node, fr)
+		}
+		s.RemoveWorkload(wl)
+		reduced := false
+		for i, fr := range over {
+			if available(node, fr) > before[i] {
+				reduced = true
+				break
+			}
+		}
+		if !reduced {
+			s.AddWorkload(wl)
+			continue
+		}
+		targets = append(targets, wl)
+	}
+	return targets
+}
+
+func quotaReservationTime(wl *kueue.Workload) time.Time {
+	if c := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved); c != nil {
+		return c.LastTransitionTime.Time
+	}
+	return wl.CreationTimestamp.Time
 }
 
 func (s *Snapshot) Log(log logr.Logger) {
@@ -86,6 +161,7 @@ func (c *Cache) Snapshot(ctx context.Context) (*Snapshot, error) {
 		Manager:                  hierarchy.NewManager(newCohortSnapshot),
 		ResourceFlavors:          make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, len(c.resourceFlavors)),
 		InactiveClusterQueueSets: sets.New[string](),
+		Reservations:             make(map[string]*ReservationSnapshot),
 	}
 	for _, cohort := range c.hm.Cohorts {
 		if c.hm.CycleChecker.HasCycle(cohort) {
@@ -93,6 +169,7 @@ func (c *Cache) Snapshot(ctx context.Context) (*Snapshot, error) {
 		}
 		snap.AddCohort(cohort.Name)
 		snap.Cohorts[cohort.Name].ResourceNode = cohort.resourceNode.Clone()
+		snap.Cohorts[cohort.Name].FairWeight = cohort.FairWeight
 		if cohort.HasParent() {
 			snap.UpdateCohortEdge(cohort.Name, cohort.Parent().Name)
 		}
@@ -113,7 +190,7 @@ func (c *Cache) Snapshot(ctx context.Context) (*Snapshot, error) {
 			snap.InactiveClusterQueueSets.Insert(cq.Name)
 			continue
 		}
-		cqSnapshot := snapshotClusterQueue(cq)
+		cqSnapshot := c.clusterQueueSnapshot(cq)
 		snap.AddClusterQueue(cqSnapshot)
 		if cq.HasParent() {
 			snap.UpdateClusterQueueEdge(cq.Name, cq.Parent().Name)
@@ -130,6 +207,12 @@ func (c *Cache) Snapshot(ctx context.Context) (*Snapshot, error) {
 		// Shallow copy is enough
 		snap.ResourceFlavors[name] = rf
 	}
+	if features.Enabled(features.AdvanceReservations) {
+		now := c.clock.Now()
+		for _, r := range c.reservations {
+			snap.addReservation(r, now)
+		}
+	}
 	return &snap, nil
 }
 
@@ -140,6 +223,7 @@ func snapshotClusterQueue(c *clusterQueue) *ClusterQueueSnapshot {
 		Name:                          c.Name,
 		ResourceGroups:                make([]ResourceGroup, len(c.ResourceGroups)),
 		FlavorFungibility:             c.FlavorFungibility,
+		FlavorSelectionPolicy:         c.FlavorSelectionPolicy,
 		FairWeight:                    c.FairWeight,
 		AllocatableResourceGeneration: c.AllocatableResourceGeneration,
 		Workloads:                     maps.Clone(c.Workloads),
@@ -149,16 +233,25 @@ func snapshotClusterQueue(c *clusterQueue) *ClusterQueueSnapshot {
 		AdmissionChecks:               utilmaps.DeepCopySets[kueue.ResourceFlavorReference](c.AdmissionChecks),
 		ResourceNode:                  c.resourceNode.Clone(),
 		TASFlavors:                    make(map[kueue.ResourceFlavorReference]*TASFlavorSnapshot),
+		ConsumedResources:             c.consumedResources.snapshot(),
+		BackfillMaxCandidates:         c.backfillMaxCandidates,
 	}
 	for i, rg := range c.ResourceGroups {
 		cc.ResourceGroups[i] = rg.Clone()
 	}
+	if len(c.provisioningAdmissionChecks) > 0 {
+		cc.ProvisioningAdmissionChecks = sets.New(c.provisioningAdmissionChecks...)
+	}
+	if len(c.multiKueueAdmissionChecks) > 0 {
+		cc.MultiKueueAdmissionChecks = sets.New(c.multiKueueAdmissionChecks...)
+	}
 	return cc
 }
 
 func newCohortSnapshot(name string) *CohortSnapshot {
 	return &CohortSnapshot{
-		Name:   name,
-		Cohort: hierarchy.NewCohort[*ClusterQueueSnapshot, *CohortSnapshot](),
+		Name:       name,
+		Cohort:     hierarchy.NewCohort[*ClusterQueueSnapshot, *CohortSnapshot](),
+		FairWeight: oneQuantity,
 	}
 }
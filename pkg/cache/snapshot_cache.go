@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"maps"
+	"sync"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+)
+
+// clusterQueueSnapshots holds the snapshots of the ClusterQueues taken by
+// the previous scheduling cycles. A snapshot is reused as long as neither
+// the Cache nor the ClusterQueue changed since it was taken.
+//
+// The cached snapshots are templates: they are never handed out, nor linked
+// to a Cohort. Each Snapshot gets a copy which shares the data the scheduler
+// doesn't modify, and which clones the workloads on the first write.
+type clusterQueueSnapshots struct {
+	sync.Mutex
+	// generation is the generation of the Cache when the entries were taken.
+	generation int64
+	entries    map[string]cachedClusterQueueSnapshot
+}
+
+type cachedClusterQueueSnapshot struct {
+	// generation is the generation of the ClusterQueue when the snapshot
+	// was taken.
+	generation int64
+	snapshot   *ClusterQueueSnapshot
+}
+
+// clusterQueueSnapshot returns the snapshot of the ClusterQueue, reusing the
+// snapshot of the previous cycle when the IncrementalCacheSnapshots feature
+// gate is enabled. The caller needs to hold the read lock of the Cache.
+func (c *Cache) clusterQueueSnapshot(cq *clusterQueue) *ClusterQueueSnapshot {
+	if !features.Enabled(features.IncrementalCacheSnapshots) {
+		return snapshotClusterQueue(cq)
+	}
+	c.snapshots.Lock()
+	defer c.snapshots.Unlock()
+	if c.snapshots.generation != c.generation {
+		clear(c.snapshots.entries)
+		c.snapshots.generation = c.generation
+	}
+	cached, found := c.snapshots.entries[cq.Name]
+	if !found || cached.generation != cq.generation {
+		cached = cachedClusterQueueSnapshot{
+			generation: cq.generation,
+			snapshot:   snapshotClusterQueue(cq),
+		}
+		c.snapshots.entries[cq.Name] = cached
+	}
+	return cached.snapshot.copyOnWrite()
+}
+
+// copyOnWrite returns a copy of the snapshot which can be modified by the
+// scheduler without affecting the original. The usage is cloned, while the
+// workloads are cloned by ownWorkloads before they are modified.
+func (c *ClusterQueueSnapshot) copyOnWrite() *ClusterQueueSnapshot {
+	cc := *c
+	cc.ResourceNode = c.ResourceNode.Clone()
+	cc.TASFlavors = make(map[kueue.ResourceFlavorReference]*TASFlavorSnapshot, len(c.TASFlavors))
+	cc.sharedWorkloads = true
+	return &cc
+}
+
+// ownWorkloads clones the workloads of the snapshot if they are shared with
+// the snapshot it was copied from.
+func (c *ClusterQueueSnapshot) ownWorkloads() {
+	if c.sharedWorkloads {
+		c.Workloads = maps.Clone(c.Workloads)
+		c.sharedWorkloads = false
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestIncrementalSnapshot(t *testing.T) {
+	admitted := func(name, cq string) *kueue.Workload {
+		return utiltesting.MakeWorkload(name, "").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuota(utiltesting.MakeAdmission(cq).Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+			Obj()
+	}
+	clusterQueue := func(name, cohort, quota string) *kueue.ClusterQueue {
+		return utiltesting.MakeClusterQueue(name).
+			Cohort(cohort).
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, quota).Obj()).
+			Obj()
+	}
+	cases := map[string]struct {
+		// change is applied between the snapshot of the previous scheduling
+		// cycle, which it can modify, and the snapshot under test.
+		change func(t *testing.T, ctx context.Context, c *Cache, prev *Snapshot)
+	}{
+		"no change": {
+			change: func(*testing.T, context.Context, *Cache, *Snapshot) {},
+		},
+		"workload added": {
+			change: func(t *testing.T, _ context.Context, c *Cache, _ *Snapshot) {
+				if !c.AddOrUpdateWorkload(admitted("a-2", "a")) {
+					t.Fatal("Failed adding workload")
+				}
+			},
+		},
+		"workload deleted": {
+			change: func(t *testing.T, _ context.Context, c *Cache, _ *Snapshot) {
+				if err := c.DeleteWorkload(admitted("b-1", "b")); err != nil {
+					t.Fatalf("Failed deleting workload: %v", err)
+				}
+			},
+		},
+		"ClusterQueue updated": {
+			change: func(t *testing.T, _ context.Context, c *Cache, _ *Snapshot) {
+				if err := c.UpdateClusterQueue(clusterQueue("a", "cohort", "4")); err != nil {
+					t.Fatalf("Failed updating ClusterQueue: %v", err)
+				}
+			},
+		},
+		"ClusterQueue deleted": {
+			change: func(_ *testing.T, _ context.Context, c *Cache, _ *Snapshot) {
+				c.DeleteClusterQueue(clusterQueue("c", "", "10"))
+			},
+		},
+		"Cohort updated": {
+			change: func(t *testing.T, _ context.Context, c *Cache, _ *Snapshot) {
+				cohort := utiltesting.MakeCohort("cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+					Obj()
+				if err := c.AddOrUpdateCohort(cohort); err != nil {
+					t.Fatalf("Failed updating Cohort: %v", err)
+				}
+			},
+		},
+		"ResourceFlavor deleted": {
+			change: func(_ *testing.T, _ context.Context, c *Cache, _ *Snapshot) {
+				c.DeleteResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			},
+		},
+		"previous snapshot modified": {
+			change: func(_ *testing.T, _ context.Context, _ *Cache, prev *Snapshot) {
+				prev.RemoveWorkload(prev.ClusterQueues["a"].Workloads["/a-1"])
+				prev.AddWorkload(workload.NewInfo(admitted("b-2", "b")))
+				prev.ClusterQueues["c"].AddUsage(workload.NewInfo(admitted("c-2", "c")).FlavorResourceUsage())
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			features.SetFeatureGateDuringTest(t, features.IncrementalCacheSnapshots, true)
+			cache := New(utiltesting.NewFakeClient())
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			for _, cq := range []*kueue.ClusterQueue{
+				clusterQueue("a", "cohort", "2"),
+				clusterQueue("b", "cohort", "2"),
+				clusterQueue("c", "", "10"),
+			} {
+				if err := cache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Failed adding ClusterQueue: %v", err)
+				}
+			}
+			for _, wl := range []*kueue.Workload{admitted("a-1", "a"), admitted("b-1", "b"), admitted("c-1", "c")} {
+				cache.AddOrUpdateWorkload(wl)
+			}
+
+			prev, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			tc.change(t, ctx, cache, prev)
+			got, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+
+			features.SetFeatureGateDuringTest(t, features.IncrementalCacheSnapshots, false)
+			want, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			if diff := cmp.Diff(want, got, snapCmpOpts...); diff != "" {
+				t.Errorf("Unexpected incremental snapshot (-want,+got):\n%s", diff)
+			}
+			for name, cq := range got.ClusterQueues {
+				if cohort := want.ClusterQueues[name].Parent(); cohort != nil && (cq.Parent() == nil || cq.Parent().Name != cohort.Name) {
+					t.Errorf("Unexpected Cohort of ClusterQueue %q in the incremental snapshot", name)
+				}
+			}
+		})
+	}
+}
@@ -44,6 +44,7 @@ var snapCmpOpts = []cmp.Option{
 	cmpopts.IgnoreUnexported(hierarchy.ClusterQueue[*CohortSnapshot]{}),
 	cmpopts.IgnoreUnexported(hierarchy.Manager[*ClusterQueueSnapshot, *CohortSnapshot]{}),
 	cmpopts.IgnoreUnexported(hierarchy.CycleChecker{}),
+	cmpopts.IgnoreUnexported(ClusterQueueSnapshot{}),
 	cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
 }
 
@@ -211,7 +212,8 @@ func TestSnapshot(t *testing.T) {
 			},
 			wantSnapshot: func() Snapshot {
 				cohort := &CohortSnapshot{
-					Name: "borrowing",
+					Name:       "borrowing",
+					FairWeight: oneQuantity,
 					ResourceNode: ResourceNode{
 						Usage: resources.FlavorResourceQuantities{
 							{Flavor: "demand", Resource: corev1.ResourceCPU}: 10_000,
@@ -459,7 +461,8 @@ func TestSnapshot(t *testing.T) {
 			},
 			wantSnapshot: func() Snapshot {
 				cohort := &CohortSnapshot{
-					Name: "lending",
+					Name:       "lending",
+					FairWeight: oneQuantity,
 					ResourceNode: ResourceNode{
 						Usage: resources.FlavorResourceQuantities{
 							{Flavor: "arm", Resource: corev1.ResourceCPU}: 10_000,
@@ -613,7 +616,8 @@ func TestSnapshot(t *testing.T) {
 			},
 			wantSnapshot: func() Snapshot {
 				cohort := &CohortSnapshot{
-					Name: "lending",
+					Name:       "lending",
+					FairWeight: oneQuantity,
 					ResourceNode: ResourceNode{
 						SubtreeQuota: resources.FlavorResourceQuantities{
 							{Flavor: "arm", Resource: corev1.ResourceCPU}: 20_000,
@@ -775,7 +779,8 @@ func TestSnapshot(t *testing.T) {
 					},
 					Cohorts: map[string]*CohortSnapshot{
 						"cohort": {
-							Name: "cohort",
+							Name:       "cohort",
+							FairWeight: oneQuantity,
 							ResourceNode: ResourceNode{
 								Quotas: map[resources.FlavorResource]ResourceQuota{
 									{Flavor: "arm", Resource: corev1.ResourceCPU}:  {Nominal: 10_000, BorrowingLimit: nil, LendingLimit: nil},
@@ -859,7 +864,8 @@ func TestSnapshot(t *testing.T) {
 					},
 					Cohorts: map[string]*CohortSnapshot{
 						"nocycle": {
-							Name: "nocycle",
+							Name:       "nocycle",
+							FairWeight: oneQuantity,
 							ResourceNode: ResourceNode{
 								SubtreeQuota: resources.FlavorResourceQuantities{
 									{Flavor: "arm", Resource: corev1.ResourceCPU}: 0,
@@ -1004,7 +1010,8 @@ func TestSnapshotAddRemoveWorkload(t *testing.T) {
 			remove: []string{"/c1-cpu", "/c1-memory-alpha", "/c1-memory-beta", "/c2-cpu-1", "/c2-cpu-2"},
 			want: func() Snapshot {
 				cohort := &CohortSnapshot{
-					Name: "cohort",
+					Name:       "cohort",
+					FairWeight: oneQuantity,
 					ResourceNode: ResourceNode{
 						Usage: resources.FlavorResourceQuantities{
 							{Flavor: "default", Resource: corev1.ResourceCPU}:  0,
@@ -1064,7 +1071,8 @@ func TestSnapshotAddRemoveWorkload(t *testing.T) {
 			remove: []string{"/c1-cpu"},
 			want: func() Snapshot {
 				cohort := &CohortSnapshot{
-					Name: "cohort",
+					Name:       "cohort",
+					FairWeight: oneQuantity,
 					ResourceNode: ResourceNode{
 						Usage: resources.FlavorResourceQuantities{
 							{Flavor: "default", Resource: corev1.ResourceCPU}:  2_000,
@@ -1130,7 +1138,8 @@ func TestSnapshotAddRemoveWorkload(t *testing.T) {
 			remove: []string{"/c1-memory-alpha"},
 			want: func() Snapshot {
 				cohort := &CohortSnapshot{
-					Name: "cohort",
+					Name:       "cohort",
+					FairWeight: oneQuantity,
 					ResourceNode: ResourceNode{
 						Usage: resources.FlavorResourceQuantities{
 							{Flavor: "default", Resource: corev1.ResourceCPU}:  3_000,
@@ -1298,7 +1307,8 @@ func TestSnapshotAddRemoveWorkloadWithLendingLimit(t *testing.T) {
 			remove: []string{"/lend-a-1", "/lend-a-2", "/lend-a-3", "/lend-b-1"},
 			want: func() Snapshot {
 				cohort := &CohortSnapshot{
-					Name: "lend",
+					Name:       "lend",
+					FairWeight: oneQuantity,
 					ResourceNode: ResourceNode{
 						Usage: resources.FlavorResourceQuantities{
 							{Flavor: "default", Resource: corev1.ResourceCPU}: 0,
@@ -1351,7 +1361,8 @@ func TestSnapshotAddRemoveWorkloadWithLendingLimit(t *testing.T) {
 			remove: []string{"/lend-a-2"},
 			want: func() Snapshot {
 				cohort := &CohortSnapshot{
-					Name: "lend",
+					Name:       "lend",
+					FairWeight: oneQuantity,
 					ResourceNode: ResourceNode{
 						Usage: resources.FlavorResourceQuantities{
 							{Flavor: "default", Resource: corev1.ResourceCPU}: 1_000,
@@ -1405,7 +1416,8 @@ func TestSnapshotAddRemoveWorkloadWithLendingLimit(t *testing.T) {
 			remove: []string{"/lend-a-1", "/lend-a-2"},
 			want: func() Snapshot {
 				cohort := &CohortSnapshot{
-					Name: "lend",
+					Name:       "lend",
+					FairWeight: oneQuantity,
 					ResourceNode: ResourceNode{
 						Usage: resources.FlavorResourceQuantities{
 							{Flavor: "default", Resource: corev1.ResourceCPU}: 0,
@@ -1459,7 +1471,8 @@ func TestSnapshotAddRemoveWorkloadWithLendingLimit(t *testing.T) {
 			remove: []string{"/lend-a-2", "/lend-a-3"},
 			want: func() Snapshot {
 				cohort := &CohortSnapshot{
-					Name: "lend",
+					Name:       "lend",
+					FairWeight: oneQuantity,
 					ResourceNode: ResourceNode{
 						Usage: resources.FlavorResourceQuantities{
 							{Flavor: "default", Resource: corev1.ResourceCPU}: 0,
@@ -1514,7 +1527,8 @@ func TestSnapshotAddRemoveWorkloadWithLendingLimit(t *testing.T) {
 			add:    []string{"/lend-a-1"},
 			want: func() Snapshot {
 				cohort := &CohortSnapshot{
-					Name: "lend",
+					Name:       "lend",
+					FairWeight: oneQuantity,
 					ResourceNode: ResourceNode{
 						Usage: resources.FlavorResourceQuantities{
 							{Flavor: "default", Resource: corev1.ResourceCPU}: 0,
@@ -1569,7 +1583,8 @@ func TestSnapshotAddRemoveWorkloadWithLendingLimit(t *testing.T) {
 			add:    []string{"/lend-a-3"},
 			want: func() Snapshot {
 				cohort := &CohortSnapshot{
-					Name: "lend",
+					Name:       "lend",
+					FairWeight: oneQuantity,
 					ResourceNode: ResourceNode{
 						Usage: resources.FlavorResourceQuantities{
 							{Flavor: "default", Resource: corev1.ResourceCPU}: 0,
@@ -1623,7 +1638,8 @@ func TestSnapshotAddRemoveWorkloadWithLendingLimit(t *testing.T) {
 			add:    []string{"/lend-a-2"},
 			want: func() Snapshot {
 				cohort := &CohortSnapshot{
-					Name: "lend",
+					Name:       "lend",
+					FairWeight: oneQuantity,
 					ResourceNode: ResourceNode{
 						Usage: resources.FlavorResourceQuantities{
 							{Flavor: "default", Resource: corev1.ResourceCPU}: 3_000,
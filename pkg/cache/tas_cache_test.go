@@ -18,9 +18,11 @@ package cache
 
 import (
 	"context"
+	"slices"
 	"sort"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -34,6 +36,7 @@ import (
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
 	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 func TestFindTopologyAssignment(t *testing.T) {
@@ -1072,3 +1075,59 @@ func TestFindTopologyAssignment(t *testing.T) {
 		})
 	}
 }
+
+func TestTASFlavorSnapshotUsage(t *testing.T) {
+	nodes := []corev1.Node{
+		*testingnode.MakeNode("x1").
+			Label(corev1.LabelHostname, "x1").
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("2"),
+			}).
+			Ready().
+			Obj(),
+		*testingnode.MakeNode("x2").
+			Label(corev1.LabelHostname, "x2").
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			}).
+			Ready().
+			Obj(),
+	}
+	onX1 := []workload.TopologyDomainRequests{{
+		Values:   []string{"x1"},
+		Requests: resources.Requests{corev1.ResourceCPU: 2_000},
+	}}
+	onX2 := []workload.TopologyDomainRequests{{
+		Values:   []string{"x2"},
+		Requests: resources.Requests{corev1.ResourceCPU: 1_000},
+	}}
+	onUnknownNode := []workload.TopologyDomainRequests{{
+		Values:   []string{"x3"},
+		Requests: resources.Requests{corev1.ResourceCPU: 1_000},
+	}}
+
+	tasCache := NewTASCache(nil)
+	tasFlavorCache := tasCache.NewTASFlavorCache("default", []string{corev1.LabelHostname}, nil, nil)
+	snapshot := tasFlavorCache.snapshotForNodes(logr.Discard(), nodes, nil)
+
+	if !snapshot.FitsTASUsage(onX1) || !snapshot.FitsTASUsage(onX2) {
+		t.Fatalf("expected the usage to fit in the empty snapshot")
+	}
+	if snapshot.FitsTASUsage(onUnknownNode) {
+		t.Errorf("expected the usage on an unknown node not to fit")
+	}
+	if snapshot.FitsTASUsage(append(slices.Clone(onX2), onX2...)) {
+		t.Errorf("expected the aggregated usage on x2 not to fit")
+	}
+	snapshot.AddTASUsage(onX1)
+	if snapshot.FitsTASUsage(onX1) {
+		t.Errorf("expected the usage on x1 not to fit after it was accounted")
+	}
+	if !snapshot.FitsTASUsage(onX2) {
+		t.Errorf("expected the usage on x2 to fit after accounting the usage on x1")
+	}
+	snapshot.RemoveTASUsage(onX1)
+	if !snapshot.FitsTASUsage(onX1) {
+		t.Errorf("expected the usage on x1 to fit after it was removed")
+	}
+}
@@ -30,6 +30,7 @@ import (
 	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
 	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
 	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 var (
@@ -212,6 +213,56 @@ func (s *TASFlavorSnapshot) addUsage(domainID utiltas.TopologyDomainID, usage re
 	s.leaves[domainID].freeCapacity.Sub(usage)
 }
 
+func (s *TASFlavorSnapshot) removeUsage(domainID utiltas.TopologyDomainID, usage resources.Requests) {
+	if s.leaves[domainID] == nil {
+		s.log.Info("skip removing usage in domain", "domain", domainID, "usage", usage)
+		return
+	}
+	s.leaves[domainID].freeCapacity.Add(usage)
+}
+
+// AddTASUsage accounts for the topology usage of a workload admitted during
+// the scheduling cycle. The snapshot is shared by all ClusterQueues which use
+// the flavor, including ClusterQueues in the same cohort, so the capacity
+// assigned to the workload is not available to the following workloads.
+func (s *TASFlavorSnapshot) AddTASUsage(topologyRequests []workload.TopologyDomainRequests) {
+	for _, tr := range topologyRequests {
+		s.addUsage(utiltas.DomainID(tr.Values), tr.Requests)
+	}
+}
+
+// RemoveTASUsage reverts the accounting done by AddTASUsage.
+func (s *TASFlavorSnapshot) RemoveTASUsage(topologyRequests []workload.TopologyDomainRequests) {
+	for _, tr := range topologyRequests {
+		s.removeUsage(utiltas.DomainID(tr.Values), tr.Requests)
+	}
+}
+
+// FitsTASUsage returns true if the free capacity of the topology domains is
+// enough to accommodate the topology usage.
+func (s *TASFlavorSnapshot) FitsTASUsage(topologyRequests []workload.TopologyDomainRequests) bool {
+	usagePerDomain := make(map[utiltas.TopologyDomainID]resources.Requests, len(topologyRequests))
+	for _, tr := range topologyRequests {
+		domainID := utiltas.DomainID(tr.Values)
+		if _, found := usagePerDomain[domainID]; !found {
+			usagePerDomain[domainID] = resources.Requests{}
+		}
+		usagePerDomain[domainID].Add(tr.Requests)
+	}
+	for domainID, usage := range usagePerDomain {
+		leaf := s.leaves[domainID]
+		if leaf == nil {
+			return false
+		}
+		for rName, rValue := range usage {
+			if rValue > leaf.freeCapacity[rName] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // Algorithm overview:
 // Phase 1:
 //
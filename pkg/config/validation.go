@@ -58,9 +58,11 @@ var (
 	requeuingStrategyPath             = waitForPodsReadyPath.Child("requeuingStrategy")
 	multiKueuePath                    = field.NewPath("multiKueue")
 	fsPreemptionStrategiesPath        = field.NewPath("fairSharing", "preemptionStrategies")
+	admissionFairSharingPath          = field.NewPath("admissionFairSharing")
 	internalCertManagementPath        = field.NewPath("internalCertManagement")
 	queueVisibilityPath               = field.NewPath("queueVisibility")
 	resourceTransformationPath        = field.NewPath("resources", "transformations")
+	schedulingPath                    = field.NewPath("scheduling")
 )
 
 func validate(c *configapi.Configuration, scheme *runtime.Scheme) field.ErrorList {
@@ -70,9 +72,11 @@ func validate(c *configapi.Configuration, scheme *runtime.Scheme) field.ErrorLis
 	allErrs = append(allErrs, validateIntegrations(c, scheme)...)
 	allErrs = append(allErrs, validateMultiKueue(c)...)
 	allErrs = append(allErrs, validateFairSharing(c)...)
+	allErrs = append(allErrs, validateAdmissionFairSharing(c)...)
 	allErrs = append(allErrs, validateInternalCertManagement(c)...)
 	allErrs = append(allErrs, validateResourceTransformations(c)...)
 	allErrs = append(allErrs, validateManagedJobsNamespaceSelector(c)...)
+	allErrs = append(allErrs, validateScheduling(c)...)
 	return allErrs
 }
 
@@ -285,6 +289,23 @@ func validateFairSharing(c *configapi.Configuration) field.ErrorList {
 	return allErrs
 }
 
+func validateAdmissionFairSharing(c *configapi.Configuration) field.ErrorList {
+	afs := c.AdmissionFairSharing
+	if afs == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if afs.UsageHalfLifeTime != nil && afs.UsageHalfLifeTime.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(admissionFairSharingPath.Child("usageHalfLifeTime"),
+			afs.UsageHalfLifeTime.Duration, "must be greater than 0"))
+	}
+	if afs.UsageSamplingInterval != nil && afs.UsageSamplingInterval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(admissionFairSharingPath.Child("usageSamplingInterval"),
+			afs.UsageSamplingInterval.Duration, "must be greater than 0"))
+	}
+	return allErrs
+}
+
 func validateResourceTransformations(c *configapi.Configuration) field.ErrorList {
 	res := c.Resources
 	if res == nil {
@@ -307,6 +328,87 @@ func validateResourceTransformations(c *configapi.Configuration) field.ErrorList
 	return allErrs
 }
 
+func validateScheduling(c *configapi.Configuration) field.ErrorList {
+	sched := c.Scheduling
+	if sched == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if sched.MaxAdmissionsPerCycle != nil && *sched.MaxAdmissionsPerCycle < 1 {
+		allErrs = append(allErrs, field.Invalid(schedulingPath.Child("maxAdmissionsPerCycle"),
+			*sched.MaxAdmissionsPerCycle, "must be greater than 0"))
+	}
+	if plugins := sched.Plugins; plugins != nil {
+		pluginsPath := schedulingPath.Child("plugins")
+		allErrs = append(allErrs, validateSchedulingPlugins(pluginsPath.Child("queueSort"), plugins.QueueSort, false)...)
+		allErrs = append(allErrs, validateSchedulingPlugins(pluginsPath.Child("flavorFilter"), plugins.FlavorFilter, false)...)
+		allErrs = append(allErrs, validateSchedulingPlugins(pluginsPath.Child("flavorScore"), plugins.FlavorScore, true)...)
+		allErrs = append(allErrs, validateSchedulingPlugins(pluginsPath.Child("preemptionOrder"), plugins.PreemptionOrder, false)...)
+		allErrs = append(allErrs, validateSchedulingPlugins(pluginsPath.Child("postAdmission"), plugins.PostAdmission, false)...)
+	}
+	seenNames := sets.New[string]()
+	for idx, pc := range sched.PluginConfig {
+		namePath := schedulingPath.Child("pluginConfig").Index(idx).Child("name")
+		if pc.Name == "" {
+			allErrs = append(allErrs, field.Required(namePath, ""))
+		} else if seenNames.Has(pc.Name) {
+			allErrs = append(allErrs, field.Duplicate(namePath, pc.Name))
+		}
+		seenNames.Insert(pc.Name)
+	}
+	allErrs = append(allErrs, validateSchedulingTrace(sched.Trace)...)
+	return allErrs
+}
+
+func validateSchedulingTrace(trace *configapi.SchedulingTrace) field.ErrorList {
+	if trace == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	tracePath := schedulingPath.Child("trace")
+	if p := trace.SamplingPercentage; p != nil && (*p < 1 || *p > 100) {
+		allErrs = append(allErrs, field.Invalid(tracePath.Child("samplingPercentage"), *p, "must be between 1 and 100"))
+	}
+	if size := trace.BufferSize; size != nil && *size < 1 {
+		allErrs = append(allErrs, field.Invalid(tracePath.Child("bufferSize"), *size, "must be greater than 0"))
+	}
+	if file := trace.File; file != nil {
+		filePath := tracePath.Child("file")
+		if file.Path == "" {
+			allErrs = append(allErrs, field.Required(filePath.Child("path"), ""))
+		}
+		if size := file.MaxSizeMegabytes; size != nil && *size < 1 {
+			allErrs = append(allErrs, field.Invalid(filePath.Child("maxSizeMegabytes"), *size, "must be greater than 0"))
+		}
+		if backups := file.MaxBackups; backups != nil && *backups < 0 {
+			allErrs = append(allErrs, field.Invalid(filePath.Child("maxBackups"), *backups, "must be greater than or equal to 0"))
+		}
+	}
+	return allErrs
+}
+
+func validateSchedulingPlugins(path *field.Path, plugins []configapi.Plugin, weighted bool) field.ErrorList {
+	var allErrs field.ErrorList
+	seenNames := sets.New[string]()
+	for idx, p := range plugins {
+		if p.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Index(idx).Child("name"), ""))
+		} else if seenNames.Has(p.Name) {
+			allErrs = append(allErrs, field.Duplicate(path.Index(idx).Child("name"), p.Name))
+		}
+		seenNames.Insert(p.Name)
+		if p.Weight == nil {
+			continue
+		}
+		if !weighted {
+			allErrs = append(allErrs, field.Forbidden(path.Index(idx).Child("weight"), "only supported for the flavorScore plugins"))
+		} else if *p.Weight < 1 {
+			allErrs = append(allErrs, field.Invalid(path.Index(idx).Child("weight"), *p.Weight, "must be greater than 0"))
+		}
+	}
+	return allErrs
+}
+
 func validateManagedJobsNamespaceSelector(c *configapi.Configuration) field.ErrorList {
 	var allErrs field.ErrorList
 
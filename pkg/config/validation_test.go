@@ -582,6 +582,147 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		"non-positive admission fair sharing durations": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				AdmissionFairSharing: &configapi.AdmissionFairSharing{
+					UsageHalfLifeTime:     &metav1.Duration{},
+					UsageSamplingInterval: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "admissionFairSharing.usageHalfLifeTime",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "admissionFairSharing.usageSamplingInterval",
+				},
+			},
+		},
+		"valid admission fair sharing": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				AdmissionFairSharing: &configapi.AdmissionFairSharing{
+					UsageHalfLifeTime:     &metav1.Duration{Duration: time.Hour},
+					UsageSamplingInterval: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+		},
+		"invalid scheduling plugins": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduling: &configapi.Scheduling{
+					Plugins: &configapi.SchedulingPlugins{
+						QueueSort:   []configapi.Plugin{{Name: "a", Weight: ptr.To[int32](2)}, {Name: "a"}},
+						FlavorScore: []configapi.Plugin{{Name: "b", Weight: ptr.To[int32](0)}, {}},
+					},
+					PluginConfig: []configapi.PluginConfig{{Name: "a"}, {Name: "a"}},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeForbidden,
+					Field: "scheduling.plugins.queueSort[0].weight",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "scheduling.plugins.queueSort[1].name",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "scheduling.plugins.flavorScore[0].weight",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "scheduling.plugins.flavorScore[1].name",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "scheduling.pluginConfig[1].name",
+				},
+			},
+		},
+		"non-positive maxAdmissionsPerCycle": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduling: &configapi.Scheduling{
+					MaxAdmissionsPerCycle: ptr.To[int32](0),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "scheduling.maxAdmissionsPerCycle",
+				},
+			},
+		},
+		"invalid scheduling trace": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduling: &configapi.Scheduling{
+					Trace: &configapi.SchedulingTrace{
+						SamplingPercentage: ptr.To[int32](101),
+						BufferSize:         ptr.To[int32](0),
+						File: &configapi.SchedulingTraceFile{
+							MaxSizeMegabytes: ptr.To[int32](0),
+							MaxBackups:       ptr.To[int32](-1),
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "scheduling.trace.samplingPercentage",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "scheduling.trace.bufferSize",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "scheduling.trace.file.path",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "scheduling.trace.file.maxSizeMegabytes",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "scheduling.trace.file.maxBackups",
+				},
+			},
+		},
+		"valid scheduling trace": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduling: &configapi.Scheduling{
+					Trace: &configapi.SchedulingTrace{
+						SamplingPercentage: ptr.To[int32](10),
+						BufferSize:         ptr.To[int32](50),
+						File: &configapi.SchedulingTraceFile{
+							Path:             "/var/log/kueue/scheduling-trace.jsonl",
+							MaxSizeMegabytes: ptr.To[int32](10),
+							MaxBackups:       ptr.To[int32](0),
+						},
+					},
+				},
+			},
+		},
+		"valid scheduling plugins": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduling: &configapi.Scheduling{
+					Plugins: &configapi.SchedulingPlugins{
+						QueueSort:   []configapi.Plugin{{Name: "a"}},
+						FlavorScore: []configapi.Plugin{{Name: "a", Weight: ptr.To[int32](2)}},
+					},
+					PluginConfig: []configapi.PluginConfig{{Name: "a"}},
+				},
+			},
+		},
 		"invalid .internalCertManagement.webhookSecretName": {
 			cfg: &configapi.Configuration{
 				Integrations: defaultIntegrations,
@@ -21,11 +21,13 @@ import (
 )
 
 const (
-	KueueName              = "kueue"
-	JobControllerName      = KueueName + "-job-controller"
-	WorkloadControllerName = KueueName + "-workload-controller"
-	AdmissionName          = KueueName + "-admission"
-	ReclaimablePodsMgr     = KueueName + "-reclaimable-pods"
+	KueueName                   = "kueue"
+	JobControllerName           = KueueName + "-job-controller"
+	WorkloadControllerName      = KueueName + "-workload-controller"
+	ReservationControllerName   = KueueName + "-reservation-controller"
+	QuotaScheduleControllerName = KueueName + "-quotaschedule-controller"
+	AdmissionName               = KueueName + "-admission"
+	ReclaimablePodsMgr          = KueueName + "-reclaimable-pods"
 
 	// UpdatesBatchPeriod is the batch period to hold workload updates
 	// before syncing a Queue and ClusterQueue objects.
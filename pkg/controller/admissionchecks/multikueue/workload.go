@@ -40,6 +40,7 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
 	"sigs.k8s.io/kueue/pkg/util/api"
 	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
@@ -97,6 +98,8 @@ func (g *wlGroup) IsFinished() bool {
 
 // FirstReserving returns true if there is a workload reserving quota,
 // the string identifies the remote cluster.
+// A remote workload requiring Topology Aware Scheduling is only considered
+// as reserving once the worker computed its topology assignment.
 func (g *wlGroup) FirstReserving() (bool, string) {
 	found := false
 	bestMatch := ""
@@ -105,6 +108,9 @@ func (g *wlGroup) FirstReserving() (bool, string) {
 		if wl == nil {
 			continue
 		}
+		if features.Enabled(features.TopologyAwareScheduling) && !workload.HasAllTopologyAssignments(wl) {
+			continue
+		}
 		c := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
 		if c != nil && c.Status == metav1.ConditionTrue && (!found || bestTime.IsZero() || c.LastTransitionTime.Time.Before(bestTime)) {
 			found = true
@@ -66,6 +66,14 @@ func TestWlReconcile(t *testing.T) {
 	baseWorkloadBuilder := utiltesting.MakeWorkload("wl1", TestNamespace)
 	baseJobBuilder := testingjob.MakeJob("job1", TestNamespace).Suspend(false)
 	baseJobManagedByKueueBuilder := baseJobBuilder.Clone().ManagedBy(kueue.MultiKueueControllerName)
+	baseTASWorkloadBuilder := baseWorkloadBuilder.Clone().
+		PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).
+			RequiredTopologyRequest(corev1.LabelHostname).
+			Obj())
+	topologyAssignment := &kueue.TopologyAssignment{
+		Levels:  []string{corev1.LabelHostname},
+		Domains: []kueue.TopologyDomainAssignment{{Values: []string{"node1"}, Count: 1}},
+	}
 
 	cases := map[string]struct {
 		reconcileFor             string
@@ -75,6 +83,7 @@ func TestWlReconcile(t *testing.T) {
 		worker1Workloads         []kueue.Workload
 		worker1Jobs              []batchv1.Job
 		withoutJobManagedBy      bool
+		enableTAS                bool
 
 		// second worker
 		useSecondWorker      bool
@@ -478,6 +487,98 @@ func TestWlReconcile(t *testing.T) {
 					Obj(),
 			},
 		},
+		"remote wl with reservation, but pending topology assignment, is not reserving": {
+			reconcileFor: "wl1",
+			enableTAS:    true,
+			managersWorkloads: []kueue.Workload{
+				*baseTASWorkloadBuilder.Clone().
+					AdmissionCheck(kueue.AdmissionCheckState{Name: "ac1", State: kueue.CheckStatePending}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "uid1").
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					Obj(),
+			},
+			managersJobs: []batchv1.Job{
+				*baseJobManagedByKueueBuilder.Clone().Obj(),
+			},
+			worker1Workloads: []kueue.Workload{
+				*baseTASWorkloadBuilder.Clone().
+					ReserveQuota(utiltesting.MakeAdmission("q1").
+						DelayedTopologyRequest(kueue.DelayedTopologyRequestStatePending).
+						Obj()).
+					Label(kueue.MultiKueueOriginLabel, defaultOrigin).
+					Obj(),
+			},
+
+			wantManagersWorkloads: []kueue.Workload{
+				*baseTASWorkloadBuilder.Clone().
+					AdmissionCheck(kueue.AdmissionCheckState{Name: "ac1", State: kueue.CheckStatePending}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "uid1").
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					Obj(),
+			},
+			wantManagersJobs: []batchv1.Job{
+				*baseJobManagedByKueueBuilder.Clone().Obj(),
+			},
+			wantWorker1Workloads: []kueue.Workload{
+				*baseTASWorkloadBuilder.Clone().
+					ReserveQuota(utiltesting.MakeAdmission("q1").
+						DelayedTopologyRequest(kueue.DelayedTopologyRequestStatePending).
+						Obj()).
+					Label(kueue.MultiKueueOriginLabel, defaultOrigin).
+					Obj(),
+			},
+		},
+		"remote wl with reservation and topology assignment": {
+			reconcileFor: "wl1",
+			enableTAS:    true,
+			managersWorkloads: []kueue.Workload{
+				*baseTASWorkloadBuilder.Clone().
+					AdmissionCheck(kueue.AdmissionCheckState{Name: "ac1", State: kueue.CheckStatePending}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "uid1").
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					Obj(),
+			},
+			managersJobs: []batchv1.Job{
+				*baseJobManagedByKueueBuilder.Clone().Obj(),
+			},
+			worker1Workloads: []kueue.Workload{
+				*baseTASWorkloadBuilder.Clone().
+					ReserveQuota(utiltesting.MakeAdmission("q1").
+						TopologyAssignment(topologyAssignment).
+						Obj()).
+					Label(kueue.MultiKueueOriginLabel, defaultOrigin).
+					Obj(),
+			},
+
+			wantManagersWorkloads: []kueue.Workload{
+				*baseTASWorkloadBuilder.Clone().
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:    "ac1",
+						State:   kueue.CheckStateReady,
+						Message: `The workload got reservation on "worker1"`,
+					}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "uid1").
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					Obj(),
+			},
+			wantManagersJobs: []batchv1.Job{
+				*baseJobManagedByKueueBuilder.Clone().Obj(),
+			},
+			wantWorker1Workloads: []kueue.Workload{
+				*baseTASWorkloadBuilder.Clone().
+					ReserveQuota(utiltesting.MakeAdmission("q1").
+						TopologyAssignment(topologyAssignment).
+						Obj()).
+					Label(kueue.MultiKueueOriginLabel, defaultOrigin).
+					Obj(),
+			},
+			wantWorker1Jobs: []batchv1.Job{
+				*baseJobBuilder.Clone().
+					Label(constants.PrebuiltWorkloadLabel, "wl1").
+					Label(kueue.MultiKueueOriginLabel, defaultOrigin).
+					Obj(),
+			},
+		},
 		"remote wl with reservation (withoutJobManagedBy)": {
 			reconcileFor:        "wl1",
 			withoutJobManagedBy: true,
@@ -1003,6 +1104,7 @@ func TestWlReconcile(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			features.SetFeatureGateDuringTest(t, features.MultiKueueBatchJobWithManagedBy, !tc.withoutJobManagedBy)
+			features.SetFeatureGateDuringTest(t, features.TopologyAwareScheduling, tc.enableTAS)
 			managerBuilder, ctx := getClientBuilder()
 			managerBuilder = managerBuilder.WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge})
 
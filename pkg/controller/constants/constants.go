@@ -46,4 +46,8 @@ const (
 
 	// MaxExecTimeSecondsLabel is the label key in the job that holds the maximum execution time.
 	MaxExecTimeSecondsLabel = `kueue.x-k8s.io/max-exec-time-seconds`
+
+	// CompletionDeadlineAnnotation is the annotation key in the job that holds
+	// the time, in RFC 3339 format, by which the job needs to complete.
+	CompletionDeadlineAnnotation = "kueue.x-k8s.io/completion-deadline"
 )
@@ -75,6 +75,7 @@ type ClusterQueueReconciler struct {
 	watchers                             []ClusterQueueUpdateWatcher
 	reportResourceMetrics                bool
 	fairSharingEnabled                   bool
+	admissionFairSharing                 *config.AdmissionFairSharing
 	queueVisibilityUpdateInterval        time.Duration
 	queueVisibilityClusterQueuesMaxCount int32
 	clock                                clock.Clock
@@ -84,6 +85,7 @@ type ClusterQueueReconcilerOptions struct {
 	Watchers                             []ClusterQueueUpdateWatcher
 	ReportResourceMetrics                bool
 	FairSharingEnabled                   bool
+	AdmissionFairSharing                 *config.AdmissionFairSharing
 	QueueVisibilityUpdateInterval        time.Duration
 	QueueVisibilityClusterQueuesMaxCount int32
 	clock                                clock.Clock
@@ -110,6 +112,14 @@ func WithFairSharing(enabled bool) ClusterQueueReconcilerOption {
 	}
 }
 
+// WithAdmissionFairSharing enables sampling the historical resource consumption
+// of the queues and persisting it in the ClusterQueue status.
+func WithAdmissionFairSharing(afs *config.AdmissionFairSharing) ClusterQueueReconcilerOption {
+	return func(o *ClusterQueueReconcilerOptions) {
+		o.AdmissionFairSharing = afs
+	}
+}
+
 // WithQueueVisibilityUpdateInterval specifies the time interval for updates to the structure
 // of the top pending workloads in the queues.
 func WithQueueVisibilityUpdateInterval(interval time.Duration) ClusterQueueReconcilerOption {
@@ -161,6 +171,7 @@ func NewClusterQueueReconciler(
 		watchers:                             options.Watchers,
 		reportResourceMetrics:                options.ReportResourceMetrics,
 		fairSharingEnabled:                   options.FairSharingEnabled,
+		admissionFairSharing:                 options.AdmissionFairSharing,
 		queueVisibilityUpdateInterval:        options.QueueVisibilityUpdateInterval,
 		queueVisibilityClusterQueuesMaxCount: options.QueueVisibilityClusterQueuesMaxCount,
 		clock:                                options.clock,
@@ -215,7 +226,8 @@ func (r *ClusterQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	if err := r.updateCqStatusIfChanged(ctx, newCQObj, cqCondition, reason, msg); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
-	return ctrl.Result{}, nil
+	// Requeue to persist the historical resource consumption sampled in the cache.
+	return ctrl.Result{RequeueAfter: consumedResourcesSamplingInterval(r.admissionFairSharing)}, nil
 }
 
 // Only topology Creation or Delete can impact the CQ active state, so we
@@ -291,6 +303,7 @@ func (r *ClusterQueueReconciler) Create(e event.CreateEvent) bool {
 	log := r.log.WithValues("clusterQueue", klog.KObj(cq))
 	log.V(2).Info("ClusterQueue create event")
 	ctx := ctrl.LoggerInto(context.Background(), log)
+	r.logLocalQueueUsageFallback(log, cq)
 	if err := r.cache.AddClusterQueue(ctx, cq); err != nil {
 		log.Error(err, "Failed to add clusterQueue to cache")
 	}
@@ -345,6 +358,9 @@ func (r *ClusterQueueReconciler) Update(e event.UpdateEvent) bool {
 	}
 	defer r.notifyWatchers(oldCq, newCq)
 	specUpdated := !equality.Semantic.DeepEqual(oldCq.Spec, newCq.Spec)
+	if specUpdated {
+		r.logLocalQueueUsageFallback(log, newCq)
+	}
 
 	if err := r.cache.UpdateClusterQueue(newCq); err != nil {
 		log.Error(err, "Failed to update clusterQueue in cache")
@@ -359,6 +375,16 @@ func (r *ClusterQueueReconciler) Update(e event.UpdateEvent) bool {
 	return true
 }
 
+// logLocalQueueUsageFallback logs that the current usage of the LocalQueues
+// is compared instead of their historical usage, which isn't sampled when
+// the admission fair sharing is disabled.
+func (r *ClusterQueueReconciler) logLocalQueueUsageFallback(log logr.Logger, cq *kueue.ClusterQueue) {
+	if r.admissionFairSharing == nil && cq.Spec.LocalQueueFairSharing != nil &&
+		cq.Spec.LocalQueueFairSharing.UsageMode == kueue.LocalQueueHistoricalUsage {
+		log.Info("The historical usage of the LocalQueues requires the admission fair sharing, comparing their current usage instead")
+	}
+}
+
 func (r *ClusterQueueReconciler) Generic(e event.GenericEvent) bool {
 	r.log.V(2).Info("Got generic event", "obj", klog.KObj(e.Object), "kind", e.Object.GetObjectKind().GroupVersionKind())
 	return true
@@ -745,6 +771,14 @@ func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
 	} else {
 		cq.Status.FairSharing = nil
 	}
+	if stats.AdmissionFairSharing != nil {
+		if cq.Status.FairSharing == nil {
+			cq.Status.FairSharing = &kueue.FairSharingStatus{}
+		}
+		cq.Status.FairSharing.AdmissionFairSharingStatus = stats.AdmissionFairSharing
+	} else if cq.Status.FairSharing != nil {
+		cq.Status.FairSharing.AdmissionFairSharingStatus = nil
+	}
 	if !equality.Semantic.DeepEqual(cq.Status, oldStatus) {
 		return r.client.Status().Update(ctx, cq)
 	}
@@ -773,6 +807,10 @@ func (r *ClusterQueueReconciler) getWorkloadsStatus(cq *kueue.ClusterQueue) *kue
 }
 
 func (r *ClusterQueueReconciler) Start(ctx context.Context) error {
+	if interval := consumedResourcesSamplingInterval(r.admissionFairSharing); interval > 0 {
+		go wait.UntilWithContext(ctx, r.sampleConsumedResources, interval)
+	}
+
 	if !r.isVisibilityEnabled() {
 		return nil
 	}
@@ -790,6 +828,20 @@ func (r *ClusterQueueReconciler) Start(ctx context.Context) error {
 	return nil
 }
 
+func (r *ClusterQueueReconciler) sampleConsumedResources(_ context.Context) {
+	r.cache.SampleConsumedResources(r.clock.Now())
+}
+
+// consumedResourcesSamplingInterval returns the interval to sample and persist
+// the historical resource consumption of the queues, or zero when admission
+// fair sharing is disabled.
+func consumedResourcesSamplingInterval(afs *config.AdmissionFairSharing) time.Duration {
+	if afs == nil || afs.UsageSamplingInterval == nil {
+		return 0
+	}
+	return afs.UsageSamplingInterval.Duration
+}
+
 func (r *ClusterQueueReconciler) enqueueTakeSnapshot(_ context.Context) {
 	for _, cq := range r.qManager.GetClusterQueueNames() {
 		r.snapshotsQueue.Add(cq)
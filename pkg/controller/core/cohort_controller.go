@@ -22,41 +22,106 @@ import (
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueuebeta "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 // CohortReconciler is responsible for synchronizing the in-memory
 // representation of Cohorts in cache.Cache and queue.Manager with
 // Cohort Kubernetes objects.
 type CohortReconciler struct {
-	client   client.Client
-	log      logr.Logger
-	cache    *cache.Cache
-	qManager *queue.Manager
+	client             client.Client
+	log                logr.Logger
+	cache              *cache.Cache
+	qManager           *queue.Manager
+	updateCh           chan event.GenericEvent
+	fairSharingEnabled bool
 }
 
-func NewCohortReconciler(client client.Client, cache *cache.Cache, qManager *queue.Manager) CohortReconciler {
-	return CohortReconciler{client, ctrl.Log.WithName("cohort-reconciler"), cache, qManager}
+type CohortReconcilerOptions struct {
+	FairSharingEnabled bool
+}
+
+// CohortReconcilerOption configures the reconciler.
+type CohortReconcilerOption func(*CohortReconcilerOptions)
+
+func WithCohortFairSharing(enabled bool) CohortReconcilerOption {
+	return func(o *CohortReconcilerOptions) {
+		o.FairSharingEnabled = enabled
+	}
+}
+
+var _ ClusterQueueUpdateWatcher = (*CohortReconciler)(nil)
+var _ WorkloadUpdateWatcher = (*CohortReconciler)(nil)
+
+func NewCohortReconciler(client client.Client, cache *cache.Cache, qManager *queue.Manager, opts ...CohortReconcilerOption) *CohortReconciler {
+	var options CohortReconcilerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &CohortReconciler{
+		client:             client,
+		log:                ctrl.Log.WithName("cohort-reconciler"),
+		cache:              cache,
+		qManager:           qManager,
+		updateCh:           make(chan event.GenericEvent, updateChBuffer),
+		fairSharingEnabled: options.FairSharingEnabled,
+	}
 }
 
 func (r *CohortReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
+	handler := cohortUpdatesHandler{
+		cache: r.cache,
+	}
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&kueue.Cohort{}).
+		Named("cohort").
+		Watches(&kueue.Cohort{}, &handler).
+		WatchesRawSource(source.Channel(r.updateCh, &handler)).
 		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
 		WithEventFilter(r).
 		Complete(WithLeadingManager(mgr, r, &kueue.Cohort{}, cfg))
 }
 
+// NotifyWorkloadUpdate enqueues the Cohorts above the ClusterQueue which
+// reserves quota for the workload, as their usage might have changed.
+func (r *CohortReconciler) NotifyWorkloadUpdate(oldWl, newWl *kueuebeta.Workload) {
+	oldReserving := oldWl != nil && workload.HasQuotaReservation(oldWl)
+	if oldReserving {
+		r.updateCh <- event.GenericEvent{Object: oldWl}
+	}
+	if newWl != nil && workload.HasQuotaReservation(newWl) &&
+		(!oldReserving || oldWl.Status.Admission.ClusterQueue != newWl.Status.Admission.ClusterQueue) {
+		r.updateCh <- event.GenericEvent{Object: newWl}
+	}
+}
+
+// NotifyClusterQueueUpdate enqueues the Cohorts above the ClusterQueue, as
+// their quotas might have changed.
+func (r *CohortReconciler) NotifyClusterQueueUpdate(oldCq, newCq *kueuebeta.ClusterQueue) {
+	if oldCq != nil && oldCq.Spec.Cohort != "" {
+		r.updateCh <- event.GenericEvent{Object: oldCq}
+	}
+	if newCq != nil && newCq.Spec.Cohort != "" && (oldCq == nil || oldCq.Spec.Cohort != newCq.Spec.Cohort) {
+		r.updateCh <- event.GenericEvent{Object: newCq}
+	}
+}
+
 func (r *CohortReconciler) Create(e event.CreateEvent) bool {
 	return true
 }
@@ -71,8 +136,7 @@ func (r *CohortReconciler) Update(e event.UpdateEvent) bool {
 		return false
 	}
 	log := r.log.WithValues("cohort", klog.KObj(newCohort))
-	if equality.Semantic.DeepEqual(oldCohort.Spec.ResourceGroups, newCohort.Spec.ResourceGroups) &&
-		oldCohort.Spec.Parent == newCohort.Spec.Parent {
+	if equality.Semantic.DeepEqual(oldCohort.Spec, newCohort.Spec) {
 		log.V(2).Info("Skip Cohort update event as Cohort unchanged")
 		return false
 	}
@@ -89,6 +153,7 @@ func (r *CohortReconciler) Generic(e event.GenericEvent) bool {
 }
 
 //+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=cohorts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=cohorts/status,verbs=get;update;patch
 
 func (r *CohortReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx).WithValues("cohort", req.Name)
@@ -108,5 +173,95 @@ func (r *CohortReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		log.V(2).Error(err, "Error adding or updating cohort in the cache")
 	}
 	r.qManager.AddOrUpdateCohort(ctx, &cohort)
-	return ctrl.Result{}, nil
+	return ctrl.Result{}, r.updateCohortStatusIfChanged(ctx, &cohort)
+}
+
+func (r *CohortReconciler) updateCohortStatusIfChanged(ctx context.Context, cohort *kueue.Cohort) error {
+	stats, err := r.cache.CohortUsage(cohort.Name)
+	if err != nil {
+		// The usage can't be computed while the Cohort is a part of a cycle.
+		ctrl.LoggerFrom(ctx).V(2).Info("Skipping Cohort status update", "reason", err.Error())
+		return nil
+	}
+	oldStatus := cohort.Status.DeepCopy()
+	cohort.Status.FlavorsUsage = stats.FlavorsUsage
+	if r.fairSharingEnabled {
+		if cohort.Status.FairSharing == nil {
+			cohort.Status.FairSharing = &kueuebeta.FairSharingStatus{}
+		}
+		cohort.Status.FairSharing.WeightedShare = stats.WeightedShare
+	} else {
+		cohort.Status.FairSharing = nil
+	}
+	if !equality.Semantic.DeepEqual(cohort.Status, *oldStatus) {
+		return r.client.Status().Update(ctx, cohort)
+	}
+	return nil
+}
+
+// cohortUpdatesHandler signals the controller to reconcile the Cohorts
+// whose aggregated quota or usage might be affected by the object in the
+// event. All the Cohorts up to the root of the tree are enqueued, as the
+// values are rolled up from the whole subtree. The Cohort of a Cohort event
+// is enqueued without delay, as it's the only handler of these events.
+type cohortUpdatesHandler struct {
+	cache *cache.Cache
+}
+
+func (h *cohortUpdatesHandler) Create(_ context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if cohort, ok := e.Object.(*kueue.Cohort); ok {
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: cohort.Name}})
+		h.enqueueAncestry(q, cohort.Spec.Parent)
+	}
+}
+
+func (h *cohortUpdatesHandler) Update(_ context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldCohort, ok := e.ObjectOld.(*kueue.Cohort)
+	if !ok {
+		return
+	}
+	newCohort, ok := e.ObjectNew.(*kueue.Cohort)
+	if !ok {
+		return
+	}
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: newCohort.Name}})
+	h.enqueueAncestry(q, oldCohort.Spec.Parent)
+	if oldCohort.Spec.Parent != newCohort.Spec.Parent {
+		h.enqueueAncestry(q, newCohort.Spec.Parent)
+	}
+}
+
+func (h *cohortUpdatesHandler) Delete(_ context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if cohort, ok := e.Object.(*kueue.Cohort); ok {
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: cohort.Name}})
+		h.enqueueAncestry(q, cohort.Spec.Parent)
+	}
+}
+
+func (h *cohortUpdatesHandler) Generic(_ context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	switch obj := e.Object.(type) {
+	case *kueuebeta.ClusterQueue:
+		h.enqueueAncestry(q, obj.Spec.Cohort)
+	case *kueuebeta.Workload:
+		if workload.HasQuotaReservation(obj) {
+			h.enqueue(q, h.cache.ClusterQueueCohortAncestry(string(obj.Status.Admission.ClusterQueue)))
+		}
+	}
+}
+
+func (h *cohortUpdatesHandler) enqueueAncestry(q workqueue.TypedRateLimitingInterface[reconcile.Request], cohortName string) {
+	if cohortName == "" {
+		return
+	}
+	h.enqueue(q, h.cache.CohortAncestry(cohortName))
+}
+
+func (h *cohortUpdatesHandler) enqueue(q workqueue.TypedRateLimitingInterface[reconcile.Request], cohortNames []string) {
+	for _, name := range cohortNames {
+		q.AddAfter(reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: name,
+			},
+		}, constants.UpdatesBatchPeriod)
+	}
 }
@@ -21,11 +21,15 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/resources"
@@ -186,7 +190,7 @@ func TestCohortReconcileLifecycle(t *testing.T) {
 	cohort := utiltesting.MakeCohort("cohort").ResourceGroup(
 		utiltesting.MakeFlavorQuotas("red").Resource("cpu", "10").FlavorQuotas,
 	).Obj()
-	cl := utiltesting.NewClientBuilder().WithObjects(cohort).Build()
+	cl := utiltesting.NewClientBuilder().WithObjects(cohort).WithStatusSubresource(cohort).Build()
 	cache := cache.New(cl)
 	qManager := queue.NewManager(cl, cache)
 	reconciler := NewCohortReconciler(cl, cache, qManager)
@@ -274,6 +278,77 @@ func TestCohortReconcileLifecycle(t *testing.T) {
 	}
 }
 
+func TestCohortReconcileStatus(t *testing.T) {
+	ctx := context.Background()
+	root := utiltesting.MakeCohort("root").ResourceGroup(
+		utiltesting.MakeFlavorQuotas("red").Resource("cpu", "10").FlavorQuotas,
+	).Obj()
+	child := utiltesting.MakeCohort("child").Parent("root").Obj()
+	cq := utiltesting.MakeClusterQueue("cq").
+		Cohort("child").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("red").Resource("cpu", "4").Obj()).
+		Obj()
+	wl := utiltesting.MakeWorkload("wl", "ns").
+		Request("cpu", "6").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment("cpu", "red", "6").Obj()).
+		Obj()
+	cl := utiltesting.NewClientBuilder().WithObjects(root, child).WithStatusSubresource(root, child).Build()
+	cache := cache.New(cl, cache.WithFairSharing(true))
+	qManager := queue.NewManager(cl, cache)
+	reconciler := NewCohortReconciler(cl, cache, qManager, WithCohortFairSharing(true))
+
+	if err := cache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("unexpected error adding ClusterQueue: %v", err)
+	}
+	cache.AddOrUpdateWorkload(wl)
+	// The child is reconciled again, as its share depends on the quota of the root.
+	for _, cohort := range []*kueuealpha.Cohort{child, root, child} {
+		if _, err := reconciler.Reconcile(
+			ctx,
+			reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cohort)},
+		); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	wantStatuses := map[string]kueuealpha.CohortStatus{
+		"root": {
+			FlavorsUsage: []kueuealpha.CohortFlavorUsage{{
+				Name: "red",
+				Resources: []kueuealpha.CohortResourceUsage{{
+					Name:         "cpu",
+					NominalQuota: resource.MustParse("14"),
+					Total:        resource.MustParse("6"),
+					Lendable:     resource.MustParse("14"),
+				}},
+			}},
+			FairSharing: &kueue.FairSharingStatus{},
+		},
+		"child": {
+			FlavorsUsage: []kueuealpha.CohortFlavorUsage{{
+				Name: "red",
+				Resources: []kueuealpha.CohortResourceUsage{{
+					Name:         "cpu",
+					NominalQuota: resource.MustParse("4"),
+					Total:        resource.MustParse("6"),
+					Borrowed:     resource.MustParse("2"),
+					Lendable:     resource.MustParse("4"),
+				}},
+			}},
+			FairSharing: &kueue.FairSharingStatus{WeightedShare: 142},
+		},
+	}
+	for name, wantStatus := range wantStatuses {
+		var cohort kueuealpha.Cohort
+		if err := cl.Get(ctx, client.ObjectKey{Name: name}, &cohort); err != nil {
+			t.Fatalf("unexpected error getting Cohort %q: %v", name, err)
+		}
+		if diff := cmp.Diff(wantStatus, cohort.Status); diff != "" {
+			t.Errorf("unexpected status of Cohort %q (-want +got) %s", name, diff)
+		}
+	}
+}
+
 func TestCohortReconcilerFilters(t *testing.T) {
 	cl := utiltesting.NewClientBuilder().
 		Build()
@@ -360,3 +435,43 @@ func TestCohortReconcilerFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestCohortUpdatesHandler(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cl := utiltesting.NewClientBuilder().Build()
+	cqCache := cache.New(cl)
+	for _, cohort := range []*kueuealpha.Cohort{
+		utiltesting.MakeCohort("root").Obj(),
+		utiltesting.MakeCohort("child").Parent("root").Obj(),
+	} {
+		if err := cqCache.AddOrUpdateCohort(cohort); err != nil {
+			t.Fatalf("Inserting cohort %s in cache: %v", cohort.Name, err)
+		}
+	}
+	handler := cohortUpdatesHandler{cache: cqCache}
+	cohort := utiltesting.MakeCohort("child").Parent("root").Obj()
+
+	cases := map[string]func(q workqueue.TypedRateLimitingInterface[reconcile.Request]){
+		"create": func(q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			handler.Create(ctx, event.CreateEvent{Object: cohort}, q)
+		},
+		"update": func(q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			handler.Update(ctx, event.UpdateEvent{ObjectOld: cohort, ObjectNew: cohort}, q)
+		},
+	}
+	for name, handle := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			defer q.ShutDown()
+			handle(q)
+			// The ancestors are enqueued after the batch period.
+			if q.Len() != 1 {
+				t.Fatalf("Unexpected number of queued reconciles, want=1, got=%d", q.Len())
+			}
+			req, _ := q.Get()
+			if req.Name != "child" {
+				t.Errorf("Unexpected queued reconcile, want=child, got=%s", req.Name)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 )
 
@@ -42,7 +43,8 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 	if err := acRec.SetupWithManager(mgr, cfg); err != nil {
 		return "AdmissionCheck", err
 	}
-	qRec := NewLocalQueueReconciler(mgr.GetClient(), qManager, cc)
+	afs := admissionFairSharing(cfg)
+	qRec := NewLocalQueueReconciler(mgr.GetClient(), qManager, cc, WithLocalQueueAdmissionFairSharing(afs))
 	if err := qRec.SetupWithManager(mgr, cfg); err != nil {
 		return "LocalQueue", err
 	}
@@ -52,6 +54,7 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 		fairSharingEnabled = cfg.FairSharing.Enable
 	}
 
+	cohortRec := NewCohortReconciler(mgr.GetClient(), cc, qManager, WithCohortFairSharing(fairSharingEnabled))
 	cqRec := NewClusterQueueReconciler(
 		mgr.GetClient(),
 		qManager,
@@ -60,7 +63,8 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 		WithReportResourceMetrics(cfg.Metrics.EnableClusterQueueResources),
 		WithQueueVisibilityClusterQueuesMaxCount(queueVisibilityClusterQueuesMaxCount(cfg)),
 		WithFairSharing(fairSharingEnabled),
-		WithWatchers(rfRec, acRec),
+		WithAdmissionFairSharing(afs),
+		WithWatchers(rfRec, acRec, cohortRec),
 	)
 	if err := mgr.Add(cqRec); err != nil {
 		return "Unable to add ClusterQueue to manager", err
@@ -71,14 +75,29 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 		return "ClusterQueue", err
 	}
 
-	cohortRec := NewCohortReconciler(mgr.GetClient(), cc, qManager)
 	if err := cohortRec.SetupWithManager(mgr, cfg); err != nil {
 		return "Cohort", err
 	}
 
+	if features.Enabled(features.AdvanceReservations) {
+		if err := NewReservationReconciler(mgr.GetClient(), cc, qManager,
+			mgr.GetEventRecorderFor(constants.ReservationControllerName),
+		).SetupWithManager(mgr, cfg); err != nil {
+			return "Reservation", err
+		}
+	}
+
+	if features.Enabled(features.QuotaSchedules) {
+		if err := NewQuotaScheduleReconciler(mgr.GetClient(), cc, qManager,
+			mgr.GetEventRecorderFor(constants.QuotaScheduleControllerName),
+		).SetupWithManager(mgr, cfg); err != nil {
+			return "QuotaSchedule", err
+		}
+	}
+
 	if err := NewWorkloadReconciler(mgr.GetClient(), qManager, cc,
 		mgr.GetEventRecorderFor(constants.WorkloadControllerName),
-		WithWorkloadUpdateWatchers(qRec, cqRec),
+		WithWorkloadUpdateWatchers(qRec, cqRec, cohortRec),
 		WithWaitForPodsReady(waitForPodsReady(cfg.WaitForPodsReady)),
 	).SetupWithManager(mgr, cfg); err != nil {
 		return "Workload", err
@@ -103,6 +122,13 @@ func waitForPodsReady(cfg *configapi.WaitForPodsReady) *waitForPodsReadyConfig {
 	return &result
 }
 
+func admissionFairSharing(cfg *configapi.Configuration) *configapi.AdmissionFairSharing {
+	if !features.Enabled(features.AdmissionFairSharing) {
+		return nil
+	}
+	return cfg.AdmissionFairSharing
+}
+
 func queueVisibilityUpdateInterval(cfg *configapi.Configuration) time.Duration {
 	if cfg.QueueVisibility != nil {
 		return time.Duration(cfg.QueueVisibility.UpdateIntervalSeconds) * time.Second
@@ -38,6 +38,11 @@ const (
 	WorkloadQuotaReservedKey   = "status.quotaReserved"
 	WorkloadRuntimeClassKey    = "spec.runtimeClass"
 	OwnerReferenceUID          = "metadata.ownerReferences.uid"
+	WorkloadDependencyKey      = "spec.dependencies"
+
+	// WorkloadDependencySelector is the value indexed under the
+	// WorkloadDependencyKey for the dependencies specified by a selector.
+	WorkloadDependencySelector = "*"
 )
 
 func IndexQueueClusterQueue(obj client.Object) []string {
@@ -112,6 +117,29 @@ func IndexWorkloadRuntimeClass(obj client.Object) []string {
 	return nil
 }
 
+// IndexWorkloadDependency indexes the workloads by the names of their
+// dependencies, and by WorkloadDependencySelector if any dependency is
+// specified by a selector.
+func IndexWorkloadDependency(obj client.Object) []string {
+	wl, ok := obj.(*kueue.Workload)
+	if !ok {
+		return nil
+	}
+	set := sets.New[string]()
+	for _, dep := range wl.Spec.Dependencies {
+		if dep.Name != nil {
+			set.Insert(*dep.Name)
+		}
+		if dep.Selector != nil {
+			set.Insert(WorkloadDependencySelector)
+		}
+	}
+	if set.Len() > 0 {
+		return set.UnsortedList()
+	}
+	return nil
+}
+
 func IndexOwnerUID(obj client.Object) []string {
 	return slices.Map(obj.GetOwnerReferences(), func(o *metav1.OwnerReference) string { return string(o.UID) })
 }
@@ -139,5 +167,8 @@ func Setup(ctx context.Context, indexer client.FieldIndexer) error {
 	if err := indexer.IndexField(ctx, &kueue.Workload{}, OwnerReferenceUID, IndexOwnerUID); err != nil {
 		return fmt.Errorf("setting index on ownerReferences.uid for Workload: %w", err)
 	}
+	if err := indexer.IndexField(ctx, &kueue.Workload{}, WorkloadDependencyKey, IndexWorkloadDependency); err != nil {
+		return fmt.Errorf("setting index on dependencies for Workload: %w", err)
+	}
 	return nil
 }
@@ -59,24 +59,46 @@ const (
 
 // LocalQueueReconciler reconciles a LocalQueue object
 type LocalQueueReconciler struct {
-	client     client.Client
-	log        logr.Logger
-	queues     *queue.Manager
-	cache      *cache.Cache
-	wlUpdateCh chan event.GenericEvent
+	client               client.Client
+	log                  logr.Logger
+	queues               *queue.Manager
+	cache                *cache.Cache
+	wlUpdateCh           chan event.GenericEvent
+	admissionFairSharing *config.AdmissionFairSharing
+}
+
+type LocalQueueReconcilerOptions struct {
+	AdmissionFairSharing *config.AdmissionFairSharing
+}
+
+// LocalQueueReconcilerOption configures the reconciler.
+type LocalQueueReconcilerOption func(*LocalQueueReconcilerOptions)
+
+// WithLocalQueueAdmissionFairSharing enables persisting the historical
+// resource consumption in the LocalQueue status.
+func WithLocalQueueAdmissionFairSharing(afs *config.AdmissionFairSharing) LocalQueueReconcilerOption {
+	return func(o *LocalQueueReconcilerOptions) {
+		o.AdmissionFairSharing = afs
+	}
 }
 
 func NewLocalQueueReconciler(
 	client client.Client,
 	queues *queue.Manager,
 	cache *cache.Cache,
+	opts ...LocalQueueReconcilerOption,
 ) *LocalQueueReconciler {
+	var options LocalQueueReconcilerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 	return &LocalQueueReconciler{
-		log:        ctrl.Log.WithName("localqueue-reconciler"),
-		queues:     queues,
-		cache:      cache,
-		client:     client,
-		wlUpdateCh: make(chan event.GenericEvent, updateChBuffer),
+		log:                  ctrl.Log.WithName("localqueue-reconciler"),
+		queues:               queues,
+		cache:                cache,
+		client:               client,
+		wlUpdateCh:           make(chan event.GenericEvent, updateChBuffer),
+		admissionFairSharing: options.AdmissionFairSharing,
 	}
 }
 
@@ -123,10 +145,14 @@ func (r *LocalQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 	if meta.IsStatusConditionTrue(cq.Status.Conditions, kueue.ClusterQueueActive) {
 		err = r.UpdateStatusIfChanged(ctx, &queueObj, metav1.ConditionTrue, "Ready", "Can submit new workloads to clusterQueue")
+	} else {
+		err = r.UpdateStatusIfChanged(ctx, &queueObj, metav1.ConditionFalse, clusterQueueIsInactiveReason, clusterQueueIsInactiveMsg)
+	}
+	if err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
-	err = r.UpdateStatusIfChanged(ctx, &queueObj, metav1.ConditionFalse, clusterQueueIsInactiveReason, clusterQueueIsInactiveMsg)
-	return ctrl.Result{}, client.IgnoreNotFound(err)
+	// Requeue to persist the historical resource consumption sampled in the cache.
+	return ctrl.Result{RequeueAfter: consumedResourcesSamplingInterval(r.admissionFairSharing)}, nil
 }
 
 func (r *LocalQueueReconciler) Create(e event.CreateEvent) bool {
@@ -373,6 +399,14 @@ func (r *LocalQueueReconciler) UpdateStatusIfChanged(
 	queue.Status.FlavorsReservation = stats.ReservedResources
 	queue.Status.FlavorUsage = stats.AdmittedResources
 	queue.Status.Flavors = stats.Flavors
+	if stats.WeightedShare != nil || stats.AdmissionFairSharing != nil {
+		queue.Status.FairSharing = &kueue.FairSharingStatus{
+			WeightedShare:              ptr.Deref(stats.WeightedShare, 0),
+			AdmissionFairSharingStatus: stats.AdmissionFairSharing,
+		}
+	} else {
+		queue.Status.FairSharing = nil
+	}
 	if len(conditionStatus) != 0 && len(reason) != 0 && len(msg) != 0 {
 		meta.SetStatusCondition(&queue.Status.Conditions, metav1.Condition{
 			Type:               kueue.LocalQueueActive,
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// preemptToReclaimQuota evicts the targets, which use quota that is no
+// longer available to them, setting the Preempted condition with the
+// reason. The targets which were deleted meanwhile are skipped.
+func preemptToReclaimQuota(ctx context.Context, c client.Client, recorder record.EventRecorder, now time.Time, targets []*workload.Info, reason, message string) error {
+	log := ctrl.LoggerFrom(ctx)
+	for _, target := range targets {
+		if apimeta.IsStatusConditionTrue(target.Obj.Status.Conditions, kueue.WorkloadEvicted) {
+			continue
+		}
+		wl := target.Obj.DeepCopy()
+		workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByPreemption, message)
+		workload.ResetChecksOnEviction(wl, now)
+		workload.SetPreemptedCondition(wl, reason, message)
+		if err := workload.ApplyAdmissionStatus(ctx, c, wl, true); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				continue
+			}
+			return err
+		}
+		log.V(3).Info("Preempted", "targetWorkload", klog.KObj(wl), "reason", reason, "targetClusterQueue", klog.KRef("", target.ClusterQueue))
+		recorder.Eventf(wl, corev1.EventTypeNormal, "Preempted", message)
+	}
+	return nil
+}
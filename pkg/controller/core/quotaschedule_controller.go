@@ -0,0 +1,280 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/util/cron"
+)
+
+// QuotaScheduleReconciler applies the quota of the active window of the
+// QuotaSchedules to their ClusterQueues in the cache, and reclaims the
+// quota used above the quota in effect, if requested.
+type QuotaScheduleReconciler struct {
+	client   client.Client
+	log      logr.Logger
+	cache    *cache.Cache
+	qManager *queue.Manager
+	recorder record.EventRecorder
+	clock    clock.Clock
+}
+
+type QuotaScheduleReconcilerOptions struct {
+	Clock clock.Clock
+}
+
+// QuotaScheduleReconcilerOption configures the reconciler.
+type QuotaScheduleReconcilerOption func(*QuotaScheduleReconcilerOptions)
+
+// WithQuotaScheduleClock allows to specify a custom clock.
+func WithQuotaScheduleClock(_ testing.TB, c clock.Clock) QuotaScheduleReconcilerOption {
+	return func(o *QuotaScheduleReconcilerOptions) {
+		o.Clock = c
+	}
+}
+
+func NewQuotaScheduleReconciler(client client.Client, cache *cache.Cache, qManager *queue.Manager, recorder record.EventRecorder, opts ...QuotaScheduleReconcilerOption) *QuotaScheduleReconciler {
+	options := QuotaScheduleReconcilerOptions{
+		Clock: realClock,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &QuotaScheduleReconciler{
+		client:   client,
+		log:      ctrl.Log.WithName("quotaschedule-reconciler"),
+		cache:    cache,
+		qManager: qManager,
+		recorder: recorder,
+		clock:    options.Clock,
+	}
+}
+
+func (r *QuotaScheduleReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kueuealpha.QuotaSchedule{}).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(WithLeadingManager(mgr, r, &kueuealpha.QuotaSchedule{}, cfg))
+}
+
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=quotaschedules,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=quotaschedules/status,verbs=get;update;patch
+
+func (r *QuotaScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("quotaSchedule", req.Name)
+	ctx = ctrl.LoggerInto(ctx, log)
+	log.V(2).Info("Reconciling QuotaSchedule")
+	var qs kueuealpha.QuotaSchedule
+	if err := r.client.Get(ctx, req.NamespacedName, &qs); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.V(2).Info("QuotaSchedule is being deleted")
+			cqName, found := r.cache.QuotaScheduleClusterQueue(req.Name)
+			if err := r.cache.DeleteQuotaSchedule(req.Name); err != nil {
+				log.Error(err, "Restoring the quota of the ClusterQueue")
+			}
+			if found {
+				r.qManager.QueueInadmissibleWorkloads(ctx, sets.New(cqName))
+			}
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	cqName := string(qs.Spec.ClusterQueue)
+	now := r.clock.Now()
+	windows, err := parseQuotaWindows(&qs)
+	if err != nil {
+		log.V(2).Info("Invalid QuotaSchedule", "error", err.Error())
+		if err := r.cache.SetQuotaScheduleOverrides(qs.Name, cqName, nil); err != nil {
+			log.Error(err, "Restoring the quota of the ClusterQueue")
+		}
+		r.qManager.QueueInadmissibleWorkloads(ctx, sets.New(cqName))
+		return ctrl.Result{}, r.updateStatusIfChanged(ctx, &qs, nil, err)
+	}
+
+	active := activeQuotaWindow(windows, now)
+	var overrides map[resources.FlavorResource]cache.QuotaOverride
+	if active != nil {
+		overrides = quotaOverrides(active.window)
+	}
+	if err := r.cache.SetQuotaScheduleOverrides(qs.Name, cqName, overrides); err != nil {
+		log.Error(err, "Applying the quota of the window to the ClusterQueue")
+	}
+	// A larger quota might make the pending workloads admissible.
+	r.qManager.QueueInadmissibleWorkloads(ctx, sets.New(cqName))
+
+	if qs.Spec.ReclaimPolicy == kueuealpha.QuotaReclaimPreempt {
+		if err := r.reclaim(ctx, cqName); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if err := r.updateStatusIfChanged(ctx, &qs, active, nil); err != nil {
+		return ctrl.Result{}, err
+	}
+	if next, found := nextQuotaWindowTransition(windows, now); found {
+		return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// reclaim evicts the workloads of the ClusterQueue until its usage fits the
+// quota in effect.
+func (r *QuotaScheduleReconciler) reclaim(ctx context.Context, cqName string) error {
+	snapshot, err := r.cache.Snapshot(ctx)
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("Preempted due to %s", preemption.HumanReadablePreemptionReasons[kueue.InQuotaReductionReason])
+	return preemptToReclaimQuota(ctx, r.client, r.recorder, r.clock.Now(), snapshot.ClusterQueueReclaimTargets(cqName), kueue.InQuotaReductionReason, message)
+}
+
+func (r *QuotaScheduleReconciler) updateStatusIfChanged(ctx context.Context, qs *kueuealpha.QuotaSchedule, active *activeWindow, invalid error) error {
+	oldStatus := qs.Status.DeepCopy()
+	cond := metav1.Condition{
+		Type:               kueuealpha.QuotaScheduleActive,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: qs.Generation,
+	}
+	qs.Status.ActiveWindow = ""
+	switch {
+	case invalid != nil:
+		cond.Reason = kueuealpha.QuotaScheduleReasonInvalidSchedule
+		cond.Message = invalid.Error()
+	case active != nil:
+		qs.Status.ActiveWindow = active.window.Name
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = kueuealpha.QuotaScheduleReasonWindowActive
+		cond.Message = fmt.Sprintf("The quota of the window %q is applied until %s", active.window.Name, active.end.UTC().Format(time.RFC3339))
+	default:
+		cond.Reason = kueuealpha.QuotaScheduleReasonNoActiveWindow
+		cond.Message = "The ClusterQueue uses the quota from its spec"
+	}
+	apimeta.SetStatusCondition(&qs.Status.Conditions, cond)
+	if !equality.Semantic.DeepEqual(qs.Status, *oldStatus) {
+		return r.client.Status().Update(ctx, qs)
+	}
+	return nil
+}
+
+type parsedQuotaWindow struct {
+	window   *kueuealpha.QuotaWindow
+	schedule *cron.Schedule
+	location *time.Location
+}
+
+type activeWindow struct {
+	window *kueuealpha.QuotaWindow
+	end    time.Time
+}
+
+func parseQuotaWindows(qs *kueuealpha.QuotaSchedule) ([]parsedQuotaWindow, error) {
+	loc := time.UTC
+	if tz := ptr.Deref(qs.Spec.TimeZone, ""); tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", tz, err)
+		}
+	}
+	windows := make([]parsedQuotaWindow, 0, len(qs.Spec.Windows))
+	for i := range qs.Spec.Windows {
+		w := &qs.Spec.Windows[i]
+		schedule, err := cron.Parse(w.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule of the window %q: %w", w.Name, err)
+		}
+		if w.Duration.Duration <= 0 {
+			return nil, fmt.Errorf("invalid duration of the window %q: must be positive", w.Name)
+		}
+		windows = append(windows, parsedQuotaWindow{window: w, schedule: schedule, location: loc})
+	}
+	return windows, nil
+}
+
+// activeQuotaWindow returns the first window active at now, or nil.
+func activeQuotaWindow(windows []parsedQuotaWindow, now time.Time) *activeWindow {
+	for _, w := range windows {
+		if start, found := w.schedule.LastActivation(now.In(w.location), w.window.Duration.Duration); found {
+			return &activeWindow{window: w.window, end: start.Add(w.window.Duration.Duration)}
+		}
+	}
+	return nil
+}
+
+// nextQuotaWindowTransition returns the next time at which a window starts
+// or ends.
+func nextQuotaWindowTransition(windows []parsedQuotaWindow, now time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	consider := func(t time.Time) {
+		if t.After(now) && (!found || t.Before(next)) {
+			next, found = t, true
+		}
+	}
+	for _, w := range windows {
+		local := now.In(w.location)
+		if start, ok := w.schedule.LastActivation(local, w.window.Duration.Duration); ok {
+			consider(start.Add(w.window.Duration.Duration))
+		}
+		if start, ok := w.schedule.Next(local); ok {
+			consider(start)
+		}
+	}
+	return next, found
+}
+
+func quotaOverrides(w *kueuealpha.QuotaWindow) map[resources.FlavorResource]cache.QuotaOverride {
+	overrides := make(map[resources.FlavorResource]cache.QuotaOverride)
+	for _, flv := range w.Flavors {
+		for _, rq := range flv.Resources {
+			var o cache.QuotaOverride
+			if rq.NominalQuota != nil {
+				o.Nominal = ptr.To(resources.ResourceValue(rq.Name, *rq.NominalQuota))
+			}
+			if rq.BorrowingLimit != nil {
+				o.BorrowingLimit = ptr.To(resources.ResourceValue(rq.Name, *rq.BorrowingLimit))
+			}
+			if rq.LendingLimit != nil {
+				o.LendingLimit = ptr.To(resources.ResourceValue(rq.Name, *rq.LendingLimit))
+			}
+			overrides[resources.FlavorResource{Flavor: flv.Name, Resource: rq.Name}] = o
+		}
+	}
+	return overrides
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestQuotaScheduleReconcile(t *testing.T) {
+	day := time.Date(2024, time.December, 2, 0, 0, 0, 0, time.UTC)
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	cases := map[string]struct {
+		quotaSchedule    *kueuealpha.QuotaSchedule
+		now              time.Time
+		wantActive       metav1.ConditionStatus
+		wantReason       string
+		wantWindow       string
+		wantNominal      int64
+		wantRequeue      time.Duration
+		wantPreempted    []string
+		wantNotPreempted []string
+	}{
+		"no active window": {
+			quotaSchedule: utiltesting.MakeQuotaSchedule("night", "cq").
+				Window("night", "0 22 * * *", 8*time.Hour, "default", corev1.ResourceCPU, "20").
+				Obj(),
+			now:              day.Add(12 * time.Hour),
+			wantActive:       metav1.ConditionFalse,
+			wantReason:       kueuealpha.QuotaScheduleReasonNoActiveWindow,
+			wantNominal:      10_000,
+			wantRequeue:      10 * time.Hour,
+			wantNotPreempted: []string{"low", "high"},
+		},
+		"active window": {
+			quotaSchedule: utiltesting.MakeQuotaSchedule("night", "cq").
+				Window("night", "0 22 * * *", 8*time.Hour, "default", corev1.ResourceCPU, "20").
+				Obj(),
+			now:              day.Add(23 * time.Hour),
+			wantActive:       metav1.ConditionTrue,
+			wantReason:       kueuealpha.QuotaScheduleReasonWindowActive,
+			wantWindow:       "night",
+			wantNominal:      20_000,
+			wantRequeue:      7 * time.Hour,
+			wantNotPreempted: []string{"low", "high"},
+		},
+		"active window in the time zone": {
+			quotaSchedule: utiltesting.MakeQuotaSchedule("night", "cq").
+				TimeZone("Asia/Tokyo").
+				Window("night", "0 22 * * *", 8*time.Hour, "default", corev1.ResourceCPU, "20").
+				Obj(),
+			now:              day.Add(14 * time.Hour),
+			wantActive:       metav1.ConditionTrue,
+			wantReason:       kueuealpha.QuotaScheduleReasonWindowActive,
+			wantWindow:       "night",
+			wantNominal:      20_000,
+			wantRequeue:      7 * time.Hour,
+			wantNotPreempted: []string{"low", "high"},
+		},
+		"reduced quota is not reclaimed": {
+			quotaSchedule: utiltesting.MakeQuotaSchedule("day", "cq").
+				Window("day", "0 8 * * 1-5", 10*time.Hour, "default", corev1.ResourceCPU, "5").
+				Obj(),
+			now:              day.Add(9 * time.Hour),
+			wantActive:       metav1.ConditionTrue,
+			wantReason:       kueuealpha.QuotaScheduleReasonWindowActive,
+			wantWindow:       "day",
+			wantNominal:      5_000,
+			wantRequeue:      9 * time.Hour,
+			wantNotPreempted: []string{"low", "high"},
+		},
+		"reduced quota is reclaimed": {
+			quotaSchedule: utiltesting.MakeQuotaSchedule("day", "cq").
+				ReclaimPolicy(kueuealpha.QuotaReclaimPreempt).
+				Window("day", "0 8 * * 1-5", 10*time.Hour, "default", corev1.ResourceCPU, "5").
+				Obj(),
+			now:              day.Add(9 * time.Hour),
+			wantActive:       metav1.ConditionTrue,
+			wantReason:       kueuealpha.QuotaScheduleReasonWindowActive,
+			wantWindow:       "day",
+			wantNominal:      5_000,
+			wantRequeue:      9 * time.Hour,
+			wantPreempted:    []string{"low"},
+			wantNotPreempted: []string{"high"},
+		},
+		"invalid schedule": {
+			quotaSchedule: utiltesting.MakeQuotaSchedule("night", "cq").
+				Window("night", "0 25 * * *", 8*time.Hour, "default", corev1.ResourceCPU, "20").
+				Obj(),
+			now:              day.Add(23 * time.Hour),
+			wantActive:       metav1.ConditionFalse,
+			wantReason:       kueuealpha.QuotaScheduleReasonInvalidSchedule,
+			wantNominal:      10_000,
+			wantNotPreempted: []string{"low", "high"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			clock := testingclock.NewFakeClock(tc.now)
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+				Obj()
+			workloads := []*kueue.Workload{
+				utiltesting.MakeWorkload("low", "ns").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "4").Obj(), tc.now).
+					Admitted(true).
+					Obj(),
+				utiltesting.MakeWorkload("high", "ns").
+					Priority(100).
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "4").Obj(), tc.now).
+					Admitted(true).
+					Obj(),
+			}
+			objs := []client.Object{tc.quotaSchedule}
+			for _, wl := range workloads {
+				objs = append(objs, wl)
+			}
+			cl := utiltesting.NewFakeClientSSAAsSM(objs...)
+			cqCache := cache.New(cl)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Adding ClusterQueue: %v", err)
+			}
+			for _, wl := range workloads {
+				cqCache.AddOrUpdateWorkload(wl)
+			}
+			qManager := queue.NewManager(cl, cqCache)
+			reconciler := NewQuotaScheduleReconciler(cl, cqCache, qManager, &utiltesting.EventRecorder{}, WithQuotaScheduleClock(t, clock))
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.quotaSchedule)})
+			if err != nil {
+				t.Fatalf("Reconciling: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantRequeue, result.RequeueAfter); diff != "" {
+				t.Errorf("Unexpected requeue (-want,+got):\n%s", diff)
+			}
+
+			var gotQuotaSchedule kueuealpha.QuotaSchedule
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(tc.quotaSchedule), &gotQuotaSchedule); err != nil {
+				t.Fatalf("Getting QuotaSchedule: %v", err)
+			}
+			if gotQuotaSchedule.Status.ActiveWindow != tc.wantWindow {
+				t.Errorf("Unexpected active window, want=%q, got=%q", tc.wantWindow, gotQuotaSchedule.Status.ActiveWindow)
+			}
+			cond := apimeta.FindStatusCondition(gotQuotaSchedule.Status.Conditions, kueuealpha.QuotaScheduleActive)
+			if cond == nil || cond.Status != tc.wantActive || cond.Reason != tc.wantReason {
+				t.Errorf("Unexpected Active condition %v, want status=%s, reason=%s", cond, tc.wantActive, tc.wantReason)
+			}
+
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Taking the snapshot: %v", err)
+			}
+			if got := snapshot.ClusterQueues["cq"].ResourceNode.Quotas[cpu].Nominal; got != tc.wantNominal {
+				t.Errorf("Unexpected nominal quota, want=%d, got=%d", tc.wantNominal, got)
+			}
+
+			checkPreempted := func(names []string, want bool) {
+				for _, name := range names {
+					var wl kueue.Workload
+					if err := cl.Get(ctx, client.ObjectKey{Namespace: "ns", Name: name}, &wl); err != nil {
+						t.Fatalf("Getting workload %q: %v", name, err)
+					}
+					got := apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadPreempted)
+					if got != want {
+						t.Errorf("Unexpected preemption of workload %q, want=%t, got=%t", name, want, got)
+					}
+				}
+			}
+			checkPreempted(tc.wantPreempted, true)
+			checkPreempted(tc.wantNotPreempted, false)
+		})
+	}
+}
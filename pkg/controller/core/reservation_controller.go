@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+)
+
+// ReservationReconciler synchronizes the Reservations with the cache, and
+// evicts the workloads which use the reserved quota, without referencing the
+// Reservation, when its window starts.
+type ReservationReconciler struct {
+	client   client.Client
+	log      logr.Logger
+	cache    *cache.Cache
+	qManager *queue.Manager
+	recorder record.EventRecorder
+	clock    clock.Clock
+}
+
+type ReservationReconcilerOptions struct {
+	Clock clock.Clock
+}
+
+// ReservationReconcilerOption configures the reconciler.
+type ReservationReconcilerOption func(*ReservationReconcilerOptions)
+
+// WithReservationClock allows to specify a custom clock.
+func WithReservationClock(_ testing.TB, c clock.Clock) ReservationReconcilerOption {
+	return func(o *ReservationReconcilerOptions) {
+		o.Clock = c
+	}
+}
+
+func NewReservationReconciler(client client.Client, cache *cache.Cache, qManager *queue.Manager, recorder record.EventRecorder, opts ...ReservationReconcilerOption) *ReservationReconciler {
+	options := ReservationReconcilerOptions{
+		Clock: realClock,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &ReservationReconciler{
+		client:   client,
+		log:      ctrl.Log.WithName("reservation-reconciler"),
+		cache:    cache,
+		qManager: qManager,
+		recorder: recorder,
+		clock:    options.Clock,
+	}
+}
+
+func (r *ReservationReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kueuealpha.Reservation{}).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(WithLeadingManager(mgr, r, &kueuealpha.Reservation{}, cfg))
+}
+
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=reservations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=reservations/status,verbs=get;update;patch
+
+func (r *ReservationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("reservation", req.Name)
+	ctx = ctrl.LoggerInto(ctx, log)
+	log.V(2).Info("Reconciling Reservation")
+	var reservation kueuealpha.Reservation
+	if err := r.client.Get(ctx, req.NamespacedName, &reservation); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.V(2).Info("Reservation is being deleted")
+			cqNames := r.cache.ReservationClusterQueues(req.Name)
+			r.cache.DeleteReservation(req.Name)
+			r.qManager.QueueInadmissibleWorkloads(ctx, cqNames)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	r.cache.AddOrUpdateReservation(&reservation)
+	// The held quota changes at the transitions of the Reservation, which
+	// might make the pending workloads admissible.
+	defer r.qManager.QueueInadmissibleWorkloads(ctx, r.cache.ReservationClusterQueues(reservation.Name))
+
+	now := r.clock.Now()
+	start, end := reservation.Spec.StartTime.Time, reservation.Spec.EndTime.Time
+	if !now.Before(start) && now.Before(end) {
+		if err := r.drain(ctx, &reservation); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if err := r.updateStatusIfChanged(ctx, &reservation, now); err != nil {
+		return ctrl.Result{}, err
+	}
+	if next, found := nextReservationTransition(&reservation, now); found {
+		return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// drain evicts the workloads which use the quota of the started Reservation
+// without referencing it.
+func (r *ReservationReconciler) drain(ctx context.Context, reservation *kueuealpha.Reservation) error {
+	snapshot, err := r.cache.Snapshot(ctx)
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("Preempted to free the quota held by the Reservation %q due to %s", reservation.Name, preemption.HumanReadablePreemptionReasons[kueue.InReservationReason])
+	return preemptToReclaimQuota(ctx, r.client, r.recorder, r.clock.Now(), snapshot.ReservationDrainTargets(reservation.Name), kueue.InReservationReason, message)
+}
+
+func (r *ReservationReconciler) updateStatusIfChanged(ctx context.Context, reservation *kueuealpha.Reservation, now time.Time) error {
+	oldStatus := reservation.Status.DeepCopy()
+	cond := metav1.Condition{
+		Type:               kueuealpha.ReservationActive,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: reservation.Generation,
+	}
+	switch {
+	case now.Before(reservation.Spec.StartTime.Time):
+		cond.Reason = kueuealpha.ReservationReasonPending
+		cond.Message = "The window of the Reservation didn't start yet"
+	case now.Before(reservation.Spec.EndTime.Time):
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = kueuealpha.ReservationReasonStarted
+		cond.Message = "The reserved quota can only be used by the workloads referencing the Reservation"
+	default:
+		cond.Reason = kueuealpha.ReservationReasonExpired
+		cond.Message = "The window of the Reservation has ended"
+	}
+	apimeta.SetStatusCondition(&reservation.Status.Conditions, cond)
+	if !equality.Semantic.DeepEqual(reservation.Status, *oldStatus) {
+		return r.client.Status().Update(ctx, reservation)
+	}
+	return nil
+}
+
+// nextReservationTransition returns the next time at which the Reservation
+// starts holding quota, its window starts or ends.
+func nextReservationTransition(reservation *kueuealpha.Reservation, now time.Time) (time.Time, bool) {
+	start := reservation.Spec.StartTime.Time
+	transitions := []time.Time{start, reservation.Spec.EndTime.Time}
+	if reservation.Spec.LeadTime != nil {
+		transitions = append([]time.Time{start.Add(-reservation.Spec.LeadTime.Duration)}, transitions...)
+	}
+	for _, t := range transitions {
+		if t.After(now) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
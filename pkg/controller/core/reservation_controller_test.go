@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestReservationReconcile(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cases := map[string]struct {
+		now              time.Time
+		wantActive       metav1.ConditionStatus
+		wantReason       string
+		wantRequeue      time.Duration
+		wantPreempted    []string
+		wantNotPreempted []string
+	}{
+		"pending": {
+			now:              now.Add(-time.Hour),
+			wantActive:       metav1.ConditionFalse,
+			wantReason:       kueuealpha.ReservationReasonPending,
+			wantRequeue:      30 * time.Minute,
+			wantNotPreempted: []string{"low", "high", "referencing"},
+		},
+		"started": {
+			now:              now,
+			wantActive:       metav1.ConditionTrue,
+			wantReason:       kueuealpha.ReservationReasonStarted,
+			wantRequeue:      time.Hour,
+			wantPreempted:    []string{"low"},
+			wantNotPreempted: []string{"high", "referencing"},
+		},
+		"expired": {
+			now:              now.Add(2 * time.Hour),
+			wantActive:       metav1.ConditionFalse,
+			wantReason:       kueuealpha.ReservationReasonExpired,
+			wantNotPreempted: []string{"low", "high", "referencing"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.AdvanceReservations, true)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			clock := testingclock.NewFakeClock(tc.now)
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+				Obj()
+			reservation := utiltesting.MakeReservation("maintenance", "cq", now, now.Add(time.Hour)).
+				Flavor("default", corev1.ResourceCPU, "6").
+				LeadTime(30 * time.Minute).
+				Obj()
+			workloads := []*kueue.Workload{
+				utiltesting.MakeWorkload("low", "ns").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "4").Obj(), now).
+					Admitted(true).
+					Obj(),
+				utiltesting.MakeWorkload("high", "ns").
+					Priority(100).
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "4").Obj(), now).
+					Admitted(true).
+					Obj(),
+				utiltesting.MakeWorkload("referencing", "ns").
+					Label(kueuealpha.ReservationLabel, "maintenance").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj(), now).
+					Admitted(true).
+					Obj(),
+			}
+			objs := []client.Object{reservation}
+			for _, wl := range workloads {
+				objs = append(objs, wl)
+			}
+			cl := utiltesting.NewFakeClientSSAAsSM(objs...)
+			cqCache := cache.New(cl, cache.WithClock(t, clock))
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Adding ClusterQueue: %v", err)
+			}
+			for _, wl := range workloads {
+				cqCache.AddOrUpdateWorkload(wl)
+			}
+			qManager := queue.NewManager(cl, cqCache)
+			reconciler := NewReservationReconciler(cl, cqCache, qManager, &utiltesting.EventRecorder{}, WithReservationClock(t, clock))
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(reservation)})
+			if err != nil {
+				t.Fatalf("Reconciling: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantRequeue, result.RequeueAfter); diff != "" {
+				t.Errorf("Unexpected requeue (-want,+got):\n%s", diff)
+			}
+
+			var gotReservation kueuealpha.Reservation
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(reservation), &gotReservation); err != nil {
+				t.Fatalf("Getting Reservation: %v", err)
+			}
+			cond := apimeta.FindStatusCondition(gotReservation.Status.Conditions, kueuealpha.ReservationActive)
+			if cond == nil || cond.Status != tc.wantActive || cond.Reason != tc.wantReason {
+				t.Errorf("Unexpected Active condition %v, want status=%s, reason=%s", cond, tc.wantActive, tc.wantReason)
+			}
+
+			checkPreempted := func(names []string, want bool) {
+				for _, name := range names {
+					var wl kueue.Workload
+					if err := cl.Get(ctx, client.ObjectKey{Namespace: "ns", Name: name}, &wl); err != nil {
+						t.Fatalf("Getting workload %q: %v", name, err)
+					}
+					got := apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadPreempted)
+					if got != want {
+						t.Errorf("Unexpected preemption of workload %q, want=%t, got=%t", name, want, got)
+					}
+				}
+			}
+			checkPreempted(tc.wantPreempted, true)
+			checkPreempted(tc.wantNotPreempted, false)
+		})
+	}
+}
@@ -43,15 +43,18 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
 	utilac "sigs.k8s.io/kueue/pkg/util/admissioncheck"
+	"sigs.k8s.io/kueue/pkg/util/priority"
 	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -60,6 +63,14 @@ var (
 	realClock = clock.RealClock{}
 )
 
+// dependencyUpdateChBuffer is the size of the channel of the finished
+// workloads whose dependents need to be reconciled.
+const dependencyUpdateChBuffer = 10
+
+// minPriorityAgingRefresh is the minimum time between the updates of the
+// effective priority of a pending workload.
+const minPriorityAgingRefresh = time.Minute
+
 type waitForPodsReadyConfig struct {
 	timeout                     time.Duration
 	requeuingBackoffLimitCount  *int32
@@ -106,6 +117,10 @@ type WorkloadReconciler struct {
 	waitForPodsReady *waitForPodsReadyConfig
 	recorder         record.EventRecorder
 	clock            clock.Clock
+
+	// dependencyUpdateCh receives the workloads which finished, or which were
+	// deleted before finishing, to reconcile the workloads depending on them.
+	dependencyUpdateCh chan event.GenericEvent
 }
 
 func NewWorkloadReconciler(client client.Client, queues *queue.Manager, cache *cache.Cache, recorder record.EventRecorder, opts ...Option) *WorkloadReconciler {
@@ -123,6 +138,8 @@ func NewWorkloadReconciler(client client.Client, queues *queue.Manager, cache *c
 		waitForPodsReady: options.waitForPodsReadyConfig,
 		recorder:         recorder,
 		clock:            realClock,
+
+		dependencyUpdateCh: make(chan event.GenericEvent, dependencyUpdateChBuffer),
 	}
 }
 
@@ -230,9 +247,9 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 
 	cqName, cqOk := r.queues.ClusterQueueForWorkload(&wl)
+	cq := kueue.ClusterQueue{}
 	if cqOk {
 		// because we need to react to API cluster cq events, the list of checks from a cache can lead to race conditions
-		cq := kueue.ClusterQueue{}
 		if err := r.client.Get(ctx, types.NamespacedName{Name: cqName}, &cq); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -272,6 +289,10 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			return ctrl.Result{}, err
 		}
 
+		if features.Enabled(features.TopologyAwareScheduling) {
+			r.queues.QueueSecondPassIfNeeded(ctx, &wl)
+		}
+
 		if updated, err := r.reconcileOnLocalQueueActiveState(ctx, &wl, lqExists, &lq); updated || err != nil {
 			return ctrl.Result{}, err
 		}
@@ -324,7 +345,129 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		}
 	}
 
-	return ctrl.Result{}, nil
+	if workload.HasDependencies(&wl) && workload.IsActive(&wl) {
+		updated, err := r.reconcileDependencies(ctx, &wl)
+		if updated || err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+	}
+
+	var deadlineRecheckAfter, agingRecheckAfter time.Duration
+	if features.Enabled(features.DeadlineAwareScheduling) {
+		deactivated, recheckAfter, err := r.reconcileCompletionDeadline(ctx, &wl)
+		if deactivated || err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		deadlineRecheckAfter = recheckAfter
+	}
+	if features.Enabled(features.PriorityAging) {
+		recheckAfter, err := r.reconcilePriorityAging(ctx, &wl, cq.Spec.PriorityAging)
+		if err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		agingRecheckAfter = recheckAfter
+	}
+
+	// Requeue after the earliest of the non-zero recheck times.
+	recheckAfter := min(deadlineRecheckAfter, agingRecheckAfter)
+	if recheckAfter == 0 {
+		recheckAfter = max(deadlineRecheckAfter, agingRecheckAfter)
+	}
+	return ctrl.Result{RequeueAfter: recheckAfter}, nil
+}
+
+// reconcileDependencies evaluates the dependencies of the pending workload
+// which are not satisfied yet. It sets the DependenciesSatisfied condition, or
+// finishes the workload if a dependency failed. The dependencies which don't
+// exist, for example because they were deleted, are pending. Returns whether
+// the workload was updated.
+func (r *WorkloadReconciler) reconcileDependencies(ctx context.Context, wl *kueue.Workload) (bool, error) {
+	if apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadDependenciesSatisfied) {
+		return false, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	var workloads kueue.WorkloadList
+	if err := r.client.List(ctx, &workloads, client.InNamespace(wl.Namespace)); err != nil {
+		return false, err
+	}
+	state, message, err := workload.EvaluateDependencies(wl, workloads.Items)
+	if err != nil {
+		return false, err
+	}
+
+	if state == workload.DependenciesFailed {
+		log.V(2).Info("Finishing the workload because of a failed dependency", "reason", message)
+		if err := workload.UpdateStatus(ctx, r.client, wl, kueue.WorkloadFinished, metav1.ConditionTrue, kueue.WorkloadFinishedReasonDependencyFailed, message, constants.WorkloadControllerName); err != nil {
+			return false, err
+		}
+		r.recorder.Event(wl, corev1.EventTypeWarning, kueue.WorkloadFinishedReasonDependencyFailed, message)
+		return true, nil
+	}
+	if !workload.SetDependenciesSatisfiedCondition(wl, state == workload.DependenciesSatisfied, message) {
+		return false, nil
+	}
+	log.V(3).Info("Updating the dependencies status", "satisfied", state == workload.DependenciesSatisfied)
+	return true, workload.ApplyAdmissionStatus(ctx, r.client, wl, true)
+}
+
+// notifyDependents queues the reconciliation of the workloads depending on
+// wl. It's called from the event handlers, so it doesn't block them when the
+// channel is full.
+func (r *WorkloadReconciler) notifyDependents(wl *kueue.Workload) {
+	e := event.GenericEvent{Object: wl}
+	select {
+	case r.dependencyUpdateCh <- e:
+	default:
+		go func() { r.dependencyUpdateCh <- e }()
+	}
+}
+
+// reconcileCompletionDeadline deactivates the pending workload if it can no
+// longer complete before its deadline, or returns when it needs to be
+// checked again.
+func (r *WorkloadReconciler) reconcileCompletionDeadline(ctx context.Context, wl *kueue.Workload) (bool, time.Duration, error) {
+	latestStart := workload.LatestStartTime(wl)
+	if latestStart == nil || !workload.IsActive(wl) || apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadDeactivationTarget) {
+		return false, 0, nil
+	}
+	if remaining := latestStart.Sub(r.clock.Now()); remaining > 0 {
+		return false, remaining, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Deactivating the workload which can no longer complete before its deadline", "completionDeadline", wl.Spec.CompletionDeadline)
+	workload.SetDeactivationTarget(wl, kueue.WorkloadDeadlineExceeded, "the completion deadline can no longer be met")
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true); err != nil {
+		return false, 0, err
+	}
+	r.recorder.Eventf(wl, corev1.EventTypeWarning, kueue.WorkloadDeadlineExceeded, "The workload can no longer complete before its deadline (%s)", wl.Spec.CompletionDeadline.UTC().Format(time.RFC3339))
+	return true, 0, nil
+}
+
+// reconcilePriorityAging updates the effective priority of the pending
+// workload according to the aging policy of its ClusterQueue, and returns
+// when it needs to be raised next.
+func (r *WorkloadReconciler) reconcilePriorityAging(ctx context.Context, wl *kueue.Workload, aging *kueue.PriorityAging) (time.Duration, error) {
+	var effective *int32
+	var requeueAfter time.Duration
+	if aging != nil {
+		p, next := priority.Aged(priority.Priority(wl), aging, r.clock.Since(workload.QueuedSince(wl)))
+		effective = &p
+		if next > 0 {
+			// Limit the status updates of the workloads aging linearly.
+			requeueAfter = max(next, minPriorityAgingRefresh)
+		}
+	}
+	if !ptr.Equal(wl.Status.EffectivePriority, effective) {
+		log := ctrl.LoggerFrom(ctx)
+		log.V(3).Info("Updating the effective priority", "effectivePriority", ptr.Deref(effective, priority.Priority(wl)))
+		wl.Status.EffectivePriority = effective
+		if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true); err != nil {
+			return 0, err
+		}
+	}
+	return requeueAfter, nil
 }
 
 // isDisabledRequeuedByClusterQueueStopped returns true if the workload is unset requeued by cluster queue stopped.
@@ -634,6 +777,11 @@ func (r *WorkloadReconciler) Delete(e event.DeleteEvent) bool {
 	log.V(2).Info("Workload delete event")
 	ctx := ctrl.LoggerInto(context.Background(), log)
 
+	if features.Enabled(features.WorkloadDependencies) && !workload.IsFinished(wl) {
+		// The dependents are evaluated again without the deleted workload.
+		r.notifyDependents(wl)
+	}
+
 	// When assigning a clusterQueue to a workload, we assume it in the cache. If
 	// the state is unknown, the workload could have been assumed, and we need
 	// to clear it from the cache.
@@ -668,6 +816,9 @@ func (r *WorkloadReconciler) Update(e event.UpdateEvent) bool {
 	defer r.notifyWatchers(oldWl, wl)
 
 	status := workload.Status(wl)
+	if features.Enabled(features.WorkloadDependencies) && status == workload.StatusFinished && workload.Status(oldWl) != workload.StatusFinished {
+		r.notifyDependents(wl)
+	}
 	log := r.log.WithValues("workload", klog.KObj(wl), "queue", wl.Spec.QueueName, "status", status)
 	ctx := ctrl.LoggerInto(context.Background(), log)
 	active := workload.IsActive(wl)
@@ -794,6 +945,7 @@ func (r *WorkloadReconciler) notifyWatchers(oldWl, newWl *kueue.Workload) {
 func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
 	ruh := &resourceUpdatesHandler{r: r}
 	wqh := &workloadQueueHandler{r: r}
+	dh := &dependentsHandler{r: r}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kueue.Workload{}).
 		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
@@ -801,6 +953,7 @@ func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Conf
 		Watches(&nodev1.RuntimeClass{}, ruh).
 		Watches(&kueue.ClusterQueue{}, wqh).
 		Watches(&kueue.LocalQueue{}, wqh).
+		WatchesRawSource(source.Channel(r.dependencyUpdateCh, dh)).
 		WithEventFilter(r).
 		Complete(WithLeadingManager(mgr, r, &kueue.Workload{}, cfg))
 }
@@ -933,7 +1086,8 @@ func (w *workloadQueueHandler) Update(ctx context.Context, ev event.UpdateEvent,
 		if !newCq.DeletionTimestamp.IsZero() ||
 			!utilslices.CmpNoOrder(oldCq.Spec.AdmissionChecks, newCq.Spec.AdmissionChecks) ||
 			!gocmp.Equal(oldCq.Spec.AdmissionChecksStrategy, newCq.Spec.AdmissionChecksStrategy) ||
-			!ptr.Equal(oldCq.Spec.StopPolicy, newCq.Spec.StopPolicy) {
+			!ptr.Equal(oldCq.Spec.StopPolicy, newCq.Spec.StopPolicy) ||
+			!gocmp.Equal(oldCq.Spec.PriorityAging, newCq.Spec.PriorityAging) {
 			w.queueReconcileForWorkloadsOfClusterQueue(ctx, newCq.Name, wq)
 		}
 		return
@@ -1004,3 +1158,52 @@ func (w *workloadQueueHandler) queueReconcileForWorkloadsOfLocalQueue(ctx contex
 		log.V(5).Info("Queued reconcile for workload")
 	}
 }
+
+// dependentsHandler queues the reconciliation of the pending workloads
+// depending on the workloads received through the dependencyUpdateCh, which
+// either finished or were deleted before finishing.
+type dependentsHandler struct {
+	r *WorkloadReconciler
+}
+
+var _ handler.EventHandler = (*dependentsHandler)(nil)
+
+// Create is called in response to a create event.
+func (h *dependentsHandler) Create(context.Context, event.CreateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// nothing to do here
+}
+
+// Update is called in response to an update event.
+func (h *dependentsHandler) Update(context.Context, event.UpdateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// nothing to do here
+}
+
+// Delete is called in response to a delete event.
+func (h *dependentsHandler) Delete(context.Context, event.DeleteEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// nothing to do here
+}
+
+// Generic is called in response to a workload finishing, or being deleted
+// before finishing.
+func (h *dependentsHandler) Generic(ctx context.Context, e event.GenericEvent, wq workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	dep, isWorkload := e.Object.(*kueue.Workload)
+	if !isWorkload {
+		return
+	}
+	log := ctrl.LoggerFrom(ctx).WithValues("dependency", klog.KObj(dep))
+	for _, value := range []string{dep.Name, indexer.WorkloadDependencySelector} {
+		lst := kueue.WorkloadList{}
+		if err := h.r.client.List(ctx, &lst, client.InNamespace(dep.Namespace), client.MatchingFields{indexer.WorkloadDependencyKey: value}); err != nil {
+			log.Error(err, "Could not list the dependent workloads")
+			return
+		}
+		for i := range lst.Items {
+			wl := &lst.Items[i]
+			if workload.HasQuotaReservation(wl) || !workload.DependsOn(wl, dep) {
+				continue
+			}
+			wq.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(wl)})
+			log.V(5).Info("Queued reconcile for dependent workload", "workload", klog.KObj(wl))
+		}
+	}
+}
@@ -30,14 +30,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
 	testingclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 )
@@ -325,6 +328,7 @@ func TestReconcile(t *testing.T) {
 
 	cases := map[string]struct {
 		workload       *kueue.Workload
+		otherWorkloads []*kueue.Workload
 		cq             *kueue.ClusterQueue
 		lq             *kueue.LocalQueue
 		wantWorkload   *kueue.Workload
@@ -332,6 +336,12 @@ func TestReconcile(t *testing.T) {
 		wantEvents     []utiltesting.EventRecord
 		wantResult     reconcile.Result
 		reconcilerOpts []Option
+		// enablePriorityAging enables the PriorityAging feature gate.
+		enablePriorityAging bool
+		// enableDeadlineAwareScheduling enables the DeadlineAwareScheduling feature gate.
+		enableDeadlineAwareScheduling bool
+		// enableWorkloadDependencies enables the WorkloadDependencies feature gate.
+		enableWorkloadDependencies bool
 	}{
 		"assign Admission Checks from ClusterQueue.spec.AdmissionCheckStrategy": {
 			workload: utiltesting.MakeWorkload("wl", "ns").
@@ -1458,6 +1468,351 @@ func TestReconcile(t *testing.T) {
 				Obj(),
 		},
 
+		"should raise the effective priority of a pending workload": {
+			lq: utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").PriorityAging(kueue.PriorityAgingStep, 10*time.Minute, 5, 12).Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Priority(100).
+				Creation(testStartTime.Add(-25 * time.Minute)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Priority(100).
+				Creation(testStartTime.Add(-25 * time.Minute)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				EffectivePriority(110).
+				Obj(),
+			wantResult:          reconcile.Result{RequeueAfter: 5 * time.Minute},
+			enablePriorityAging: true,
+		},
+		"should cap the effective priority of a pending workload": {
+			lq: utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").PriorityAging(kueue.PriorityAgingStep, 10*time.Minute, 5, 12).Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Priority(100).
+				Creation(testStartTime.Add(-2 * time.Hour)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				EffectivePriority(110).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Priority(100).
+				Creation(testStartTime.Add(-2 * time.Hour)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				EffectivePriority(112).
+				Obj(),
+			enablePriorityAging: true,
+		},
+		"should limit the updates of the effective priority of a workload aging linearly": {
+			lq: utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").PriorityAging(kueue.PriorityAgingLinear, 10*time.Minute, 50, 200).Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Priority(100).
+				Creation(testStartTime.Add(-25 * time.Minute)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Priority(100).
+				Creation(testStartTime.Add(-25 * time.Minute)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				EffectivePriority(225).
+				Obj(),
+			wantResult:          reconcile.Result{RequeueAfter: time.Minute},
+			enablePriorityAging: true,
+		},
+		"should keep the effective priority of an admitted workload": {
+			cq: utiltesting.MakeClusterQueue("cq").PriorityAging(kueue.PriorityAgingStep, 10*time.Minute, 5, 12).Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				Admitted(true).
+				Priority(100).
+				Creation(testStartTime.Add(-2 * time.Hour)).
+				EffectivePriority(105).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				Admitted(true).
+				Priority(100).
+				Creation(testStartTime.Add(-2 * time.Hour)).
+				EffectivePriority(105).
+				Obj(),
+			enablePriorityAging: true,
+		},
+		"should requeue a pending workload with a deadline at its latest start time": {
+			lq: utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").QueueingStrategy(kueue.EarliestDeadlineFirst).Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				MaximumExecutionTimeSeconds(600).
+				CompletionDeadline(testStartTime.Add(time.Hour)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				MaximumExecutionTimeSeconds(600).
+				CompletionDeadline(testStartTime.Add(time.Hour)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Obj(),
+			wantResult:                    reconcile.Result{RequeueAfter: 50 * time.Minute},
+			enableDeadlineAwareScheduling: true,
+		},
+		"should deactivate a pending workload which can no longer meet its deadline": {
+			lq: utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").QueueingStrategy(kueue.EarliestDeadlineFirst).Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				MaximumExecutionTimeSeconds(600).
+				CompletionDeadline(testStartTime.Add(5 * time.Minute)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				MaximumExecutionTimeSeconds(600).
+				CompletionDeadline(testStartTime.Add(5 * time.Minute)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadDeactivationTarget,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadDeadlineExceeded,
+					Message: "the completion deadline can no longer be met",
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Warning",
+					Reason:    "DeadlineExceeded",
+					Message:   "The workload can no longer complete before its deadline (" + testStartTime.Add(5*time.Minute).UTC().Format(time.RFC3339) + ")",
+				},
+			},
+			enableDeadlineAwareScheduling: true,
+		},
+		"should not deactivate a pending workload past its deadline when the feature is disabled": {
+			lq: utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").QueueingStrategy(kueue.EarliestDeadlineFirst).Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				CompletionDeadline(testStartTime.Add(-time.Minute)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				CompletionDeadline(testStartTime.Add(-time.Minute)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Obj(),
+		},
+		"should keep a pending workload with unfinished dependencies inadmissible": {
+			lq:             utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			cq:             utiltesting.MakeClusterQueue("cq").Obj(),
+			otherWorkloads: []*kueue.Workload{utiltesting.MakeWorkload("prepare", "ns").Queue("lq").Obj()},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Dependencies(kueue.WorkloadDependency{Name: ptr.To("prepare"), Condition: kueue.WorkloadDependencySucceeded}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Dependencies(kueue.WorkloadDependency{Name: ptr.To("prepare"), Condition: kueue.WorkloadDependencySucceeded}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadDependenciesSatisfied,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadDependenciesReasonPending,
+					Message: `Waiting for the dependency "prepare" to finish`,
+				}).
+				Obj(),
+			enableWorkloadDependencies: true,
+		},
+		"should mark the dependencies of a pending workload as satisfied": {
+			lq: utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").Obj(),
+			otherWorkloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("prepare", "ns").
+					Queue("lq").
+					Condition(metav1.Condition{
+						Type:   kueue.WorkloadFinished,
+						Status: metav1.ConditionTrue,
+						Reason: kueue.WorkloadFinishedReasonSucceeded,
+					}).
+					Obj(),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Dependencies(kueue.WorkloadDependency{Name: ptr.To("prepare"), Condition: kueue.WorkloadDependencySucceeded}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Dependencies(kueue.WorkloadDependency{Name: ptr.To("prepare"), Condition: kueue.WorkloadDependencySucceeded}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadDependenciesSatisfied,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadDependenciesReasonSatisfied,
+					Message: "All the dependencies are satisfied",
+				}).
+				Obj(),
+			enableWorkloadDependencies: true,
+		},
+		"should finish a pending workload whose dependency failed": {
+			lq: utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").Obj(),
+			otherWorkloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("prepare", "ns").
+					Queue("lq").
+					Condition(metav1.Condition{
+						Type:   kueue.WorkloadFinished,
+						Status: metav1.ConditionTrue,
+						Reason: kueue.WorkloadFinishedReasonFailed,
+					}).
+					Obj(),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Dependencies(kueue.WorkloadDependency{Name: ptr.To("prepare"), Condition: kueue.WorkloadDependencySucceeded}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Dependencies(kueue.WorkloadDependency{Name: ptr.To("prepare"), Condition: kueue.WorkloadDependencySucceeded}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadFinished,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadFinishedReasonDependencyFailed,
+					Message: `The dependency "prepare" finished with the Failed reason`,
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Warning",
+					Reason:    "DependencyFailed",
+					Message:   `The dependency "prepare" finished with the Failed reason`,
+				},
+			},
+			enableWorkloadDependencies: true,
+		},
+		"should ignore the dependencies of a pending workload when the feature is disabled": {
+			lq: utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Dependencies(kueue.WorkloadDependency{Name: ptr.To("prepare"), Condition: kueue.WorkloadDependencySucceeded}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Dependencies(kueue.WorkloadDependency{Name: ptr.To("prepare"), Condition: kueue.WorkloadDependencySucceeded}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Obj(),
+		},
+
 		"admitted workload with max execution time": {
 			workload: utiltesting.MakeWorkload("wl", "ns").
 				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
@@ -1505,7 +1860,13 @@ func TestReconcile(t *testing.T) {
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PriorityAging, tc.enablePriorityAging)
+			features.SetFeatureGateDuringTest(t, features.DeadlineAwareScheduling, tc.enableDeadlineAwareScheduling)
+			features.SetFeatureGateDuringTest(t, features.WorkloadDependencies, tc.enableWorkloadDependencies)
 			objs := []client.Object{tc.workload}
+			for _, wl := range tc.otherWorkloads {
+				objs = append(objs, wl)
+			}
 			clientBuilder := utiltesting.NewClientBuilder().WithObjects(objs...).WithStatusSubresource(objs...).WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge})
 			cl := clientBuilder.Build()
 			recorder := &utiltesting.EventRecorder{}
@@ -1564,3 +1925,127 @@ func TestReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestDeletedDependency(t *testing.T) {
+	lqMissing := metav1.Condition{
+		Type:    kueue.WorkloadQuotaReserved,
+		Status:  metav1.ConditionFalse,
+		Reason:  kueue.WorkloadInadmissible,
+		Message: "LocalQueue lq doesn't exist",
+	}
+	finishedSucceeded := metav1.Condition{
+		Type:   kueue.WorkloadFinished,
+		Status: metav1.ConditionTrue,
+		Reason: kueue.WorkloadFinishedReasonSucceeded,
+	}
+	byName := kueue.WorkloadDependency{Name: ptr.To("prepare"), Condition: kueue.WorkloadDependencySucceeded}
+	bySelector := kueue.WorkloadDependency{
+		Selector:  &metav1.LabelSelector{MatchLabels: map[string]string{"stage": "prepare"}},
+		Condition: kueue.WorkloadDependencySucceeded,
+	}
+	cases := map[string]struct {
+		deleted        *kueue.Workload
+		otherWorkloads []*kueue.Workload
+		dependency     kueue.WorkloadDependency
+		wantCondition  metav1.Condition
+	}{
+		"dependency deleted before finishing": {
+			deleted:    utiltesting.MakeWorkload("prepare", "ns").Queue("lq").Obj(),
+			dependency: byName,
+			wantCondition: metav1.Condition{
+				Type:    kueue.WorkloadDependenciesSatisfied,
+				Status:  metav1.ConditionFalse,
+				Reason:  kueue.WorkloadDependenciesReasonPending,
+				Message: `Waiting for the dependency "prepare" to be created`,
+			},
+		},
+		"dependency recreated with the same name": {
+			deleted: utiltesting.MakeWorkload("prepare", "ns").Queue("lq").Obj(),
+			otherWorkloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("prepare", "ns").Queue("lq").Obj(),
+			},
+			dependency: byName,
+			wantCondition: metav1.Condition{
+				Type:    kueue.WorkloadDependenciesSatisfied,
+				Status:  metav1.ConditionFalse,
+				Reason:  kueue.WorkloadDependenciesReasonPending,
+				Message: `Waiting for the dependency "prepare" to finish`,
+			},
+		},
+		"one of the dependencies matching the selector deleted": {
+			deleted: utiltesting.MakeWorkload("prepare-1", "ns").Queue("lq").Label("stage", "prepare").Obj(),
+			otherWorkloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("prepare-2", "ns").
+					Queue("lq").
+					Label("stage", "prepare").
+					Condition(finishedSucceeded).
+					Obj(),
+			},
+			dependency: bySelector,
+			wantCondition: metav1.Condition{
+				Type:    kueue.WorkloadDependenciesSatisfied,
+				Status:  metav1.ConditionTrue,
+				Reason:  kueue.WorkloadDependenciesReasonSatisfied,
+				Message: "All the dependencies are satisfied",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.WorkloadDependencies, true)
+			ctx, _ := utiltesting.ContextWithLog(t)
+
+			dependent := utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Dependencies(tc.dependency).
+				Condition(lqMissing).
+				Obj()
+			unrelated := utiltesting.MakeWorkload("unrelated", "ns").
+				Queue("lq").
+				Dependencies(kueue.WorkloadDependency{Name: ptr.To("other"), Condition: kueue.WorkloadDependencySucceeded}).
+				Obj()
+			objs := []client.Object{dependent, unrelated}
+			for _, wl := range tc.otherWorkloads {
+				objs = append(objs, wl)
+			}
+			cl := utiltesting.NewClientBuilder().WithObjects(objs...).WithStatusSubresource(objs...).WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).Build()
+			recorder := &utiltesting.EventRecorder{}
+			cqCache := cache.New(cl)
+			qManager := queue.NewManager(cl, cqCache)
+			reconciler := NewWorkloadReconciler(cl, qManager, cqCache, recorder)
+
+			reconciler.Delete(event.DeleteEvent{Object: tc.deleted})
+			wq := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			defer wq.ShutDown()
+			(&dependentsHandler{r: reconciler}).Generic(ctx, <-reconciler.dependencyUpdateCh, wq)
+
+			if wq.Len() != 1 {
+				t.Fatalf("Unexpected number of queued reconciles, want=1, got=%d", wq.Len())
+			}
+			req, _ := wq.Get()
+			if diff := cmp.Diff(client.ObjectKeyFromObject(dependent), req.NamespacedName); diff != "" {
+				t.Errorf("Unexpected queued reconcile (-want,+got):\n%s", diff)
+			}
+
+			if _, err := reconciler.Reconcile(ctx, req); err != nil {
+				t.Fatalf("Unexpected reconcile error: %v", err)
+			}
+			gotWorkload := &kueue.Workload{}
+			if err := cl.Get(ctx, req.NamespacedName, gotWorkload); err != nil {
+				t.Fatalf("Could not get the workload after reconcile: %v", err)
+			}
+			wantWorkload := utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Dependencies(tc.dependency).
+				Condition(lqMissing).
+				Condition(tc.wantCondition).
+				Obj()
+			if diff := cmp.Diff(wantWorkload, gotWorkload, workloadCmpOpts...); diff != "" {
+				t.Errorf("Workload after reconcile (-want,+got):\n%s", diff)
+			}
+			if len(recorder.RecordedEvents) != 0 {
+				t.Errorf("Unexpected events: %v", recorder.RecordedEvents)
+			}
+		})
+	}
+}
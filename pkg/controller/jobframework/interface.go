@@ -19,6 +19,7 @@ package jobframework
 import (
 	"context"
 	"strconv"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -170,6 +171,24 @@ func MaximumExecutionTimeSeconds(job GenericJob) *int32 {
 	return ptr.To(int32(v))
 }
 
+// CompletionDeadline returns the completion deadline of the job, set by the
+// completion deadline annotation, or nil if it's not set or invalid.
+func CompletionDeadline(job GenericJob) *metav1.Time {
+	strVal, found := job.Object().GetAnnotations()[constants.CompletionDeadlineAnnotation]
+	if !found {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, strVal)
+	if err != nil {
+		return nil
+	}
+
+	// metav1.Time is serialized with second precision, drop the fraction so
+	// that the value survives a round trip through the API server.
+	return &metav1.Time{Time: t.Truncate(time.Second)}
+}
+
 func workloadPriorityClassName(job GenericJob) string {
 	object := job.Object()
 	if workloadPriorityClassLabel := object.GetLabels()[constants.WorkloadPriorityClassLabel]; workloadPriorityClassLabel != "" {
@@ -807,6 +807,10 @@ func equivalentToWorkload(ctx context.Context, c client.Client, job GenericJob,
 		return false
 	}
 
+	if !wl.Spec.CompletionDeadline.Equal(CompletionDeadline(job)) {
+		return false
+	}
+
 	jobPodSets := clearMinCountsIfFeatureDisabled(job.PodSets())
 
 	if runningPodSets := expectedRunningPodSets(ctx, c, wl); runningPodSets != nil {
@@ -949,11 +953,15 @@ func (r *JobReconciler) constructWorkload(ctx context.Context, job GenericJob, o
 			PodSets:                     podSets,
 			QueueName:                   QueueName(job),
 			MaximumExecutionTimeSeconds: MaximumExecutionTimeSeconds(job),
+			CompletionDeadline:          CompletionDeadline(job),
 		},
 	}
 	if wl.Labels == nil {
 		wl.Labels = make(map[string]string)
 	}
+	if reservation, found := job.Object().GetLabels()[kueuealpha.ReservationLabel]; found {
+		wl.Labels[kueuealpha.ReservationLabel] = reservation
+	}
 	jobUID := string(job.Object().GetUID())
 	if errs := validation.IsValidLabelValue(jobUID); len(errs) == 0 {
 		wl.Labels[controllerconsts.JobUIDLabel] = jobUID
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	kfmpi "github.com/kubeflow/mpi-operator/pkg/apis/kubeflow/v2beta1"
 	kftraining "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
@@ -39,6 +40,7 @@ var (
 	labelsPath                    = field.NewPath("metadata", "labels")
 	queueNameLabelPath            = labelsPath.Key(constants.QueueLabel)
 	maxExecTimeLabelPath          = labelsPath.Key(constants.MaxExecTimeSecondsLabel)
+	completionDeadlinePath        = annotationsPath.Key(constants.CompletionDeadlineAnnotation)
 	workloadPriorityClassNamePath = labelsPath.Key(constants.WorkloadPriorityClassLabel)
 	supportedPrebuiltWlJobGVKs    = sets.New(
 		batchv1.SchemeGroupVersion.WithKind("Job").String(),
@@ -54,6 +56,7 @@ var (
 func ValidateJobOnCreate(job GenericJob) field.ErrorList {
 	allErrs := validateCreateForQueueName(job)
 	allErrs = append(allErrs, validateCreateForMaxExecTime(job)...)
+	allErrs = append(allErrs, validateCreateForCompletionDeadline(job)...)
 	return allErrs
 }
 
@@ -62,6 +65,7 @@ func ValidateJobOnUpdate(oldJob, newJob GenericJob) field.ErrorList {
 	allErrs := validateUpdateForQueueName(oldJob, newJob)
 	allErrs = append(allErrs, validateUpdateForWorkloadPriorityClassName(oldJob, newJob)...)
 	allErrs = append(allErrs, validateUpdateForMaxExecTime(oldJob, newJob)...)
+	allErrs = append(allErrs, validateUpdateForCompletionDeadline(oldJob, newJob)...)
 	return allErrs
 }
 
@@ -144,3 +148,19 @@ func validateUpdateForMaxExecTime(oldJob, newJob GenericJob) field.ErrorList {
 	}
 	return nil
 }
+
+func validateCreateForCompletionDeadline(job GenericJob) field.ErrorList {
+	if strVal, found := job.Object().GetAnnotations()[constants.CompletionDeadlineAnnotation]; found {
+		if _, err := time.Parse(time.RFC3339, strVal); err != nil {
+			return field.ErrorList{field.Invalid(completionDeadlinePath, strVal, "should be a time in RFC 3339 format")}
+		}
+	}
+	return nil
+}
+
+func validateUpdateForCompletionDeadline(oldJob, newJob GenericJob) field.ErrorList {
+	if !newJob.IsSuspended() || !oldJob.IsSuspended() {
+		return apivalidation.ValidateImmutableField(newJob.Object().GetAnnotations()[constants.CompletionDeadlineAnnotation], oldJob.Object().GetAnnotations()[constants.CompletionDeadlineAnnotation], completionDeadlinePath)
+	}
+	return validateCreateForCompletionDeadline(newJob)
+}
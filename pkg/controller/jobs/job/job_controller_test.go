@@ -2876,6 +2876,34 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"the workload is kept when the completion deadline has fractional seconds": {
+			job: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.CompletionDeadlineAnnotation, "2024-12-02T10:00:00.5Z").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.CompletionDeadlineAnnotation, "2024-12-02T10:00:00.5Z").
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					CompletionDeadline(time.Date(2024, 12, 2, 10, 0, 0, 0, time.UTC)).
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("foo").
+					Priority(0).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: string(baseJobWrapper.GetUID())}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					CompletionDeadline(time.Date(2024, 12, 2, 10, 0, 0, 0, time.UTC)).
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("foo").
+					Priority(0).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: string(baseJobWrapper.GetUID())}).
+					Obj(),
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
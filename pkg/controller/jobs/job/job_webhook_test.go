@@ -54,6 +54,7 @@ var (
 	queueNameLabelPath            = labelsPath.Key(constants.QueueLabel)
 	prebuiltWlNameLabelPath       = labelsPath.Key(constants.PrebuiltWorkloadLabel)
 	maxExecTimeLabelPath          = labelsPath.Key(constants.MaxExecTimeSecondsLabel)
+	completionDeadlinePath        = annotationsPath.Key(constants.CompletionDeadlineAnnotation)
 	queueNameAnnotationsPath      = annotationsPath.Key(constants.QueueAnnotation)
 	workloadPriorityClassNamePath = labelsPath.Key(constants.WorkloadPriorityClassLabel)
 )
@@ -234,6 +235,21 @@ func TestValidateCreate(t *testing.T) {
 				Indexed(true).
 				Obj(),
 		},
+		{
+			name: "invalid completion deadline",
+			job: testingutil.MakeJob("job", "default").
+				SetAnnotation(constants.CompletionDeadlineAnnotation, "tomorrow").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(completionDeadlinePath, "tomorrow", "should be a time in RFC 3339 format"),
+			},
+		},
+		{
+			name: "valid completion deadline",
+			job: testingutil.MakeJob("job", "default").
+				SetAnnotation(constants.CompletionDeadlineAnnotation, "2024-12-02T10:00:00Z").
+				Obj(),
+		},
 		{
 			name: "valid topology request",
 			job: testingutil.MakeJob("job", "default").
@@ -510,6 +526,29 @@ func TestValidateUpdate(t *testing.T) {
 				Label(constants.MaxExecTimeSecondsLabel, "20").
 				Obj(),
 		},
+		{
+			name: "immutable completion deadline while unsuspended",
+			oldJob: testingutil.MakeJob("job", "default").
+				Suspend(false).
+				SetAnnotation(constants.CompletionDeadlineAnnotation, "2024-12-02T10:00:00Z").
+				Obj(),
+			newJob: testingutil.MakeJob("job", "default").
+				Suspend(false).
+				SetAnnotation(constants.CompletionDeadlineAnnotation, "2024-12-02T12:00:00Z").
+				Obj(),
+			wantErr: apivalidation.ValidateImmutableField("2024-12-02T12:00:00Z", "2024-12-02T10:00:00Z", completionDeadlinePath),
+		},
+		{
+			name: "mutable completion deadline while suspended",
+			oldJob: testingutil.MakeJob("job", "default").
+				Suspend(true).
+				SetAnnotation(constants.CompletionDeadlineAnnotation, "2024-12-02T10:00:00Z").
+				Obj(),
+			newJob: testingutil.MakeJob("job", "default").
+				Suspend(true).
+				SetAnnotation(constants.CompletionDeadlineAnnotation, "2024-12-02T12:00:00Z").
+				Obj(),
+		},
 		{
 			name: "set valid TAS request",
 			oldJob: testingutil.MakeJob("job", "default").
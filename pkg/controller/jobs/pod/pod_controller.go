@@ -969,6 +969,7 @@ func (p *Pod) ConstructComposableWorkload(ctx context.Context, c client.Client,
 		Spec: kueue.WorkloadSpec{
 			QueueName:                   jobframework.QueueName(p),
 			MaximumExecutionTimeSeconds: jobframework.MaximumExecutionTimeSeconds(p),
+			CompletionDeadline:          jobframework.CompletionDeadline(p),
 		},
 	}
 
@@ -1111,6 +1112,10 @@ func (p *Pod) FindMatchingWorkloads(ctx context.Context, c client.Client, r reco
 		return nil, []*kueue.Workload{workload}, nil
 	}
 
+	if !workload.Spec.CompletionDeadline.Equal(jobframework.CompletionDeadline(p)) {
+		return nil, []*kueue.Workload{workload}, nil
+	}
+
 	// Cleanup excess pods for each workload pod set (role)
 	activePods := p.runnableOrSucceededPods()
 	inactivePods := p.notRunnableNorSucceededPods()
@@ -435,6 +435,7 @@ func readRanksForLabels(
 
 func isAdmittedByTAS(w *kueue.Workload) bool {
 	return w.Status.Admission != nil && workload.IsAdmitted(w) &&
+		!workload.HasTopologyAssignmentsPending(w) &&
 		slices.ContainsFunc(w.Status.Admission.PodSetAssignments,
 			func(psa kueue.PodSetAssignment) bool {
 				return psa.TopologyAssignment != nil
@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugger
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// HandlerPath is the path of the metrics server on which the dumps are
+// served.
+const HandlerPath = "/debug/kueue/dump"
+
+// Dump is the state of the ClusterQueues and Cohorts in the cache and the
+// queues.
+type Dump struct {
+	ClusterQueues []ClusterQueueDump `json:"clusterQueues"`
+	Cohorts       []CohortDump       `json:"cohorts"`
+}
+
+// ClusterQueueDump is the state of a ClusterQueue.
+type ClusterQueueDump struct {
+	Name string `json:"name"`
+	// Active is false when the ClusterQueue is inactive or its Cohort is
+	// part of a cycle, in which case it can't admit workloads.
+	Active bool `json:"active"`
+	queue.PendingWorkloadsDump
+	// Resources are the quotas and usage of the ClusterQueue. They are
+	// omitted for the ClusterQueues which aren't active.
+	Resources []ResourceDump `json:"resources,omitempty"`
+	// Workloads are the workloads holding quota in the ClusterQueue.
+	Workloads []string `json:"workloads,omitempty"`
+}
+
+// CohortDump is the state of a Cohort.
+type CohortDump struct {
+	Name      string         `json:"name"`
+	Parent    string         `json:"parent,omitempty"`
+	Resources []ResourceDump `json:"resources"`
+}
+
+// ResourceDump is the quota and usage of a resource of a flavor.
+type ResourceDump struct {
+	Flavor         kueue.ResourceFlavorReference `json:"flavor"`
+	Resource       corev1.ResourceName           `json:"resource"`
+	Nominal        resource.Quantity             `json:"nominal"`
+	BorrowingLimit *resource.Quantity            `json:"borrowingLimit,omitempty"`
+	LendingLimit   *resource.Quantity            `json:"lendingLimit,omitempty"`
+	// SubtreeQuota is the nominal quota, plus the quota lent by the children
+	// for Cohorts.
+	SubtreeQuota resource.Quantity `json:"subtreeQuota"`
+	// Usage is the usage of the ClusterQueue or, for Cohorts, the usage of
+	// the children in excess of the quota they don't lend.
+	Usage resource.Quantity `json:"usage"`
+}
+
+// Filter limits a dump to some ClusterQueues and Cohorts.
+type Filter struct {
+	// ClusterQueue limits the dump to the ClusterQueue and its ancestor
+	// Cohorts.
+	ClusterQueue string
+	// Cohort limits the dump to the tree of ClusterQueues and Cohorts rooted
+	// at the Cohort.
+	Cohort string
+}
+
+// Dump returns the state of the ClusterQueues and Cohorts selected by the
+// filter.
+func (d *Dumper) Dump(ctx context.Context, f Filter) (*Dump, error) {
+	snap, err := d.cache.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pending := d.queues.DumpPendingWorkloads()
+	// ancestors returns the names of the cohort and of its ancestors.
+	ancestors := func(cohort string) sets.Set[string] {
+		result := sets.New[string]()
+		for cohort != "" && !result.Has(cohort) {
+			result.Insert(cohort)
+			c := snap.Cohorts[cohort]
+			if c == nil || !c.HasParent() {
+				break
+			}
+			cohort = c.Parent().Name
+		}
+		return result
+	}
+
+	dump := &Dump{
+		ClusterQueues: []ClusterQueueDump{},
+		Cohorts:       []CohortDump{},
+	}
+	filterCohorts := sets.New[string]()
+	names := sets.KeySet(pending).Union(sets.KeySet(snap.ClusterQueues))
+	for _, name := range sets.List(names) {
+		cqDump := ClusterQueueDump{
+			Name:                 name,
+			PendingWorkloadsDump: pending[name],
+		}
+		if cq := snap.ClusterQueues[name]; cq != nil {
+			cqDump.Active = true
+			if cq.HasParent() {
+				cqDump.Cohort = cq.Parent().Name
+			}
+			cqDump.Resources = resourcesDump(&cq.ResourceNode)
+			cqDump.Workloads = slices.Sorted(maps.Keys(cq.Workloads))
+		}
+		cohorts := ancestors(cqDump.Cohort)
+		if name == f.ClusterQueue {
+			filterCohorts = cohorts
+		}
+		if (f.ClusterQueue != "" && name != f.ClusterQueue) || (f.Cohort != "" && !cohorts.Has(f.Cohort)) {
+			continue
+		}
+		dump.ClusterQueues = append(dump.ClusterQueues, cqDump)
+	}
+	for _, name := range slices.Sorted(maps.Keys(snap.Cohorts)) {
+		if (f.ClusterQueue != "" && !filterCohorts.Has(name)) || (f.Cohort != "" && !ancestors(name).Has(f.Cohort)) {
+			continue
+		}
+		cohort := snap.Cohorts[name]
+		cohortDump := CohortDump{
+			Name:      name,
+			Resources: resourcesDump(&cohort.ResourceNode),
+		}
+		if cohort.HasParent() {
+			cohortDump.Parent = cohort.Parent().Name
+		}
+		dump.Cohorts = append(dump.Cohorts, cohortDump)
+	}
+	return dump, nil
+}
+
+func resourcesDump(node *cache.ResourceNode) []ResourceDump {
+	frs := sets.KeySet(node.Quotas).Union(sets.KeySet(node.SubtreeQuota)).Union(sets.KeySet(node.Usage))
+	result := make([]ResourceDump, 0, len(frs))
+	for fr := range frs {
+		quota := node.Quotas[fr]
+		r := ResourceDump{
+			Flavor:       fr.Flavor,
+			Resource:     fr.Resource,
+			Nominal:      resources.ResourceQuantity(fr.Resource, quota.Nominal),
+			SubtreeQuota: resources.ResourceQuantity(fr.Resource, node.SubtreeQuota[fr]),
+			Usage:        resources.ResourceQuantity(fr.Resource, node.Usage[fr]),
+		}
+		if quota.BorrowingLimit != nil {
+			r.BorrowingLimit = ptr.To(resources.ResourceQuantity(fr.Resource, *quota.BorrowingLimit))
+		}
+		if quota.LendingLimit != nil {
+			r.LendingLimit = ptr.To(resources.ResourceQuantity(fr.Resource, *quota.LendingLimit))
+		}
+		result = append(result, r)
+	}
+	slices.SortFunc(result, func(a, b ResourceDump) int {
+		return cmp.Or(cmp.Compare(a.Flavor, b.Flavor), cmp.Compare(a.Resource, b.Resource))
+	})
+	return result
+}
+
+// ServeHTTP writes the dump as JSON. The clusterQueue and cohort query
+// parameters filter the dump, as described in Filter.
+func (d *Dumper) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	dump, err := d.Dump(req.Context(), Filter{
+		ClusterQueue: req.URL.Query().Get("clusterQueue"),
+		Cohort:       req.URL.Query().Get("cohort"),
+	})
+	if err != nil {
+		ctrl.LoggerFrom(req.Context()).Error(err, "Unable to dump the cache and queues")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(dump); err != nil {
+		ctrl.LoggerFrom(req.Context()).Error(err, "Unable to write the dump")
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestServeHTTP(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cl := utiltesting.NewFakeClient()
+	cCache := cache.New(cl)
+	queues := queue.NewManager(cl, cCache)
+
+	cCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	for _, cohort := range []*utiltesting.CohortWrapper{
+		utiltesting.MakeCohort("root"),
+		utiltesting.MakeCohort("team").Parent("root"),
+	} {
+		if err := cCache.AddOrUpdateCohort(cohort.Obj()); err != nil {
+			t.Fatalf("Failed adding cohort: %v", err)
+		}
+		queues.AddOrUpdateCohort(ctx, cohort.Obj())
+	}
+	for _, cq := range []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq-a").
+			Cohort("team").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4", "2").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("cq-b").
+			Cohort("other").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("cq-c").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("missing").Resource(corev1.ResourceCPU, "2").Obj()).
+			Obj(),
+	} {
+		if err := cCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Failed adding clusterQueue %s: %v", cq.Name, err)
+		}
+		if err := queues.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Failed adding clusterQueue %s: %v", cq.Name, err)
+		}
+	}
+	for _, lq := range []*kueue.LocalQueue{
+		utiltesting.MakeLocalQueue("lq-a", "ns").ClusterQueue("cq-a").Obj(),
+		utiltesting.MakeLocalQueue("lq-c", "ns").ClusterQueue("cq-c").Obj(),
+	} {
+		if err := queues.AddLocalQueue(ctx, lq); err != nil {
+			t.Fatalf("Failed adding queue %s: %v", lq.Name, err)
+		}
+	}
+	cCache.AddOrUpdateWorkload(utiltesting.MakeWorkload("admitted", "ns").
+		Request(corev1.ResourceCPU, "1").
+		ReserveQuota(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+		Obj())
+	for _, wl := range []*kueue.Workload{
+		utiltesting.MakeWorkload("pending", "ns").Queue("lq-a").Request(corev1.ResourceCPU, "1").Obj(),
+		utiltesting.MakeWorkload("blocked", "ns").Queue("lq-c").Request(corev1.ResourceCPU, "1").Obj(),
+	} {
+		if err := queues.AddOrUpdateWorkload(wl); err != nil {
+			t.Fatalf("Failed adding workload %s: %v", wl.Name, err)
+		}
+	}
+
+	cpuResource := func(nominal, subtreeQuota, usage string) ResourceDump {
+		return ResourceDump{
+			Flavor:       "default",
+			Resource:     corev1.ResourceCPU,
+			Nominal:      resource.MustParse(nominal),
+			SubtreeQuota: resource.MustParse(subtreeQuota),
+			Usage:        resource.MustParse(usage),
+		}
+	}
+	cqA := ClusterQueueDump{
+		Name:   "cq-a",
+		Active: true,
+		PendingWorkloadsDump: queue.PendingWorkloadsDump{
+			Cohort:       "team",
+			Pending:      []queue.WorkloadDump{{Name: "ns/pending", LocalQueue: "lq-a"}},
+			Inadmissible: []queue.WorkloadDump{},
+		},
+		Resources: []ResourceDump{{
+			Flavor:         "default",
+			Resource:       corev1.ResourceCPU,
+			Nominal:        resource.MustParse("4"),
+			BorrowingLimit: ptr.To(resource.MustParse("2")),
+			SubtreeQuota:   resource.MustParse("4"),
+			Usage:          resource.MustParse("1"),
+		}},
+		Workloads: []string{"ns/admitted"},
+	}
+	cqB := ClusterQueueDump{
+		Name:   "cq-b",
+		Active: true,
+		PendingWorkloadsDump: queue.PendingWorkloadsDump{
+			Cohort:       "other",
+			Pending:      []queue.WorkloadDump{},
+			Inadmissible: []queue.WorkloadDump{},
+		},
+		Resources: []ResourceDump{cpuResource("2", "2", "0")},
+	}
+	cqC := ClusterQueueDump{
+		Name: "cq-c",
+		PendingWorkloadsDump: queue.PendingWorkloadsDump{
+			Pending:      []queue.WorkloadDump{{Name: "ns/blocked", LocalQueue: "lq-c"}},
+			Inadmissible: []queue.WorkloadDump{},
+		},
+	}
+	other := CohortDump{Name: "other", Resources: []ResourceDump{cpuResource("0", "2", "0")}}
+	root := CohortDump{Name: "root", Resources: []ResourceDump{cpuResource("0", "4", "1")}}
+	team := CohortDump{Name: "team", Parent: "root", Resources: []ResourceDump{cpuResource("0", "4", "1")}}
+
+	cases := map[string]struct {
+		query string
+		want  Dump
+	}{
+		"no filter": {
+			want: Dump{
+				ClusterQueues: []ClusterQueueDump{cqA, cqB, cqC},
+				Cohorts:       []CohortDump{other, root, team},
+			},
+		},
+		"ClusterQueue": {
+			query: "?clusterQueue=cq-a",
+			want: Dump{
+				ClusterQueues: []ClusterQueueDump{cqA},
+				Cohorts:       []CohortDump{root, team},
+			},
+		},
+		"root Cohort": {
+			query: "?cohort=root",
+			want: Dump{
+				ClusterQueues: []ClusterQueueDump{cqA},
+				Cohorts:       []CohortDump{root, team},
+			},
+		},
+		"child Cohort": {
+			query: "?cohort=team",
+			want: Dump{
+				ClusterQueues: []ClusterQueueDump{cqA},
+				Cohorts:       []CohortDump{team},
+			},
+		},
+		"ClusterQueue outside of the Cohort": {
+			query: "?clusterQueue=cq-b&cohort=team",
+			want: Dump{
+				ClusterQueues: []ClusterQueueDump{},
+				Cohorts:       []CohortDump{},
+			},
+		},
+		"unknown ClusterQueue": {
+			query: "?clusterQueue=cq-d",
+			want: Dump{
+				ClusterQueues: []ClusterQueueDump{},
+				Cohorts:       []CohortDump{},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewDumper(cCache, queues).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, HandlerPath+tc.query, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Unexpected status code %d: %s", rec.Code, rec.Body.String())
+			}
+			var got Dump
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("Failed decoding the dump: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected dump (-want,+got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("method not allowed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewDumper(cCache, queues).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, HandlerPath, nil))
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("Unexpected status code %d", rec.Code)
+		}
+	})
+}
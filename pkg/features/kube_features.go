@@ -169,6 +169,87 @@ const (
 	//
 	// Enable to set default LocalQueue.
 	LocalQueueDefaulting featuregate.Feature = "LocalQueueDefaulting"
+
+	// owner: @troychiu
+	// alpha: v0.11
+	//
+	// Record the structured details of the last scheduling attempt of pending
+	// Workloads in Workload.Status.lastSchedulingAttempt.
+	WorkloadSchedulingAttemptDetails featuregate.Feature = "WorkloadSchedulingAttemptDetails"
+
+	// owner: @troychiu
+	// alpha: v0.11
+	//
+	// Order the pending workloads across queues by their decayed historical
+	// resource consumption.
+	AdmissionFairSharing featuregate.Feature = "AdmissionFairSharing"
+
+	// owner: @troychiu
+	// alpha: v0.11
+	//
+	// Enables backfilling the StrictFIFO ClusterQueues with the workloads
+	// expected to finish before the blocked head could be admitted.
+	StrictFIFOBackfill featuregate.Feature = "StrictFIFOBackfill"
+
+	// owner: @troychiu
+	// alpha: v0.11
+	//
+	// Enables the Reservation API, which holds a slice of the quota of a
+	// ClusterQueue or Cohort for the workloads referencing it during a window.
+	AdvanceReservations featuregate.Feature = "AdvanceReservations"
+
+	// owner: @troychiu
+	// alpha: v0.11
+	//
+	// Enables the QuotaSchedule API, which changes the quota of a
+	// ClusterQueue during recurring time windows.
+	QuotaSchedules featuregate.Feature = "QuotaSchedules"
+
+	// owner: @troychiu
+	// alpha: v0.11
+	//
+	// Enables the priority aging of the pending workloads, configured per
+	// ClusterQueue.
+	PriorityAging featuregate.Feature = "PriorityAging"
+
+	// owner: @troychiu
+	// alpha: v0.11
+	//
+	// Enables the completion deadline of the workloads and the
+	// EarliestDeadlineFirst queueing strategy.
+	DeadlineAwareScheduling featuregate.Feature = "DeadlineAwareScheduling"
+
+	// owner: @troychiu
+	// alpha: v0.11
+	//
+	// Enables ordering the pending workloads of a ClusterQueue with a CEL
+	// expression.
+	QueueOrderingExpression featuregate.Feature = "QueueOrderingExpression"
+
+	// owner: @troychiu
+	// alpha: v0.11
+	//
+	// Enables the dependencies between the workloads.
+	WorkloadDependencies featuregate.Feature = "WorkloadDependencies"
+
+	// owner: @troychiu
+	// alpha: v0.11
+	//
+	// Enables the flavorSelectionPolicy of the ClusterQueues.
+	FlavorSelectionPolicy featuregate.Feature = "FlavorSelectionPolicy"
+
+	// owner: @troychiu
+	// alpha: v0.11
+	//
+	// Enables scheduling the independent Cohort trees concurrently.
+	ParallelCohortScheduling featuregate.Feature = "ParallelCohortScheduling"
+
+	// owner: @troychiu
+	// alpha: v0.11
+	//
+	// Enables reusing the snapshots of the ClusterQueues which didn't change
+	// since the previous scheduling cycle.
+	IncrementalCacheSnapshots featuregate.Feature = "IncrementalCacheSnapshots"
 )
 
 func init() {
@@ -201,6 +282,18 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	ManagedJobsNamespaceSelector:        {Default: true, PreRelease: featuregate.Beta},
 	LocalQueueMetrics:                   {Default: false, PreRelease: featuregate.Alpha},
 	LocalQueueDefaulting:                {Default: false, PreRelease: featuregate.Alpha},
+	WorkloadSchedulingAttemptDetails:    {Default: false, PreRelease: featuregate.Alpha},
+	AdmissionFairSharing:                {Default: false, PreRelease: featuregate.Alpha},
+	StrictFIFOBackfill:                  {Default: false, PreRelease: featuregate.Alpha},
+	AdvanceReservations:                 {Default: false, PreRelease: featuregate.Alpha},
+	QuotaSchedules:                      {Default: false, PreRelease: featuregate.Alpha},
+	PriorityAging:                       {Default: false, PreRelease: featuregate.Alpha},
+	DeadlineAwareScheduling:             {Default: false, PreRelease: featuregate.Alpha},
+	QueueOrderingExpression:             {Default: false, PreRelease: featuregate.Alpha},
+	WorkloadDependencies:                {Default: false, PreRelease: featuregate.Alpha},
+	FlavorSelectionPolicy:               {Default: false, PreRelease: featuregate.Alpha},
+	ParallelCohortScheduling:            {Default: false, PreRelease: featuregate.Alpha},
+	IncrementalCacheSnapshots:           {Default: false, PreRelease: featuregate.Alpha},
 }
 
 func SetFeatureGateDuringTest(tb testing.TB, f featuregate.Feature, value bool) {
@@ -78,7 +78,8 @@ func (c *Cohort[CQ, C]) hasChildren() bool {
 	return c.childCqs.Len()+c.childCohorts.Len() > 0
 }
 
-func (c *Cohort[CQ, C]) isExplicit() bool {
+// IsExplicit returns true if the Cohort is backed by a Cohort API object.
+func (c *Cohort[CQ, C]) IsExplicit() bool {
 	return c.explicit
 }
 
@@ -60,7 +60,7 @@ func (m *Manager[CQ, C]) DeleteClusterQueue(name string) {
 
 func (m *Manager[CQ, C]) AddCohort(cohortName string) {
 	oldCohort, ok := m.Cohorts[cohortName]
-	if ok && oldCohort.isExplicit() {
+	if ok && oldCohort.IsExplicit() {
 		return
 	}
 	if !ok {
@@ -96,6 +96,22 @@ func (m *Manager[CQ, C]) DeleteCohort(name string) {
 	m.transferChildren(cohort, implicitCohort)
 }
 
+// RootCohort returns the root of the Cohort tree which the ClusterQueue
+// belongs to. It returns false if the ClusterQueue doesn't exist or doesn't
+// belong to a Cohort. It expects that no cycles exist in the Cohort graph.
+func (m *Manager[CQ, C]) RootCohort(cqName string) (C, bool) {
+	cq, ok := m.ClusterQueues[cqName]
+	if !ok || !cq.HasParent() {
+		var zero C
+		return zero, false
+	}
+	root := cq.Parent()
+	for root.HasParent() {
+		root = root.Parent()
+	}
+	return root, true
+}
+
 // transferChildren is used when we are changing a Cohort
 // from an explicit to an implicit Cohort.
 func (m *Manager[CQ, C]) transferChildren(old, new C) {
@@ -137,7 +153,7 @@ func (m *Manager[CQ, C]) getOrCreateCohort(cohortName string) C {
 }
 
 func (m *Manager[CQ, C]) cleanupCohort(cohort C) {
-	if !cohort.isExplicit() && !cohort.hasChildren() {
+	if !cohort.IsExplicit() && !cohort.hasChildren() {
 		delete(m.Cohorts, cohort.GetName())
 	}
 }
@@ -170,7 +186,7 @@ type cohortNode[CQ, C nodeBase] interface {
 	deleteClusterQueue(CQ)
 	hasChildren() bool
 	ChildCQs() []CQ
-	isExplicit() bool
+	IsExplicit() bool
 	markExplicit()
 	nodeBase
 }
@@ -492,6 +492,51 @@ func TestCycles(t *testing.T) {
 		})
 	}
 }
+func TestRootCohort(t *testing.T) {
+	mgr := NewManager(newCohort)
+	mgr.AddClusterQueue(newCq("standalone"))
+	mgr.AddClusterQueue(newCq("queue1"))
+	mgr.AddClusterQueue(newCq("queue2"))
+	mgr.AddCohort("root")
+	mgr.AddCohort("left")
+	mgr.UpdateCohortEdge("left", "root")
+	mgr.UpdateClusterQueueEdge("queue1", "left")
+	mgr.UpdateClusterQueueEdge("queue2", "root")
+
+	cases := map[string]struct {
+		cq       string
+		wantRoot string
+		wantOk   bool
+	}{
+		"queue in a nested cohort": {
+			cq:       "queue1",
+			wantRoot: "root",
+			wantOk:   true,
+		},
+		"queue in the root cohort": {
+			cq:       "queue2",
+			wantRoot: "root",
+			wantOk:   true,
+		},
+		"queue without cohort": {
+			cq: "standalone",
+		},
+		"unknown queue": {
+			cq: "unknown",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			root, ok := mgr.RootCohort(tc.cq)
+			if ok != tc.wantOk {
+				t.Fatalf("Unexpected found %v, want %v", ok, tc.wantOk)
+			}
+			if ok && root.GetName() != tc.wantRoot {
+				t.Errorf("Unexpected root %q, want %q", root.GetName(), tc.wantRoot)
+			}
+		})
+	}
+}
 
 type testCohort struct {
 	name string
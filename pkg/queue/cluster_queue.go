@@ -18,6 +18,9 @@ package queue
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"sync"
 
@@ -28,9 +31,11 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
 	"sigs.k8s.io/kueue/pkg/util/heap"
 	utilpriority "sigs.k8s.io/kueue/pkg/util/priority"
@@ -76,6 +81,21 @@ type ClusterQueue struct {
 
 	queueingStrategy kueue.QueueingStrategy
 
+	// localQueueFairSharing indicates whether the workloads are ordered by
+	// the weighted share of their LocalQueues.
+	localQueueFairSharing bool
+	// localQueueShares holds the weighted shares of the LocalQueues, keyed
+	// by the LocalQueue key, as of the last call to updateLocalQueueShares.
+	localQueueShares map[string]int64
+
+	// orderingKeys holds the results of the ordering expression of the
+	// ClusterQueue for the pending workloads, when set.
+	orderingKeys *workload.OrderingKeys
+
+	// compareWorkloads breaks the ties between the workloads of the same
+	// priority, when set.
+	compareWorkloads func(a, b *workload.Info) int
+
 	rwm sync.RWMutex
 
 	clock clock.Clock
@@ -99,15 +119,22 @@ func newClusterQueue(cq *kueue.ClusterQueue, wo workload.Ordering) (*ClusterQueu
 }
 
 func newClusterQueueImpl(wo workload.Ordering, clock clock.Clock) *ClusterQueue {
-	lessFunc := queueOrderingFunc(wo)
-	return &ClusterQueue{
-		heap:                   *heap.New(workloadKey, lessFunc),
+	c := &ClusterQueue{
 		inadmissibleWorkloads:  make(map[string]*workload.Info),
 		queueInadmissibleCycle: -1,
-		lessFunc:               lessFunc,
 		rwm:                    sync.RWMutex{},
 		clock:                  clock,
 	}
+	c.lessFunc = queueOrderingFunc(wo, func() map[string]int64 { return c.localQueueShares }, func() *workload.OrderingKeys { return c.orderingKeys }, func() bool {
+		return c.queueingStrategy == kueue.EarliestDeadlineFirst
+	}, func(a, b *workload.Info) int {
+		if c.compareWorkloads == nil {
+			return 0
+		}
+		return c.compareWorkloads(a, b)
+	})
+	c.heap = *heap.New(workloadKey, c.lessFunc)
+	return c
 }
 
 // Update updates the properties of this ClusterQueue.
@@ -115,16 +142,73 @@ func (c *ClusterQueue) Update(apiCQ *kueue.ClusterQueue) error {
 	c.rwm.Lock()
 	defer c.rwm.Unlock()
 	c.name = apiCQ.Name
-	c.queueingStrategy = apiCQ.Spec.QueueingStrategy
+	if c.queueingStrategy != apiCQ.Spec.QueueingStrategy {
+		reorder := c.queueingStrategy == kueue.EarliestDeadlineFirst || apiCQ.Spec.QueueingStrategy == kueue.EarliestDeadlineFirst
+		c.queueingStrategy = apiCQ.Spec.QueueingStrategy
+		if reorder {
+			c.heap.Reorder()
+		}
+	}
 	nsSelector, err := metav1.LabelSelectorAsSelector(apiCQ.Spec.NamespaceSelector)
 	if err != nil {
 		return err
 	}
 	c.namespaceSelector = nsSelector
+	if err := c.updateOrderingExpression(apiCQ.Spec.OrderingExpression); err != nil {
+		return err
+	}
 	c.active = apimeta.IsStatusConditionTrue(apiCQ.Status.Conditions, kueue.ClusterQueueActive)
+	c.localQueueFairSharing = apiCQ.Spec.LocalQueueFairSharing != nil
+	if !c.localQueueFairSharing && c.localQueueShares != nil {
+		c.localQueueShares = nil
+		c.heap.Reorder()
+	}
+	return nil
+}
+
+// updateOrderingExpression compiles the ordering expression and reorders the
+// pending workloads, if it changed.
+func (c *ClusterQueue) updateOrderingExpression(expression *string) error {
+	if !features.Enabled(features.QueueOrderingExpression) {
+		expression = nil
+	}
+	var current *string
+	if c.orderingKeys != nil {
+		current = ptr.To(c.orderingKeys.Expression().String())
+	}
+	if ptr.Equal(current, expression) {
+		return nil
+	}
+	var orderingKeys *workload.OrderingKeys
+	if expression != nil {
+		compiled, err := workload.CompileOrderingExpression(*expression)
+		if err != nil {
+			return fmt.Errorf("compiling the ordering expression: %w", err)
+		}
+		orderingKeys = workload.NewOrderingKeys(compiled)
+	}
+	c.orderingKeys = orderingKeys
+	c.heap.Reorder()
 	return nil
 }
 
+// LocalQueueFairSharing returns true if the workloads are ordered by the
+// weighted share of their LocalQueues.
+func (c *ClusterQueue) LocalQueueFairSharing() bool {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	return c.localQueueFairSharing
+}
+
+// updateLocalQueueShares sets the weighted shares of the LocalQueues and
+// reorders the pending workloads accordingly.
+func (c *ClusterQueue) updateLocalQueueShares(shares map[string]int64) {
+	c.rwm.Lock()
+	defer c.rwm.Unlock()
+	c.localQueueShares = shares
+	c.heap.Reorder()
+}
+
 // AddFromLocalQueue pushes all workloads belonging to this queue to
 // the ClusterQueue. If at least one workload is added, returns true,
 // otherwise returns false.
@@ -150,27 +234,38 @@ func (c *ClusterQueue) PushOrUpdate(wInfo *workload.Info) {
 	oldInfo := c.inadmissibleWorkloads[key]
 	if oldInfo != nil {
 		// update in place if the workload was inadmissible and didn't change
-		// to potentially become admissible, unless the Eviction status changed
-		// which can affect the workloads order in the queue.
+		// to potentially become admissible, unless the Eviction status or the
+		// effective priority changed which can affect the workloads order in
+		// the queue.
 		if equality.Semantic.DeepEqual(oldInfo.Obj.Spec, wInfo.Obj.Spec) &&
 			equality.Semantic.DeepEqual(oldInfo.Obj.Status.ReclaimablePods, wInfo.Obj.Status.ReclaimablePods) &&
+			ptr.Equal(oldInfo.Obj.Status.EffectivePriority, wInfo.Obj.Status.EffectivePriority) &&
 			equality.Semantic.DeepEqual(apimeta.FindStatusCondition(oldInfo.Obj.Status.Conditions, kueue.WorkloadEvicted),
 				apimeta.FindStatusCondition(wInfo.Obj.Status.Conditions, kueue.WorkloadEvicted)) &&
 			equality.Semantic.DeepEqual(apimeta.FindStatusCondition(oldInfo.Obj.Status.Conditions, kueue.WorkloadRequeued),
-				apimeta.FindStatusCondition(wInfo.Obj.Status.Conditions, kueue.WorkloadRequeued)) {
+				apimeta.FindStatusCondition(wInfo.Obj.Status.Conditions, kueue.WorkloadRequeued)) &&
+			equality.Semantic.DeepEqual(apimeta.FindStatusCondition(oldInfo.Obj.Status.Conditions, kueue.WorkloadDependenciesSatisfied),
+				apimeta.FindStatusCondition(wInfo.Obj.Status.Conditions, kueue.WorkloadDependenciesSatisfied)) {
 			c.inadmissibleWorkloads[key] = wInfo
 			return
 		}
 		// otherwise move or update in place in the queue.
 		delete(c.inadmissibleWorkloads, key)
 	}
-	if c.heap.GetByKey(key) == nil && !c.backoffWaitingTimeExpired(wInfo) {
+	if !workload.DependenciesReady(wInfo.Obj) || (c.heap.GetByKey(key) == nil && !c.backoffWaitingTimeExpired(wInfo)) {
+		c.heap.Delete(key)
 		c.inadmissibleWorkloads[key] = wInfo
 		return
 	}
 	c.heap.PushOrUpdate(wInfo)
 }
 
+// readyToCompete returns true if the workload can compete for admission,
+// that is its backoff expired and its dependencies are satisfied.
+func (c *ClusterQueue) readyToCompete(wInfo *workload.Info) bool {
+	return c.backoffWaitingTimeExpired(wInfo) && workload.DependenciesReady(wInfo.Obj)
+}
+
 // backoffWaitingTimeExpired returns true if the current time is after the requeueAt
 // and Requeued condition not present or equal True.
 func (c *ClusterQueue) backoffWaitingTimeExpired(wInfo *workload.Info) bool {
@@ -199,6 +294,9 @@ func (c *ClusterQueue) delete(w *kueue.Workload) {
 	delete(c.inadmissibleWorkloads, key)
 	c.heap.Delete(key)
 	c.forgetInflightByKey(key)
+	if c.orderingKeys != nil {
+		c.orderingKeys.Forget(key)
+	}
 }
 
 // DeleteFromLocalQueue removes all workloads belonging to this queue from
@@ -227,7 +325,7 @@ func (c *ClusterQueue) requeueIfNotPresent(wInfo *workload.Info, immediate bool)
 	defer c.rwm.Unlock()
 	key := workload.Key(wInfo.Obj)
 	c.forgetInflightByKey(key)
-	if c.backoffWaitingTimeExpired(wInfo) &&
+	if c.readyToCompete(wInfo) &&
 		(immediate || c.queueInadmissibleCycle >= c.popCycle || wInfo.LastAssignment.PendingFlavors()) {
 		// If the workload was inadmissible, move it back into the queue.
 		inadmissibleWl := c.inadmissibleWorkloads[key]
@@ -272,7 +370,7 @@ func (c *ClusterQueue) QueueInadmissibleWorkloads(ctx context.Context, client cl
 	for key, wInfo := range c.inadmissibleWorkloads {
 		ns := corev1.Namespace{}
 		err := client.Get(ctx, types.NamespacedName{Name: wInfo.Obj.Namespace}, &ns)
-		if err != nil || !c.namespaceSelector.Matches(labels.Set(ns.Labels)) || !c.backoffWaitingTimeExpired(wInfo) {
+		if err != nil || !c.namespaceSelector.Matches(labels.Set(ns.Labels)) || !c.readyToCompete(wInfo) {
 			inadmissibleWorkloads[key] = wInfo
 		} else {
 			moved = c.heap.PushIfNotPresent(wInfo) || moved
@@ -318,9 +416,28 @@ func (c *ClusterQueue) Pop() *workload.Info {
 		return nil
 	}
 	c.inflight = c.heap.Pop()
+	if c.orderingKeys != nil {
+		c.orderingKeys.Forget(workload.Key(c.inflight.Obj))
+	}
 	return c.inflight
 }
 
+// BackfillCandidates returns up to n workloads from the heap, in queue
+// order, without removing them. These are the workloads queued behind the
+// last popped head.
+func (c *ClusterQueue) BackfillCandidates(n int) []*workload.Info {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	elements := c.heap.List()
+	sort.Slice(elements, func(i, j int) bool {
+		return c.lessFunc(elements[i], elements[j])
+	})
+	if len(elements) > n {
+		elements = elements[:n]
+	}
+	return elements
+}
+
 // Dump produces a dump of the current workloads in the heap of
 // this ClusterQueue. It returns false if the queue is empty,
 // otherwise returns true.
@@ -350,9 +467,45 @@ func (c *ClusterQueue) DumpInadmissible() ([]string, bool) {
 	return elements, true
 }
 
+// dumpPendingWorkloads describes the workloads in the heap, including the
+// inflight one, and the inadmissible workloads of this ClusterQueue.
+func (c *ClusterQueue) dumpPendingWorkloads() PendingWorkloadsDump {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	pending := c.heap.List()
+	if c.inflight != nil {
+		pending = append(pending, c.inflight)
+	}
+	return PendingWorkloadsDump{
+		Pending:      c.dumpWorkloads(pending),
+		Inadmissible: c.dumpWorkloads(slices.Collect(maps.Values(c.inadmissibleWorkloads))),
+	}
+}
+
+func (c *ClusterQueue) dumpWorkloads(infos []*workload.Info) []WorkloadDump {
+	sort.Slice(infos, func(i, j int) bool {
+		return c.lessFunc(infos[i], infos[j])
+	})
+	dump := make([]WorkloadDump, 0, len(infos))
+	for _, info := range infos {
+		wlDump := WorkloadDump{
+			Name:       workload.Key(info.Obj),
+			LocalQueue: info.Obj.Spec.QueueName,
+			Priority:   utilpriority.Effective(info.Obj),
+		}
+		if cond := apimeta.FindStatusCondition(info.Obj.Status.Conditions, kueue.WorkloadQuotaReserved); cond != nil && cond.Status == metav1.ConditionFalse {
+			wlDump.Message = cond.Message
+		}
+		dump = append(dump, wlDump)
+	}
+	return dump
+}
+
 // Snapshot returns a copy of the current workloads in the heap of
 // this ClusterQueue.
 func (c *ClusterQueue) Snapshot() []*workload.Info {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
 	elements := c.totalElements()
 	sort.Slice(elements, func(i, j int) bool {
 		return c.lessFunc(elements[i], elements[j])
@@ -368,9 +521,8 @@ func (c *ClusterQueue) Info(key string) *workload.Info {
 	return c.heap.GetByKey(key)
 }
 
+// totalElements returns all the workloads of the ClusterQueue without lock.
 func (c *ClusterQueue) totalElements() []*workload.Info {
-	c.rwm.RLock()
-	defer c.rwm.RUnlock()
 	totalLen := c.heap.Len() + len(c.inadmissibleWorkloads)
 	elements := make([]*workload.Info, 0, totalLen)
 	elements = append(elements, c.heap.List()...)
@@ -407,18 +559,50 @@ func (c *ClusterQueue) RequeueIfNotPresent(wInfo *workload.Info, reason RequeueR
 }
 
 // queueOrderingFunc returns a function used by the clusterQueue heap algorithm
-// to sort workloads. The function sorts workloads based on their priority.
-// When priorities are equal, it uses the workload's creation or eviction
-// time.
-func queueOrderingFunc(wo workload.Ordering) func(a, b *workload.Info) bool {
+// to sort workloads. When the LocalQueue shares are provided, the function
+// first sorts workloads by the weighted share of their LocalQueues, lower
+// first. When the ordering keys are provided, it then sorts workloads by the
+// result of the ordering expression, lower first. Then, it sorts workloads
+// based on their effective priority. When
+// priorities are equal and earliestDeadlineFirst returns true, it sorts the
+// workloads by their latest start time, placing the workloads without a
+// deadline last. Finally, it uses the workload's creation or eviction time.
+func queueOrderingFunc(wo workload.Ordering, localQueueShares func() map[string]int64, orderingKeys func() *workload.OrderingKeys, earliestDeadlineFirst func() bool, compare func(a, b *workload.Info) int) func(a, b *workload.Info) bool {
 	return func(a, b *workload.Info) bool {
-		p1 := utilpriority.Priority(a.Obj)
-		p2 := utilpriority.Priority(b.Obj)
+		if shares := localQueueShares(); shares != nil {
+			s1 := shares[workload.QueueKey(a.Obj)]
+			s2 := shares[workload.QueueKey(b.Obj)]
+			if s1 != s2 {
+				return s1 < s2
+			}
+		}
+		if keys := orderingKeys(); keys != nil {
+			if c := keys.Compare(a, b); c != 0 {
+				return c < 0
+			}
+		}
+		p1 := utilpriority.Effective(a.Obj)
+		p2 := utilpriority.Effective(b.Obj)
 
 		if p1 != p2 {
 			return p1 > p2
 		}
 
+		if earliestDeadlineFirst() {
+			lA := wo.GetLatestStartTime(a.Obj)
+			lB := wo.GetLatestStartTime(b.Obj)
+			if (lA == nil) != (lB == nil) {
+				return lA != nil
+			}
+			if lA != nil && !lA.Equal(lB) {
+				return lA.Before(lB)
+			}
+		}
+
+		if c := compare(a, b); c != 0 {
+			return c < 0
+		}
+
 		tA := wo.GetQueueOrderTimestamp(a.Obj)
 		tB := wo.GetQueueOrderTimestamp(b.Obj)
 		return !tB.Before(tA)
@@ -31,6 +31,7 @@ import (
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -701,6 +702,309 @@ func TestFIFOClusterQueue(t *testing.T) {
 	}
 }
 
+func TestLocalQueueFairSharingOrdering(t *testing.T) {
+	q, err := newClusterQueue(
+		utiltesting.MakeClusterQueue("cq").
+			LocalQueueFairSharing(kueue.LocalQueueCurrentUsage).
+			Obj(),
+		defaultOrdering)
+	if err != nil {
+		t.Fatalf("Failed creating ClusterQueue %v", err)
+	}
+	now := time.Now()
+	ws := []*kueue.Workload{
+		utiltesting.MakeWorkload("a-high", defaultNamespace).Queue("lq-a").
+			Priority(highPriority).Creation(now).Obj(),
+		utiltesting.MakeWorkload("a-low", defaultNamespace).Queue("lq-a").
+			Priority(lowPriority).Creation(now).Obj(),
+		utiltesting.MakeWorkload("b-low", defaultNamespace).Queue("lq-b").
+			Priority(lowPriority).Creation(now.Add(time.Second)).Obj(),
+	}
+	for _, w := range ws {
+		q.PushOrUpdate(workload.NewInfo(w))
+	}
+	if got := q.Snapshot(); got[0].Obj.Name != "a-high" {
+		t.Errorf("Head before the shares are known %q, want %q", got[0].Obj.Name, "a-high")
+	}
+
+	q.updateLocalQueueShares(map[string]int64{
+		"default/lq-a": 500,
+		"default/lq-b": 100,
+	})
+	var gotOrder []string
+	for wl := q.Pop(); wl != nil; wl = q.Pop() {
+		gotOrder = append(gotOrder, wl.Obj.Name)
+	}
+	wantOrder := []string{"b-low", "a-high", "a-low"}
+	if diff := cmp.Diff(wantOrder, gotOrder); diff != "" {
+		t.Errorf("Unexpected order (-want,+got):\n%s", diff)
+	}
+}
+
+func TestPriorityAgingOrdering(t *testing.T) {
+	cases := map[string]struct {
+		enableAging bool
+		wantOrder   []string
+	}{
+		"aging disabled": {
+			wantOrder: []string{"high", "low", "aged-low"},
+		},
+		"aging enabled": {
+			enableAging: true,
+			wantOrder:   []string{"aged-low", "high", "low"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PriorityAging, tc.enableAging)
+			q, err := newClusterQueue(utiltesting.MakeClusterQueue("cq").Obj(), defaultOrdering)
+			if err != nil {
+				t.Fatalf("Failed creating ClusterQueue %v", err)
+			}
+			now := time.Now()
+			ws := []*kueue.Workload{
+				utiltesting.MakeWorkload("high", defaultNamespace).
+					Priority(highPriority).Creation(now).Obj(),
+				utiltesting.MakeWorkload("low", defaultNamespace).
+					Priority(lowPriority).Creation(now).Obj(),
+				utiltesting.MakeWorkload("aged-low", defaultNamespace).
+					Priority(lowPriority).Creation(now.Add(time.Second)).Obj(),
+			}
+			for _, w := range ws {
+				q.PushOrUpdate(workload.NewInfo(w))
+			}
+			// The effective priority is raised while the workload is queued.
+			aged := ws[2].DeepCopy()
+			aged.Status.EffectivePriority = ptr.To(highPriority + 1)
+			q.PushOrUpdate(workload.NewInfo(aged))
+
+			var gotOrder []string
+			for wl := q.Pop(); wl != nil; wl = q.Pop() {
+				gotOrder = append(gotOrder, wl.Obj.Name)
+			}
+			if diff := cmp.Diff(tc.wantOrder, gotOrder); diff != "" {
+				t.Errorf("Unexpected order (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEarliestDeadlineFirstOrdering(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cases := map[string]struct {
+		strategy       kueue.QueueingStrategy
+		enableDeadline bool
+		wantOrder      []string
+	}{
+		"best effort FIFO": {
+			strategy:       kueue.BestEffortFIFO,
+			enableDeadline: true,
+			wantOrder:      []string{"high", "no-deadline", "late-deadline", "long-runtime", "early-deadline"},
+		},
+		"earliest deadline first": {
+			strategy:       kueue.EarliestDeadlineFirst,
+			enableDeadline: true,
+			wantOrder:      []string{"high", "long-runtime", "early-deadline", "late-deadline", "no-deadline"},
+		},
+		"earliest deadline first with the feature disabled": {
+			strategy:  kueue.EarliestDeadlineFirst,
+			wantOrder: []string{"high", "no-deadline", "late-deadline", "long-runtime", "early-deadline"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.DeadlineAwareScheduling, tc.enableDeadline)
+			q, err := newClusterQueue(utiltesting.MakeClusterQueue("cq").QueueingStrategy(tc.strategy).Obj(), defaultOrdering)
+			if err != nil {
+				t.Fatalf("Failed creating ClusterQueue %v", err)
+			}
+			ws := []*kueue.Workload{
+				utiltesting.MakeWorkload("high", defaultNamespace).
+					Priority(highPriority).
+					Creation(now.Add(4 * time.Second)).
+					Obj(),
+				utiltesting.MakeWorkload("no-deadline", defaultNamespace).
+					Creation(now).
+					Obj(),
+				utiltesting.MakeWorkload("late-deadline", defaultNamespace).
+					CompletionDeadline(now.Add(2 * time.Hour)).
+					Creation(now.Add(time.Second)).
+					Obj(),
+				utiltesting.MakeWorkload("long-runtime", defaultNamespace).
+					CompletionDeadline(now.Add(2 * time.Hour)).
+					MaximumExecutionTimeSeconds(int32((90 * time.Minute).Seconds())).
+					Creation(now.Add(2 * time.Second)).
+					Obj(),
+				utiltesting.MakeWorkload("early-deadline", defaultNamespace).
+					CompletionDeadline(now.Add(time.Hour)).
+					Creation(now.Add(3 * time.Second)).
+					Obj(),
+			}
+			for _, w := range ws {
+				q.PushOrUpdate(workload.NewInfo(w))
+			}
+
+			var gotOrder []string
+			for wl := q.Pop(); wl != nil; wl = q.Pop() {
+				gotOrder = append(gotOrder, wl.Obj.Name)
+			}
+			if diff := cmp.Diff(tc.wantOrder, gotOrder); diff != "" {
+				t.Errorf("Unexpected order (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOrderingExpression(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cases := map[string]struct {
+		expression       *string
+		enableExpression bool
+		wantOrder        []string
+	}{
+		"no expression": {
+			enableExpression: true,
+			wantOrder:        []string{"high", "old", "small", "new"},
+		},
+		"smaller requests first": {
+			expression:       ptr.To(`requests["cpu"]`),
+			enableExpression: true,
+			wantOrder:        []string{"small", "high", "old", "new"},
+		},
+		"by label, then priority": {
+			expression:       ptr.To(`labels["tier"]`),
+			enableExpression: true,
+			wantOrder:        []string{"small", "new", "high", "old"},
+		},
+		"feature disabled": {
+			expression: ptr.To(`requests["cpu"]`),
+			wantOrder:  []string{"high", "old", "small", "new"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.QueueOrderingExpression, tc.enableExpression)
+			cq := utiltesting.MakeClusterQueue("cq").Obj()
+			cq.Spec.OrderingExpression = tc.expression
+			q, err := newClusterQueue(cq, defaultOrdering)
+			if err != nil {
+				t.Fatalf("Failed creating ClusterQueue %v", err)
+			}
+			ws := []*kueue.Workload{
+				utiltesting.MakeWorkload("high", defaultNamespace).
+					Priority(highPriority).
+					Label("tier", "b").
+					Request(corev1.ResourceCPU, "4").
+					Creation(now.Add(3 * time.Second)).
+					Obj(),
+				utiltesting.MakeWorkload("old", defaultNamespace).
+					Label("tier", "b").
+					Request(corev1.ResourceCPU, "4").
+					Creation(now).
+					Obj(),
+				utiltesting.MakeWorkload("small", defaultNamespace).
+					Label("tier", "a").
+					Request(corev1.ResourceCPU, "1").
+					Creation(now.Add(time.Second)).
+					Obj(),
+				utiltesting.MakeWorkload("new", defaultNamespace).
+					Label("tier", "a").
+					Request(corev1.ResourceCPU, "8").
+					Creation(now.Add(2 * time.Second)).
+					Obj(),
+			}
+			for _, w := range ws {
+				q.PushOrUpdate(workload.NewInfo(w))
+			}
+
+			var gotOrder []string
+			for wl := q.Pop(); wl != nil; wl = q.Pop() {
+				gotOrder = append(gotOrder, wl.Obj.Name)
+			}
+			if diff := cmp.Diff(tc.wantOrder, gotOrder); diff != "" {
+				t.Errorf("Unexpected order (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOrderingExpressionUpdate(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.QueueOrderingExpression, true)
+	now := time.Now().Truncate(time.Second)
+	cq := utiltesting.MakeClusterQueue("cq").Obj()
+	q, err := newClusterQueue(cq, defaultOrdering)
+	if err != nil {
+		t.Fatalf("Failed creating ClusterQueue %v", err)
+	}
+	q.PushOrUpdate(workload.NewInfo(utiltesting.MakeWorkload("old", defaultNamespace).
+		Request(corev1.ResourceCPU, "4").
+		Creation(now).
+		Obj()))
+	q.PushOrUpdate(workload.NewInfo(utiltesting.MakeWorkload("small", defaultNamespace).
+		Request(corev1.ResourceCPU, "1").
+		Creation(now.Add(time.Second)).
+		Obj()))
+
+	cq = utiltesting.MakeClusterQueue("cq").OrderingExpression(`requests["cpu"]`).Obj()
+	if err := q.Update(cq); err != nil {
+		t.Fatalf("Failed updating ClusterQueue %v", err)
+	}
+	if got := q.Pop().Obj.Name; got != "small" {
+		t.Errorf("Unexpected head after setting the expression, want=small, got=%s", got)
+	}
+
+	cq = utiltesting.MakeClusterQueue("cq").OrderingExpression("priority +").Obj()
+	if err := q.Update(cq); err == nil {
+		t.Error("Expected an error updating the ClusterQueue with an invalid expression")
+	}
+	if q.orderingKeys == nil || q.orderingKeys.Expression().String() != `requests["cpu"]` {
+		t.Error("Expected the previous expression to be kept after failing to compile the new one")
+	}
+}
+
+func TestWorkloadDependencies(t *testing.T) {
+	cases := map[string]struct {
+		enableDependencies bool
+		wantPopped         bool
+	}{
+		"dependencies not satisfied": {
+			enableDependencies: true,
+		},
+		"feature disabled": {
+			wantPopped: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.WorkloadDependencies, tc.enableDependencies)
+			q, err := newClusterQueue(utiltesting.MakeClusterQueue("cq").Obj(), defaultOrdering)
+			if err != nil {
+				t.Fatalf("Failed creating ClusterQueue %v", err)
+			}
+			wl := utiltesting.MakeWorkload("dependent", defaultNamespace).
+				Dependencies(kueue.WorkloadDependency{Name: ptr.To("prepare")}).
+				Obj()
+			q.PushOrUpdate(workload.NewInfo(wl))
+			if got := q.Pop() != nil; got != tc.wantPopped {
+				t.Fatalf("Unexpected pop of the workload with pending dependencies, want=%t, got=%t", tc.wantPopped, got)
+			}
+			if tc.wantPopped {
+				return
+			}
+			if got := q.PendingInadmissible(); got != 1 {
+				t.Errorf("Unexpected inadmissible workloads, want=1, got=%d", got)
+			}
+
+			wl = wl.DeepCopy()
+			workload.SetDependenciesSatisfiedCondition(wl, true, "All the dependencies are satisfied")
+			q.PushOrUpdate(workload.NewInfo(wl))
+			if got := q.Pop(); got == nil || got.Obj.Name != "dependent" {
+				t.Errorf("Expected the workload to be popped once its dependencies are satisfied, got=%v", got)
+			}
+		})
+	}
+}
+
 func TestStrictFIFO(t *testing.T) {
 	t1 := time.Now()
 	t2 := t1.Add(time.Second)
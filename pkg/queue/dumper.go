@@ -75,3 +75,40 @@ func (m *Manager) DumpInadmissible() map[string][]string {
 	}
 	return dump
 }
+
+// PendingWorkloadsDump lists the pending workloads of a ClusterQueue.
+type PendingWorkloadsDump struct {
+	// Cohort is the name of the parent Cohort of the ClusterQueue, if any.
+	Cohort string `json:"cohort,omitempty"`
+	// Pending are the workloads waiting to be evaluated, in queue order.
+	Pending []WorkloadDump `json:"pending"`
+	// Inadmissible are the workloads which couldn't be admitted and wait for
+	// the cluster conditions to change, in queue order.
+	Inadmissible []WorkloadDump `json:"inadmissible"`
+}
+
+// WorkloadDump describes a pending workload.
+type WorkloadDump struct {
+	Name       string `json:"name"`
+	LocalQueue string `json:"localQueue"`
+	Priority   int32  `json:"priority"`
+	// Message is the reason why the workload couldn't be admitted the last
+	// time it was evaluated, if any.
+	Message string `json:"message,omitempty"`
+}
+
+// DumpPendingWorkloads returns the pending and inadmissible workloads of each
+// ClusterQueue, by name.
+func (m *Manager) DumpPendingWorkloads() map[string]PendingWorkloadsDump {
+	m.RLock()
+	defer m.RUnlock()
+	dump := make(map[string]PendingWorkloadsDump, len(m.hm.ClusterQueues))
+	for name, cq := range m.hm.ClusterQueues {
+		cqDump := cq.dumpPendingWorkloads()
+		if cq.HasParent() {
+			cqDump.Cohort = cq.Parent().Name
+		}
+		dump[name] = cqDump
+	}
+	return dump
+}
@@ -21,10 +21,13 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -47,6 +50,8 @@ var (
 type options struct {
 	podsReadyRequeuingTimestamp config.RequeuingTimestamp
 	workloadInfoOptions         []workload.InfoOption
+	compareWorkloads            func(a, b *workload.Info) int
+	clock                       clock.WithDelayedExecution
 }
 
 // Option configures the manager.
@@ -55,6 +60,7 @@ type Option func(*options)
 var defaultOptions = options{
 	podsReadyRequeuingTimestamp: config.EvictionTimestamp,
 	workloadInfoOptions:         []workload.InfoOption{},
+	clock:                       realClock,
 }
 
 // WithPodsReadyRequeuingTimestamp sets the timestamp that is used for ordering
@@ -79,6 +85,24 @@ func WithResourceTransformations(transforms []config.ResourceTransformation) Opt
 	}
 }
 
+// WithWorkloadComparer sets the function breaking the ties between the
+// pending workloads of the same priority, such as the queueSort plugins of
+// the scheduler.
+func WithWorkloadComparer(compare func(a, b *workload.Info) int) Option {
+	return func(o *options) {
+		o.compareWorkloads = compare
+	}
+}
+
+// WithClock sets the clock used to check the backoff of the requeued
+// workloads and to delay the retries of the second pass of scheduling.
+// The offline simulations of the scheduler use it to drive time.
+func WithClock(c clock.WithDelayedExecution) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
 type TopologyUpdateWatcher interface {
 	NotifyTopologyUpdate(oldTopology, newTopology *kueuealpha.Topology)
 }
@@ -91,6 +115,10 @@ type Manager struct {
 	statusChecker StatusChecker
 	localQueues   map[string]*LocalQueue
 
+	// localQueueShareProvider is set when the statusChecker also provides
+	// the weighted shares of the LocalQueues.
+	localQueueShareProvider LocalQueueShareProvider
+
 	snapshotsMutex sync.RWMutex
 	snapshots      map[string][]kueue.ClusterQueuePendingWorkload
 
@@ -98,9 +126,17 @@ type Manager struct {
 
 	workloadInfoOptions []workload.InfoOption
 
+	compareWorkloads func(a, b *workload.Info) int
+
+	clock clock.WithDelayedExecution
+
 	hm hierarchy.Manager[*ClusterQueue, *cohort]
 
 	topologyUpdateWatchers []TopologyUpdateWatcher
+
+	// secondPassQueue holds the workloads which reserved quota, but still
+	// require the topology assignment to be computed by the scheduler.
+	secondPassQueue map[string]*workload.Info
 }
 
 func NewManager(client client.Client, checker StatusChecker, opts ...Option) *Manager {
@@ -118,14 +154,26 @@ func NewManager(client client.Client, checker StatusChecker, opts ...Option) *Ma
 			PodsReadyRequeuingTimestamp: options.podsReadyRequeuingTimestamp,
 		},
 		workloadInfoOptions: options.workloadInfoOptions,
+		compareWorkloads:    options.compareWorkloads,
+		clock:               options.clock,
 		hm:                  hierarchy.NewManager[*ClusterQueue, *cohort](newCohort),
 
 		topologyUpdateWatchers: make([]TopologyUpdateWatcher, 0),
+		secondPassQueue:        make(map[string]*workload.Info),
+	}
+	if provider, ok := checker.(LocalQueueShareProvider); ok {
+		m.localQueueShareProvider = provider
 	}
 	m.cond.L = &m.RWMutex
 	return m
 }
 
+// NewWorkloadInfo builds a workload.Info for w using the same options that the
+// manager uses for the queued workloads.
+func (m *Manager) NewWorkloadInfo(w *kueue.Workload) *workload.Info {
+	return workload.NewInfo(w, m.workloadInfoOptions...)
+}
+
 func (m *Manager) AddTopologyUpdateWatcher(watcher TopologyUpdateWatcher) {
 	m.topologyUpdateWatchers = append(m.topologyUpdateWatchers, watcher)
 }
@@ -164,6 +212,8 @@ func (m *Manager) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) e
 	if err != nil {
 		return err
 	}
+	cqImpl.compareWorkloads = m.compareWorkloads
+	cqImpl.clock = m.clock
 	m.hm.AddClusterQueue(cqImpl)
 	m.hm.UpdateClusterQueueEdge(cq.Name, cq.Spec.Cohort)
 
@@ -434,9 +484,42 @@ func (m *Manager) RequeueWorkload(ctx context.Context, info *workload.Info, reas
 func (m *Manager) DeleteWorkload(w *kueue.Workload) {
 	m.Lock()
 	m.deleteWorkloadFromQueueAndClusterQueue(w, workload.QueueKey(w))
+	delete(m.secondPassQueue, workload.Key(w))
 	m.Unlock()
 }
 
+// QueueSecondPassIfNeeded queues the workload for the second pass of
+// scheduling, in which the delayed topology assignment is computed, if the
+// workload requires it. It returns true if the workload was queued.
+func (m *Manager) QueueSecondPassIfNeeded(ctx context.Context, w *kueue.Workload) bool {
+	if !workload.NeedsSecondPass(w) {
+		return false
+	}
+	log := ctrl.LoggerFrom(ctx)
+	log.V(3).Info("Workload queued for the second pass of scheduling", "workload", klog.KObj(w))
+	m.Lock()
+	defer m.Unlock()
+	wInfo := m.NewWorkloadInfo(w)
+	wInfo.ClusterQueue = string(w.Status.Admission.ClusterQueue)
+	m.secondPassQueue[workload.Key(w)] = wInfo
+	m.Broadcast()
+	return true
+}
+
+// QueueSecondPassAfter queues the workload for the second pass of scheduling,
+// like QueueSecondPassIfNeeded, once the delay elapsed on the clock of the
+// manager. The workload isn't queued if ctx is done by then.
+func (m *Manager) QueueSecondPassAfter(ctx context.Context, w *kueue.Workload, delay time.Duration) {
+	m.clock.AfterFunc(delay, func() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			m.QueueSecondPassIfNeeded(ctx, w)
+		}
+	})
+}
+
 func (m *Manager) deleteWorkloadFromQueueAndClusterQueue(w *kueue.Workload, qKey string) {
 	q := m.localQueues[qKey]
 	if q == nil {
@@ -598,26 +681,83 @@ func (m *Manager) Heads(ctx context.Context) []workload.Info {
 	}
 }
 
+// TryHeads returns the heads of the queues like Heads, but it returns nil
+// instead of blocking when the queues are empty.
+func (m *Manager) TryHeads(ctx context.Context) []workload.Info {
+	m.Lock()
+	defer m.Unlock()
+	workloads := m.heads()
+	ctrl.LoggerFrom(ctx).V(3).Info("Obtained ClusterQueue heads", "count", len(workloads))
+	return workloads
+}
+
 func (m *Manager) heads() []workload.Info {
 	var workloads []workload.Info
+	for key, wl := range m.secondPassQueue {
+		workloads = append(workloads, *wl)
+		delete(m.secondPassQueue, key)
+	}
 	for cqName, cq := range m.hm.ClusterQueues {
 		// Cache might be nil in tests, if cache is nil, we'll skip the check.
 		if m.statusChecker != nil && !m.statusChecker.ClusterQueueActive(cqName) {
 			continue
 		}
-		wl := cq.Pop()
-		if wl == nil {
-			continue
+		if m.localQueueShareProvider != nil && cq.LocalQueueFairSharing() {
+			cq.updateLocalQueueShares(m.localQueueShareProvider.LocalQueueShares(cqName))
+		}
+		if wl := m.pop(cqName, cq); wl != nil {
+			workloads = append(workloads, *wl)
 		}
-		m.reportPendingWorkloads(cqName, cq)
+	}
+	return workloads
+}
+
+// Pop removes the head of the ClusterQueue and returns it, including its
+// desired ClusterQueue. It returns nil if the ClusterQueue is empty. It is
+// used to admit more than one workload from the ClusterQueue in a
+// scheduling cycle, so it doesn't block.
+func (m *Manager) Pop(cqName string) *workload.Info {
+	m.Lock()
+	defer m.Unlock()
+	cq := m.hm.ClusterQueues[cqName]
+	if cq == nil {
+		return nil
+	}
+	return m.pop(cqName, cq)
+}
+
+func (m *Manager) pop(cqName string, cq *ClusterQueue) *workload.Info {
+	wl := cq.Pop()
+	if wl == nil {
+		return nil
+	}
+	m.reportPendingWorkloads(cqName, cq)
+	wlCopy := *wl
+	wlCopy.ClusterQueue = cqName
+	q := m.localQueues[workload.QueueKey(wl.Obj)]
+	delete(q.items, workload.Key(wl.Obj))
+	if features.Enabled(features.LocalQueueMetrics) {
+		m.reportLQPendingWorkloads(q)
+	}
+	return &wlCopy
+}
+
+// BackfillCandidates returns up to n pending workloads queued in the
+// ClusterQueue behind its head, in queue order, including their desired
+// ClusterQueue. The workloads remain in the queue.
+func (m *Manager) BackfillCandidates(cqName string, n int) []workload.Info {
+	m.RLock()
+	defer m.RUnlock()
+	cq := m.hm.ClusterQueues[cqName]
+	if cq == nil {
+		return nil
+	}
+	candidates := cq.BackfillCandidates(n)
+	workloads := make([]workload.Info, 0, len(candidates))
+	for _, wl := range candidates {
 		wlCopy := *wl
 		wlCopy.ClusterQueue = cqName
 		workloads = append(workloads, wlCopy)
-		q := m.localQueues[workload.QueueKey(wl.Obj)]
-		delete(q.items, workload.Key(wl.Obj))
-		if features.Enabled(features.LocalQueueMetrics) {
-			m.reportLQPendingWorkloads(q)
-		}
 	}
 	return workloads
 }
@@ -30,6 +30,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
@@ -873,9 +875,10 @@ func TestHeads(t *testing.T) {
 		utiltesting.MakeLocalQueue("baz", "").ClusterQueue("pending-bazCq").Obj(),
 	}
 	tests := []struct {
-		name          string
-		workloads     []*kueue.Workload
-		wantWorkloads sets.Set[string]
+		name                 string
+		workloads            []*kueue.Workload
+		secondPassCandidates []*kueue.Workload
+		wantWorkloads        sets.Set[string]
 	}{
 		{
 			name:          "empty clusterQueues",
@@ -908,6 +911,28 @@ func TestHeads(t *testing.T) {
 			},
 			wantWorkloads: sets.New("a", "b"),
 		},
+		{
+			name: "workloads requiring the second pass",
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("a", "").Creation(now).Queue("foo").Obj(),
+			},
+			secondPassCandidates: []*kueue.Workload{
+				utiltesting.MakeWorkload("b", "").Creation(now).Queue("bar").
+					ReserveQuota(utiltesting.MakeAdmission("active-barCq").
+						DelayedTopologyRequest(kueue.DelayedTopologyRequestStatePending).
+						Obj()).
+					Obj(),
+				utiltesting.MakeWorkload("c", "").Creation(now).Queue("baz").
+					ReserveQuota(utiltesting.MakeAdmission("pending-bazCq").
+						DelayedTopologyRequest(kueue.DelayedTopologyRequestStatePending).
+						Obj()).
+					Obj(),
+				utiltesting.MakeWorkload("d", "").Creation(now).Queue("bar").
+					ReserveQuota(utiltesting.MakeAdmission("active-barCq").Obj()).
+					Obj(),
+			},
+			wantWorkloads: sets.New("a", "b", "c"),
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -932,6 +957,9 @@ func TestHeads(t *testing.T) {
 					t.Errorf("Failed to add or update workload: %v", err)
 				}
 			}
+			for _, wl := range tc.secondPassCandidates {
+				manager.QueueSecondPassIfNeeded(ctx, wl)
+			}
 
 			wlNames := sets.New[string]()
 			heads := manager.Heads(ctx)
@@ -1180,6 +1208,116 @@ func TestHeadsCancelled(t *testing.T) {
 	}
 }
 
+func TestTryHeads(t *testing.T) {
+	ctx := context.Background()
+	manager := NewManager(utiltesting.NewFakeClient(), nil)
+	cq := utiltesting.MakeClusterQueue("cq").Obj()
+	if err := manager.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Failed adding clusterQueue %s to manager: %v", cq.Name, err)
+	}
+	q := utiltesting.MakeLocalQueue("foo", "").ClusterQueue("cq").Obj()
+	if err := manager.AddLocalQueue(ctx, q); err != nil {
+		t.Fatalf("Failed adding queue %s: %s", q.Name, err)
+	}
+
+	if heads := manager.TryHeads(ctx); len(heads) != 0 {
+		t.Errorf("TryHeads returned %d elements, expected none", len(heads))
+	}
+	if err := manager.AddOrUpdateWorkload(utiltesting.MakeWorkload("a", "").Queue("foo").Obj()); err != nil {
+		t.Fatalf("Failed to add workload: %v", err)
+	}
+	var gotNames []string
+	for _, h := range manager.TryHeads(ctx) {
+		gotNames = append(gotNames, h.Obj.Name)
+	}
+	if diff := cmp.Diff([]string{"a"}, gotNames); diff != "" {
+		t.Errorf("TryHeads returned wrong heads (-want,+got):\n%s", diff)
+	}
+	if heads := manager.TryHeads(ctx); len(heads) != 0 {
+		t.Errorf("TryHeads returned %d elements after popping the heads, expected none", len(heads))
+	}
+}
+
+func TestTryHeadsWithClock(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	fakeClock := testingclock.NewFakeClock(now)
+	cl := utiltesting.NewFakeClient(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: defaultNamespace}})
+	manager := NewManager(cl, nil, WithClock(fakeClock))
+	cq := utiltesting.MakeClusterQueue("cq").Obj()
+	if err := manager.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Failed adding clusterQueue %s to manager: %v", cq.Name, err)
+	}
+	q := utiltesting.MakeLocalQueue("foo", defaultNamespace).ClusterQueue("cq").Obj()
+	if err := manager.AddLocalQueue(ctx, q); err != nil {
+		t.Fatalf("Failed adding queue %s: %s", q.Name, err)
+	}
+	wl := utiltesting.MakeWorkload("a", defaultNamespace).
+		Queue("foo").
+		RequeueState(ptr.To[int32](1), ptr.To(metav1.NewTime(now.Add(time.Minute)))).
+		Obj()
+	if err := manager.AddOrUpdateWorkload(wl); err != nil {
+		t.Fatalf("Failed to add workload: %v", err)
+	}
+	if heads := manager.TryHeads(ctx); len(heads) != 0 {
+		t.Errorf("TryHeads returned %d elements before the backoff expired, expected none", len(heads))
+	}
+
+	fakeClock.Step(time.Minute)
+	manager.QueueInadmissibleWorkloads(ctx, sets.New("cq"))
+	var gotNames []string
+	for _, h := range manager.TryHeads(ctx) {
+		gotNames = append(gotNames, h.Obj.Name)
+	}
+	if diff := cmp.Diff([]string{"a"}, gotNames); diff != "" {
+		t.Errorf("TryHeads returned wrong heads after the backoff expired (-want,+got):\n%s", diff)
+	}
+}
+
+func TestQueueSecondPassAfter(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	wl := utiltesting.MakeWorkload("a", "").
+		Queue("foo").
+		ReserveQuota(utiltesting.MakeAdmission("cq").
+			DelayedTopologyRequest(kueue.DelayedTopologyRequestStatePending).
+			Obj()).
+		Obj()
+	cases := map[string]struct {
+		cancel    bool
+		wantHeads []string
+	}{
+		"queued after the delay": {
+			wantHeads: []string{"a"},
+		},
+		"context done": {
+			cancel: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			fakeClock := testingclock.NewFakeClock(now)
+			manager := NewManager(utiltesting.NewFakeClient(), nil, WithClock(fakeClock))
+			manager.QueueSecondPassAfter(ctx, wl, time.Second)
+			if heads := manager.TryHeads(ctx); len(heads) != 0 {
+				t.Errorf("TryHeads returned %d elements before the delay elapsed, expected none", len(heads))
+			}
+			if tc.cancel {
+				cancel()
+			}
+			fakeClock.Step(time.Second)
+			var gotNames []string
+			for _, h := range manager.TryHeads(ctx) {
+				gotNames = append(gotNames, h.Obj.Name)
+			}
+			if diff := cmp.Diff(tc.wantHeads, gotNames); diff != "" {
+				t.Errorf("TryHeads returned wrong heads after the delay (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 // popNamesFromCQ pops all the workloads from the clusterQueue and returns
 // the keyed names in the order they are popped.
 func popNamesFromCQ(cq *ClusterQueue) []string {
@@ -1205,6 +1343,78 @@ func (c *fakeStatusChecker) ClusterQueueActive(name string) bool {
 	return strings.Contains(name, "active-")
 }
 
+func TestDumpPendingWorkloads(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	inadmissible := utiltesting.MakeWorkload("c", "").Queue("bar").Creation(now).
+		Condition(metav1.Condition{
+			Type:    kueue.WorkloadQuotaReserved,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Pending",
+			Message: "couldn't assign flavors to pod set main: insufficient quota for cpu",
+		}).
+		Obj()
+	manager := NewManager(utiltesting.NewFakeClient(inadmissible), nil)
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq-a").Cohort("team").Obj(),
+		utiltesting.MakeClusterQueue("cq-b").Obj(),
+	}
+	for _, cq := range clusterQueues {
+		if err := manager.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Failed adding clusterQueue %s: %v", cq.Name, err)
+		}
+	}
+	queues := []*kueue.LocalQueue{
+		utiltesting.MakeLocalQueue("foo", "").ClusterQueue("cq-a").Obj(),
+		utiltesting.MakeLocalQueue("bar", "").ClusterQueue("cq-b").Obj(),
+	}
+	for _, q := range queues {
+		if err := manager.AddLocalQueue(ctx, q); err != nil {
+			t.Fatalf("Failed adding queue %s: %v", q.Name, err)
+		}
+	}
+	if err := manager.AddOrUpdateWorkload(inadmissible); err != nil {
+		t.Fatalf("Failed adding workload: %v", err)
+	}
+	heads := manager.TryHeads(ctx)
+	if len(heads) != 1 {
+		t.Fatalf("Unexpected heads %v", heads)
+	}
+	if !manager.RequeueWorkload(ctx, &heads[0], RequeueReasonGeneric) {
+		t.Fatal("Failed requeueing the workload")
+	}
+	for _, w := range []*kueue.Workload{
+		utiltesting.MakeWorkload("a", "").Queue("foo").Creation(now).Obj(),
+		utiltesting.MakeWorkload("b", "").Queue("foo").Priority(10).Creation(now.Add(time.Second)).Obj(),
+	} {
+		if err := manager.AddOrUpdateWorkload(w); err != nil {
+			t.Fatalf("Failed adding workload: %v", err)
+		}
+	}
+
+	want := map[string]PendingWorkloadsDump{
+		"cq-a": {
+			Cohort: "team",
+			Pending: []WorkloadDump{
+				{Name: "/b", LocalQueue: "foo", Priority: 10},
+				{Name: "/a", LocalQueue: "foo"},
+			},
+			Inadmissible: []WorkloadDump{},
+		},
+		"cq-b": {
+			Pending: []WorkloadDump{},
+			Inadmissible: []WorkloadDump{{
+				Name:       "/c",
+				LocalQueue: "bar",
+				Message:    "couldn't assign flavors to pod set main: insufficient quota for cpu",
+			}},
+		},
+	}
+	if diff := cmp.Diff(want, manager.DumpPendingWorkloads()); diff != "" {
+		t.Errorf("Unexpected dump (-want,+got):\n%s", diff)
+	}
+}
+
 func TestGetPendingWorkloadsInfo(t *testing.T) {
 	now := time.Now().Truncate(time.Second)
 
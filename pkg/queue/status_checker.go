@@ -21,3 +21,12 @@ type StatusChecker interface {
 	// ClusterQueueActive returns whether the clusterQueue is active.
 	ClusterQueueActive(name string) bool
 }
+
+// LocalQueueShareProvider provides the weighted shares of the LocalQueues
+// of a clusterQueue, used to order its workloads.
+type LocalQueueShareProvider interface {
+	// LocalQueueShares returns the weighted share of each LocalQueue of the
+	// clusterQueue, keyed by the LocalQueue key. It returns nil if fair
+	// sharing between the LocalQueues is not enabled.
+	LocalQueueShares(cqName string) map[string]int64
+}
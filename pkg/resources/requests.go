@@ -49,6 +49,12 @@ func (r Requests) Divide(f int64) {
 	}
 }
 
+func (r Requests) Mul(f int64) {
+	for k := range r {
+		r[k] *= f
+	}
+}
+
 func (r Requests) Add(addRequests Requests) {
 	for k, v := range addRequests {
 		r[k] += v
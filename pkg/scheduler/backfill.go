@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/scheduler/trace"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// backfillEnabled returns true if the workloads queued behind a blocked head
+// of the ClusterQueue can be backfilled.
+func backfillEnabled(cq *cache.ClusterQueueSnapshot) bool {
+	return features.Enabled(features.StrictFIFOBackfill) && cq != nil && cq.BackfillMaxCandidates > 0
+}
+
+// blockedForBackfill returns true if the entry is the head of a ClusterQueue
+// with backfill enabled which couldn't be admitted for lack of quota, and
+// isn't waiting for the preemption of other workloads.
+func blockedForBackfill(e *entry, cq *cache.ClusterQueueSnapshot) bool {
+	return backfillEnabled(cq) &&
+		e.status != assumed &&
+		len(e.assignment.PodSets) > 0 &&
+		e.assignment.RepresentativeMode() != flavorassigner.Fit &&
+		len(e.preemptionTargets) == 0
+}
+
+// backfill admits the workloads queued behind the blocked heads of the
+// ClusterQueues with backfill enabled, as long as they fit the available
+// quota and are expected to finish, according to their
+// maximumExecutionTimeSeconds, before the head could be admitted.
+// It returns true if at least one workload was admitted.
+func (s *Scheduler) backfill(ctx context.Context, entries []entry, snapshot *cache.Snapshot) bool {
+	log := ctrl.LoggerFrom(ctx)
+	admitted := false
+	for i := range entries {
+		head := &entries[i]
+		cq := snapshot.ClusterQueues[head.ClusterQueue]
+		if !blockedForBackfill(head, cq) {
+			continue
+		}
+		log := log.WithValues("clusterQueue", klog.KRef("", head.ClusterQueue), "head", klog.KObj(head.Obj))
+		if !s.cache.PodsReadyForAllAdmittedWorkloads(log) {
+			log.V(5).Info("Skipping backfill while waiting for all admitted workloads to be in the PodsReady condition")
+			return admitted
+		}
+		if s.backfillHead(ctrl.LoggerInto(ctx, log), head, cq, snapshot) {
+			admitted = true
+		}
+	}
+	return admitted
+}
+
+// backfillHead admits the workloads queued behind the blocked head which are
+// expected to finish before the head could be admitted. Only they can use
+// the capacity held for the head, which is blocked again for the other
+// ClusterQueues of the Cohort once they are admitted.
+func (s *Scheduler) backfillHead(ctx context.Context, head *entry, cq *cache.ClusterQueueSnapshot, snapshot *cache.Snapshot) bool {
+	log := ctrl.LoggerFrom(ctx)
+	cq.RemoveUsage(head.reservedUsage)
+	defer cq.AddUsage(head.reservedUsage)
+
+	now := s.clock.Now()
+	reservation, found := s.headReservation(log, head, cq, snapshot, now)
+	if !found {
+		log.V(3).Info("Skipping backfill as the head admission time can't be estimated")
+		return false
+	}
+	log.V(3).Info("Backfilling the workloads expected to finish before the head", "reservation", reservation)
+	admitted := false
+	candidates := s.queues.BackfillCandidates(head.ClusterQueue, int(cq.BackfillMaxCandidates))
+	for _, candidate := range candidates {
+		if end, ok := expectedEndTime(candidate.Obj, now, now); !ok || end.After(reservation) {
+			continue
+		}
+		if s.tryBackfill(ctx, candidate, snapshot) {
+			admitted = true
+		}
+	}
+	return admitted
+}
+
+// tryBackfill admits the candidate if it fits the available quota, without
+// preemption. It returns true if the workload was assumed in the cache.
+func (s *Scheduler) tryBackfill(ctx context.Context, candidate workload.Info, snapshot *cache.Snapshot) bool {
+	candidates := s.nominate(ctx, []workload.Info{candidate}, snapshot)
+	if len(candidates) == 0 {
+		return false
+	}
+	e := &candidates[0]
+	if !s.reserveIfFits(e, snapshot) {
+		return false
+	}
+	defer s.traceAdmissionAttempt(e, trace.PhaseBackfill)
+	cq := snapshot.ClusterQueues[e.ClusterQueue]
+	log := ctrl.LoggerFrom(ctx).WithValues("workload", klog.KObj(e.Obj), "clusterQueue", klog.KRef("", e.ClusterQueue))
+	ctx = ctrl.LoggerInto(ctx, log)
+	// The workload was not popped from the queue, so it's removed before
+	// the admission, which requeues it if the admission fails.
+	s.queues.DeleteWorkload(e.Obj)
+	e.status = nominated
+	if err := s.admit(ctx, e, cq); err != nil {
+		e.inadmissibleMsg = fmt.Sprintf("Failed to admit workload: %v", err)
+		s.requeueAndUpdate(ctx, *e)
+		return false
+	}
+	log.V(2).Info("Workload backfilled")
+	return true
+}
+
+// reserveIfFits adds the usage of the entry to the snapshot if it fits the
+// available quota without preemption. It returns false otherwise.
+func (s *Scheduler) reserveIfFits(e *entry, snapshot *cache.Snapshot) bool {
+	if e.assignment.RepresentativeMode() != flavorassigner.Fit {
+		return false
+	}
+	cq := snapshot.ClusterQueues[e.ClusterQueue]
+	usage := e.netUsage()
+	restoreReservation := s.releaseReservation(e, snapshot)
+	if !cq.Fits(usage) {
+		restoreReservation(nil)
+		return false
+	}
+	var tasUsage map[kueue.ResourceFlavorReference][]workload.TopologyDomainRequests
+	if features.Enabled(features.TopologyAwareScheduling) {
+		tasUsage = e.assignment.TASUsage()
+		if !cq.FitsTAS(tasUsage) {
+			restoreReservation(nil)
+			return false
+		}
+	}
+	cq.AddUsage(usage)
+	cq.AddTASUsage(tasUsage)
+	restoreReservation(usage)
+	return true
+}
+
+// headReservation estimates the time at which the head could be admitted,
+// releasing the quota of the admitted workloads in the order of their
+// expected end time, computed from their maximumExecutionTimeSeconds. It
+// returns false if the head couldn't be admitted even when all the
+// workloads with a maximum execution time finished.
+func (s *Scheduler) headReservation(log logr.Logger, head *entry, cq *cache.ClusterQueueSnapshot, snapshot *cache.Snapshot, now time.Time) (time.Time, bool) {
+	type running struct {
+		wl  *workload.Info
+		end time.Time
+	}
+	var candidates []running
+	for _, other := range snapshot.ClusterQueues {
+		if other != cq && (!cq.HasParent() || !other.HasParent() || other.Parent().Root() != cq.Parent().Root()) {
+			continue
+		}
+		for _, wl := range other.Workloads {
+			if end, ok := expectedEndTime(wl.Obj, admissionTime(wl.Obj, now), now); ok {
+				candidates = append(candidates, running{wl: wl, end: end})
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].end.Before(candidates[j].end)
+	})
+
+	wl := head.Info
+	wl.LastAssignment = nil
+	oracle := preemption.NewOracle(s.preemptor, snapshot)
+	var released []*workload.Info
+	defer func() {
+		for _, r := range released {
+			snapshot.AddWorkload(r)
+		}
+	}()
+	for _, c := range candidates {
+		snapshot.RemoveWorkload(c.wl)
+		released = append(released, c.wl)
+		assignment := flavorassigner.New(&wl, cq, snapshot.ResourceFlavors, s.fairSharing.Enable, oracle, s.framework).Assign(log.V(6), nil)
+		if assignment.RepresentativeMode() == flavorassigner.Fit {
+			return c.end, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// admissionTime returns the time at which the workload was admitted, or now
+// if it only reserved quota.
+func admissionTime(wl *kueue.Workload, now time.Time) time.Time {
+	if c := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadAdmitted); c != nil && c.Status == metav1.ConditionTrue {
+		return c.LastTransitionTime.Time
+	}
+	return now
+}
+
+// expectedEndTime returns the time at which the workload, started at start,
+// is expected to finish according to its maximumExecutionTimeSeconds. It
+// returns false if the workload doesn't declare a maximum execution time.
+func expectedEndTime(wl *kueue.Workload, start, now time.Time) (time.Time, bool) {
+	if wl.Spec.MaximumExecutionTimeSeconds == nil {
+		return time.Time{}, false
+	}
+	remaining := *wl.Spec.MaximumExecutionTimeSeconds - ptr.Deref(wl.Status.AccumulatedPastExexcutionTimeSeconds, 0)
+	end := start.Add(time.Duration(remaining) * time.Second)
+	if end.Before(now) {
+		// The workload is about to be deactivated for exceeding its
+		// maximum execution time.
+		return now, true
+	}
+	return end, true
+}
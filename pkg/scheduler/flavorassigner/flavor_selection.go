@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flavorassigner
+
+import (
+	"math"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// flavorScore holds the criteria to select a flavor when all the flavors of
+// a resource group are evaluated.
+type flavorScore struct {
+	// preferred is true if the flavor fungibility would stop at the flavor.
+	preferred bool
+	mode      granularMode
+	borrow    bool
+	// policy is the score given by the flavorSelectionPolicy of the
+	// ClusterQueue.
+	policy int64
+	// plugins is the weighted score given by the flavorScore plugins.
+	plugins int64
+}
+
+// betterThan returns true if the flavor is better than the other flavor,
+// comparing, in order, whether they are preferred, their modes, whether they
+// need borrowing, and the scores of the policy and of the plugins. The
+// earlier flavor wins the ties.
+func (s flavorScore) betterThan(other flavorScore) bool {
+	if s.preferred != other.preferred {
+		return s.preferred
+	}
+	if s.mode != other.mode {
+		return s.mode > other.mode
+	}
+	if s.borrow != other.borrow {
+		return !s.borrow
+	}
+	if s.policy != other.policy {
+		return s.policy > other.policy
+	}
+	return s.plugins > other.plugins
+}
+
+// flavorSelectionPolicy returns the flavorSelectionPolicy of the
+// ClusterQueue, or an empty string for the DeclarationOrder policy.
+func (a *FlavorAssigner) flavorSelectionPolicy() kueue.FlavorSelectionPolicy {
+	if !features.Enabled(features.FlavorSelectionPolicy) {
+		return ""
+	}
+	return a.cq.FlavorSelectionPolicy
+}
+
+// policyScore returns the score of assigning the flavor to the requests
+// according to the policy, the higher the better.
+func (a *FlavorAssigner) policyScore(policy kueue.FlavorSelectionPolicy, flavor *kueue.ResourceFlavor, requests resources.Requests, assignmentUsage resources.FlavorResourceQuantities) int64 {
+	switch policy {
+	case kueue.LeastAllocated:
+		return -a.allocatedShare(kueue.ResourceFlavorReference(flavor.Name), requests, assignmentUsage)
+	case kueue.MostAllocated:
+		return a.allocatedShare(kueue.ResourceFlavorReference(flavor.Name), requests, assignmentUsage)
+	case kueue.LowestCost:
+		if flavor.Spec.Cost == nil {
+			return math.MinInt64
+		}
+		return -flavor.Spec.Cost.MilliValue()
+	}
+	return 0
+}
+
+// allocatedShare returns the average, across the requested resources, of the
+// share of the capacity of the flavor which would be in use after the
+// assignment, in per-mille. The capacity is the quota the ClusterQueue can
+// use, including the quota it can borrow from its Cohort, so that the usage
+// of the other ClusterQueues of the Cohort is accounted for. The resources
+// without capacity count as fully allocated.
+func (a *FlavorAssigner) allocatedShare(fName kueue.ResourceFlavorReference, requests resources.Requests, assignmentUsage resources.FlavorResourceQuantities) int64 {
+	if len(requests) == 0 {
+		return 0
+	}
+	var total int64
+	for rName, val := range requests {
+		fr := resources.FlavorResource{Flavor: fName, Resource: rName}
+		capacity := a.cq.PotentialAvailable(fr)
+		if capacity <= 0 {
+			total += 1000
+			continue
+		}
+		used := capacity - a.cq.Available(fr) + assignmentUsage[fr] + val
+		total += used * 1000 / capacity
+	}
+	return total / int64(len(requests))
+}
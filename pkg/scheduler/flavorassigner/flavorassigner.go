@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/scheduler/framework"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -127,6 +128,13 @@ func (a *Assignment) TotalRequestsFor(wl *workload.Info) resources.FlavorResourc
 type Status struct {
 	reasons []string
 	err     error
+
+	// flavors holds the details of the evaluation of each of the flavors,
+	// which are reported in the status of the workload.
+	flavors []*flavorAttempt
+	// shortages holds the quota details of the resources that don't fit
+	// in the unused quota.
+	shortages []resourceShortage
 }
 
 func (s *Status) IsError() bool {
@@ -175,7 +183,8 @@ type PodSetAssignment struct {
 	Requests corev1.ResourceList
 	Count    int32
 
-	TopologyAssignment *kueue.TopologyAssignment
+	TopologyAssignment     *kueue.TopologyAssignment
+	DelayedTopologyRequest *kueue.DelayedTopologyRequestState
 }
 
 // RepresentativeMode calculates the representative mode for this assignment as
@@ -204,11 +213,12 @@ func (psa *PodSetAssignment) toAPI() kueue.PodSetAssignment {
 		flavors[res] = flvAssignment.Name
 	}
 	return kueue.PodSetAssignment{
-		Name:               psa.Name,
-		Flavors:            flavors,
-		ResourceUsage:      psa.Requests,
-		Count:              ptr.To(psa.Count),
-		TopologyAssignment: psa.TopologyAssignment.DeepCopy(),
+		Name:                   psa.Name,
+		Flavors:                flavors,
+		ResourceUsage:          psa.Requests,
+		Count:                  ptr.To(psa.Count),
+		TopologyAssignment:     psa.TopologyAssignment.DeepCopy(),
+		DelayedTopologyRequest: psa.DelayedTopologyRequest,
 	}
 }
 
@@ -284,15 +294,17 @@ type FlavorAssigner struct {
 	resourceFlavors   map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor
 	enableFairSharing bool
 	oracle            preemptionOracle
+	framework         *framework.Framework
 }
 
-func New(wl *workload.Info, cq *cache.ClusterQueueSnapshot, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, enableFairSharing bool, oracle preemptionOracle) *FlavorAssigner {
+func New(wl *workload.Info, cq *cache.ClusterQueueSnapshot, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, enableFairSharing bool, oracle preemptionOracle, fw *framework.Framework) *FlavorAssigner {
 	return &FlavorAssigner{
 		wl:                wl,
 		cq:                cq,
 		resourceFlavors:   resourceFlavors,
 		enableFairSharing: enableFairSharing,
 		oracle:            oracle,
+		framework:         fw,
 	}
 }
 
@@ -365,7 +377,7 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 		}
 		if features.Enabled(features.TopologyAwareScheduling) {
 			if a.wl.Obj.Spec.PodSets[i].TopologyRequest != nil {
-				assignTopology(log, &psAssignment, a.cq, a.wl.TotalRequests[i], &a.wl.Obj.Spec.PodSets[i])
+				a.assignTopology(log, &psAssignment, a.wl.TotalRequests[i], &a.wl.Obj.Spec.PodSets[i])
 			}
 		}
 
@@ -385,6 +397,7 @@ func (psa *PodSetAssignment) append(flavors ResourceAssignment, status *Status)
 		psa.Status = status
 	} else if status != nil {
 		psa.Status.reasons = append(psa.Status.reasons, status.reasons...)
+		psa.Status.flavors = append(psa.Status.flavors, status.flavors...)
 	}
 }
 
@@ -428,6 +441,12 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 
 	var bestAssignment ResourceAssignment
 	bestAssignmentMode := noFit
+	// When scoring, all the flavors are evaluated, and the preferred
+	// flavors, which the flavor fungibility would stop at, win over the
+	// others.
+	policy := a.flavorSelectionPolicy()
+	scoring := policy != "" || a.framework.HasFlavorScorePlugins()
+	var bestScore flavorScore
 
 	// We will only check against the flavors' labels for the resource.
 	selector := flavorSelector(podSpec, resourceGroup.LabelKeys)
@@ -436,16 +455,17 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 	for ; idx < len(resourceGroup.Flavors); idx++ {
 		attemptedFlavorIdx = idx
 		fName := resourceGroup.Flavors[idx]
+		attempt := status.newFlavorAttempt(fName)
 		flavor, exist := a.resourceFlavors[fName]
 		if !exist {
 			log.Error(nil, "Flavor not found", "Flavor", fName)
-			status.append(fmt.Sprintf("flavor %s not found", fName))
+			status.appendForFlavor(attempt, fmt.Sprintf("flavor %s not found", fName))
 			continue
 		}
 		if features.Enabled(features.TopologyAwareScheduling) {
 			if message := checkPodSetAndFlavorMatchForTAS(a.cq, ps, flavor); message != nil {
 				log.Error(nil, *message)
-				status.append(*message)
+				status.appendForFlavor(attempt, *message)
 				continue
 			}
 		}
@@ -453,7 +473,7 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 			return t.Effect == corev1.TaintEffectNoSchedule || t.Effect == corev1.TaintEffectNoExecute
 		})
 		if untolerated {
-			status.append(fmt.Sprintf("untolerated taint %s in flavor %s", taint, fName))
+			status.appendForFlavor(attempt, fmt.Sprintf("untolerated taint %s in flavor %s", taint, fName))
 			continue
 		}
 		if match, err := selector.Match(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: flavor.Spec.NodeLabels}}); !match || err != nil {
@@ -461,7 +481,11 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 				status.err = err
 				return nil, status
 			}
-			status.append(fmt.Sprintf("flavor %s doesn't match node affinity", fName))
+			status.appendForFlavor(attempt, fmt.Sprintf("flavor %s doesn't match node affinity", fName))
+			continue
+		}
+		if reason := a.framework.FilterFlavor(a.wl, ps, flavor); reason != "" {
+			status.appendForFlavor(attempt, fmt.Sprintf("flavor %s filtered out: %s", fName, reason))
 			continue
 		}
 		needsBorrowing := false
@@ -474,12 +498,15 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 			fr := resources.FlavorResource{Flavor: fName, Resource: rName}
 			mode, borrow, s := a.fitsResourceQuota(log, fr, val+assignmentUsage[fr], resQuota)
 			if s != nil {
-				status.reasons = append(status.reasons, s.reasons...)
+				status.appendForFlavor(attempt, s.reasons...)
+				attempt.shortages = append(attempt.shortages, s.shortages...)
 			}
 			if mode < representativeMode {
 				representativeMode = mode
 			}
 			needsBorrowing = needsBorrowing || borrow
+			attempt.mode = representativeMode
+			attempt.borrow = needsBorrowing
 			if representativeMode == noFit {
 				// The flavor doesn't fit, no need to check other resources.
 				break
@@ -492,6 +519,29 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 			}
 		}
 
+		if scoring {
+			if representativeMode == noFit {
+				continue
+			}
+			preferred := representativeMode == fit
+			if features.Enabled(features.FlavorFungibility) {
+				preferred = !shouldTryNextFlavor(representativeMode, a.cq.FlavorFungibility, needsBorrowing)
+			}
+			score := flavorScore{
+				preferred: preferred,
+				mode:      representativeMode,
+				borrow:    needsBorrowing,
+				policy:    a.policyScore(policy, flavor, requests, assignmentUsage),
+				plugins:   a.framework.ScoreFlavor(a.wl, ps, flavor, requests, a.cq),
+			}
+			if bestAssignment == nil || score.betterThan(bestScore) {
+				bestAssignment = assignments
+				bestAssignmentMode = representativeMode
+				bestScore = score
+			}
+			continue
+		}
+
 		if features.Enabled(features.FlavorFungibility) {
 			if !shouldTryNextFlavor(representativeMode, a.cq.FlavorFungibility, needsBorrowing) {
 				bestAssignment = assignments
@@ -525,6 +575,9 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 			return bestAssignment, nil
 		}
 	}
+	if scoring && bestAssignmentMode == fit {
+		return bestAssignment, nil
+	}
 	return bestAssignment, status
 }
 
@@ -611,6 +664,12 @@ func (a *FlavorAssigner) fitsResourceQuota(log logr.Logger, fr resources.FlavorR
 	if val > maxCapacity {
 		status.append(fmt.Sprintf("insufficient quota for %s in flavor %s, request > maximum capacity (%s > %s)",
 			fr.Resource, fr.Flavor, resources.ResourceQuantityString(fr.Resource, val), resources.ResourceQuantityString(fr.Resource, maxCapacity)))
+		status.shortages = append(status.shortages, resourceShortage{
+			resource:    fr.Resource,
+			requested:   val,
+			available:   available,
+			maxCapacity: maxCapacity,
+		})
 		return noFit, false, &status
 	}
 
@@ -632,6 +691,13 @@ func (a *FlavorAssigner) fitsResourceQuota(log logr.Logger, fr resources.FlavorR
 
 	status.append(fmt.Sprintf("insufficient unused quota for %s in flavor %s, %s more needed",
 		fr.Resource, fr.Flavor, resources.ResourceQuantityString(fr.Resource, val-available)))
+	status.shortages = append(status.shortages, resourceShortage{
+		resource:             fr.Resource,
+		requested:            val,
+		available:            available,
+		maxCapacity:          maxCapacity,
+		preemptionConsidered: mode != noFit,
+	})
 
 	return mode, borrow, &status
 }
@@ -25,11 +25,14 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
 
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/scheduler/framework"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -2017,7 +2020,7 @@ func TestAssignFlavors(t *testing.T) {
 				secondaryClusterQueue.AddUsage(tc.secondaryClusterQueueUsage)
 			}
 
-			flvAssigner := New(wlInfo, clusterQueue, resourceFlavors, tc.enableFairSharing, &testOracle{})
+			flvAssigner := New(wlInfo, clusterQueue, resourceFlavors, tc.enableFairSharing, &testOracle{}, nil)
 			assignment := flvAssigner.Assign(log, nil)
 			if repMode := assignment.RepresentativeMode(); repMode != tc.wantRepMode {
 				t.Errorf("e.assignFlavors(_).RepresentativeMode()=%s, want %s", repMode, tc.wantRepMode)
@@ -2169,7 +2172,7 @@ func TestReclaimBeforePriorityPreemption(t *testing.T) {
 			testClusterQueue := snapshot.ClusterQueues["test-clusterqueue"]
 			testClusterQueue.AddUsage(tc.testClusterQueueUsage)
 
-			flvAssigner := New(wlInfo, testClusterQueue, resourceFlavors, false, &testOracle{})
+			flvAssigner := New(wlInfo, testClusterQueue, resourceFlavors, false, &testOracle{}, nil)
 			log := testr.NewWithOptions(t, testr.Options{Verbosity: 2})
 			assignment := flvAssigner.Assign(log, nil)
 			if gotRepMode := assignment.RepresentativeMode(); gotRepMode != tc.wantMode {
@@ -2300,7 +2303,7 @@ func TestDeletedFlavors(t *testing.T) {
 			cache.DeleteResourceFlavor(flavorMap["deleted-flavor"])
 			delete(flavorMap, "deleted-flavor")
 
-			flvAssigner := New(wlInfo, clusterQueue, flavorMap, false, &testOracle{})
+			flvAssigner := New(wlInfo, clusterQueue, flavorMap, false, &testOracle{}, nil)
 
 			assignment := flvAssigner.Assign(log, nil)
 			if repMode := assignment.RepresentativeMode(); repMode != tc.wantRepMode {
@@ -2314,6 +2317,240 @@ func TestDeletedFlavors(t *testing.T) {
 	}
 }
 
+type testFlavorPlugin struct {
+	rejected  kueue.ResourceFlavorReference
+	preferred kueue.ResourceFlavorReference
+}
+
+func (*testFlavorPlugin) Name() string { return "TestFlavor" }
+
+func (p *testFlavorPlugin) FilterFlavor(_ *workload.Info, _ *kueue.PodSet, flavor *kueue.ResourceFlavor) string {
+	if kueue.ResourceFlavorReference(flavor.Name) == p.rejected {
+		return "rejected for testing"
+	}
+	return ""
+}
+
+func (p *testFlavorPlugin) ScoreFlavor(_ *workload.Info, _ *kueue.PodSet, flavor *kueue.ResourceFlavor, _ resources.Requests, _ *cache.ClusterQueueSnapshot) int64 {
+	if kueue.ResourceFlavorReference(flavor.Name) == p.preferred {
+		return 10
+	}
+	return 0
+}
+
+func TestFrameworkPlugins(t *testing.T) {
+	cases := map[string]struct {
+		plugin       testFlavorPlugin
+		filter       bool
+		score        bool
+		wantFlavor   kueue.ResourceFlavorReference
+		wantMode     FlavorAssignmentMode
+		cpuRequested string
+	}{
+		"no plugins": {
+			cpuRequested: "2",
+			wantFlavor:   "one",
+			wantMode:     Fit,
+		},
+		"filtered flavor": {
+			plugin:       testFlavorPlugin{rejected: "one"},
+			filter:       true,
+			cpuRequested: "2",
+			wantFlavor:   "two",
+			wantMode:     Fit,
+		},
+		"highest score": {
+			plugin:       testFlavorPlugin{preferred: "three"},
+			score:        true,
+			cpuRequested: "2",
+			wantFlavor:   "three",
+			wantMode:     Fit,
+		},
+		"fitting flavor wins over a higher score": {
+			plugin:       testFlavorPlugin{preferred: "one"},
+			score:        true,
+			cpuRequested: "3",
+			wantFlavor:   "two",
+			wantMode:     Fit,
+		},
+		"highest score among the flavors passing the filter": {
+			plugin:       testFlavorPlugin{rejected: "three", preferred: "three"},
+			filter:       true,
+			score:        true,
+			cpuRequested: "2",
+			wantFlavor:   "one",
+			wantMode:     Fit,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, log := utiltesting.ContextWithLog(t)
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("one").Resource(corev1.ResourceCPU, "2").Obj(),
+					*utiltesting.MakeFlavorQuotas("two").Resource(corev1.ResourceCPU, "4").Obj(),
+					*utiltesting.MakeFlavorQuotas("three").Resource(corev1.ResourceCPU, "4").Obj(),
+				).Obj()
+			resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+				"one":   utiltesting.MakeResourceFlavor("one").Obj(),
+				"two":   utiltesting.MakeResourceFlavor("two").Obj(),
+				"three": utiltesting.MakeResourceFlavor("three").Obj(),
+			}
+			cqCache := cache.New(utiltesting.NewFakeClient())
+			for _, rf := range resourceFlavors {
+				cqCache.AddOrUpdateResourceFlavor(rf)
+			}
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache: %v", err)
+			}
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error while building snapshot: %v", err)
+			}
+
+			plugins := &config.SchedulingPlugins{}
+			if tc.filter {
+				plugins.FlavorFilter = []config.Plugin{{Name: "TestFlavor"}}
+			}
+			if tc.score {
+				plugins.FlavorScore = []config.Plugin{{Name: "TestFlavor"}}
+			}
+			plugin := tc.plugin
+			registry := framework.Registry{
+				"TestFlavor": func(runtime.RawExtension, framework.Handle) (framework.Plugin, error) {
+					return &plugin, nil
+				},
+			}
+			fw, err := registry.NewFramework(&config.Scheduling{Plugins: plugins}, framework.Handle{})
+			if err != nil {
+				t.Fatalf("Failed to create the framework: %v", err)
+			}
+
+			wlInfo := workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").
+				Request(corev1.ResourceCPU, tc.cpuRequested).
+				Obj())
+			assignment := New(wlInfo, snapshot.ClusterQueues["cq"], resourceFlavors, false, &testOracle{}, fw).Assign(log, nil)
+			if mode := assignment.RepresentativeMode(); mode != tc.wantMode {
+				t.Errorf("Unexpected mode, want=%s, got=%s", tc.wantMode, mode)
+			}
+			if got := assignment.PodSets[0].Flavors[corev1.ResourceCPU].Name; got != tc.wantFlavor {
+				t.Errorf("Unexpected flavor, want=%s, got=%s", tc.wantFlavor, got)
+			}
+		})
+	}
+}
+
+func TestFlavorSelectionPolicy(t *testing.T) {
+	cases := map[string]struct {
+		policy         kueue.FlavorSelectionPolicy
+		disableFeature bool
+		cpuRequested   string
+		// cohortUsage is the usage of another ClusterQueue of the cohort,
+		// borrowed from the ClusterQueue under test.
+		cohortUsage resources.FlavorResourceQuantities
+		wantFlavor  kueue.ResourceFlavorReference
+	}{
+		"declaration order": {
+			policy:       kueue.DeclarationOrder,
+			cpuRequested: "2",
+			wantFlavor:   "one",
+		},
+		"least allocated": {
+			policy:       kueue.LeastAllocated,
+			cpuRequested: "2",
+			wantFlavor:   "three",
+		},
+		"least allocated in the cohort": {
+			policy:       kueue.LeastAllocated,
+			cpuRequested: "2",
+			cohortUsage: resources.FlavorResourceQuantities{
+				{Flavor: "three", Resource: corev1.ResourceCPU}: 7_000,
+			},
+			wantFlavor: "two",
+		},
+		"most allocated": {
+			policy:       kueue.MostAllocated,
+			cpuRequested: "2",
+			wantFlavor:   "one",
+		},
+		"most allocated among the flavors that fit": {
+			policy:       kueue.MostAllocated,
+			cpuRequested: "5",
+			wantFlavor:   "two",
+		},
+		"lowest cost": {
+			policy:       kueue.LowestCost,
+			cpuRequested: "2",
+			wantFlavor:   "two",
+		},
+		"feature disabled": {
+			policy:         kueue.LeastAllocated,
+			disableFeature: true,
+			cpuRequested:   "2",
+			wantFlavor:     "one",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.FlavorSelectionPolicy, !tc.disableFeature)
+			ctx, log := utiltesting.ContextWithLog(t)
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("one").Resource(corev1.ResourceCPU, "10").Obj(),
+					*utiltesting.MakeFlavorQuotas("two").Resource(corev1.ResourceCPU, "10").Obj(),
+					*utiltesting.MakeFlavorQuotas("three").Resource(corev1.ResourceCPU, "10").Obj(),
+				).
+				FlavorSelectionPolicy(tc.policy).
+				Cohort("team").
+				Obj()
+			otherCQ := utiltesting.MakeClusterQueue("other").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("one").Resource(corev1.ResourceCPU, "0").Obj(),
+					*utiltesting.MakeFlavorQuotas("two").Resource(corev1.ResourceCPU, "0").Obj(),
+					*utiltesting.MakeFlavorQuotas("three").Resource(corev1.ResourceCPU, "0").Obj(),
+				).
+				Cohort("team").
+				Obj()
+			resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+				"one":   utiltesting.MakeResourceFlavor("one").Cost("3").Obj(),
+				"two":   utiltesting.MakeResourceFlavor("two").Cost("1.5").Obj(),
+				"three": utiltesting.MakeResourceFlavor("three").Obj(),
+			}
+			cqCache := cache.New(utiltesting.NewFakeClient())
+			for _, rf := range resourceFlavors {
+				cqCache.AddOrUpdateResourceFlavor(rf)
+			}
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache: %v", err)
+			}
+			if err := cqCache.AddClusterQueue(ctx, otherCQ); err != nil {
+				t.Fatalf("Failed to add CQ to cache: %v", err)
+			}
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error while building snapshot: %v", err)
+			}
+			snapshot.ClusterQueues["other"].AddUsage(tc.cohortUsage)
+			cqSnapshot := snapshot.ClusterQueues["cq"]
+			cqSnapshot.AddUsage(resources.FlavorResourceQuantities{
+				{Flavor: "one", Resource: corev1.ResourceCPU}: 6_000,
+				{Flavor: "two", Resource: corev1.ResourceCPU}: 2_000,
+			})
+
+			wlInfo := workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").
+				Request(corev1.ResourceCPU, tc.cpuRequested).
+				Obj())
+			assignment := New(wlInfo, cqSnapshot, resourceFlavors, false, &testOracle{}, nil).Assign(log, nil)
+			if mode := assignment.RepresentativeMode(); mode != Fit {
+				t.Errorf("Unexpected mode, want=%s, got=%s", Fit, mode)
+			}
+			if got := assignment.PodSets[0].Flavors[corev1.ResourceCPU].Name; got != tc.wantFlavor {
+				t.Errorf("Unexpected flavor, want=%s, got=%s", tc.wantFlavor, got)
+			}
+		})
+	}
+}
+
 func TestLastAssignmentOutdated(t *testing.T) {
 	type args struct {
 		wl *workload.Info
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flavorassigner
+
+import (
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// flavorAttempt holds the outcome of evaluating a flavor for the resources
+// of a resource group.
+type flavorAttempt struct {
+	name      kueue.ResourceFlavorReference
+	mode      granularMode
+	borrow    bool
+	reasons   []string
+	shortages []resourceShortage
+}
+
+// resourceShortage holds the quota details of a resource that doesn't fit
+// in the unused quota of a flavor.
+type resourceShortage struct {
+	resource             corev1.ResourceName
+	requested            int64
+	available            int64
+	maxCapacity          int64
+	preemptionConsidered bool
+}
+
+func (s *Status) newFlavorAttempt(name kueue.ResourceFlavorReference) *flavorAttempt {
+	attempt := &flavorAttempt{name: name}
+	s.flavors = append(s.flavors, attempt)
+	return attempt
+}
+
+func (s *Status) appendForFlavor(attempt *flavorAttempt, r ...string) {
+	s.append(r...)
+	attempt.reasons = append(attempt.reasons, r...)
+}
+
+func (mode granularMode) schedulingAttemptMode() kueue.FlavorSchedulingAttemptMode {
+	switch mode.flavorAssignmentMode() {
+	case Fit:
+		return kueue.FlavorSchedulingAttemptFit
+	case Preempt:
+		return kueue.FlavorSchedulingAttemptPreempt
+	default:
+		return kueue.FlavorSchedulingAttemptNoFit
+	}
+}
+
+// SchedulingAttemptToAPI returns the structured details of the assignment for
+// the pod sets that couldn't be assigned flavors immediately.
+// It returns nil if all the pod sets fit.
+func (a *Assignment) SchedulingAttemptToAPI(cqName kueue.ClusterQueueReference) *kueue.SchedulingAttempt {
+	var podSets []kueue.PodSetSchedulingAttempt
+	for i := range a.PodSets {
+		if ps := a.PodSets[i].schedulingAttemptToAPI(); ps != nil {
+			podSets = append(podSets, *ps)
+		}
+	}
+	if len(podSets) == 0 {
+		return nil
+	}
+	return &kueue.SchedulingAttempt{
+		ClusterQueue: cqName,
+		PodSets:      podSets,
+	}
+}
+
+func (psa *PodSetAssignment) schedulingAttemptToAPI() *kueue.PodSetSchedulingAttempt {
+	if psa.Status == nil {
+		return nil
+	}
+	result := &kueue.PodSetSchedulingAttempt{Name: psa.Name}
+	if psa.Status.err != nil {
+		result.Reasons = []string{psa.Status.err.Error()}
+		return result
+	}
+	flavorReasons := sets.New[string]()
+	for _, attempt := range psa.Status.flavors {
+		flavorReasons.Insert(attempt.reasons...)
+		result.Flavors = append(result.Flavors, attempt.toAPI())
+	}
+	for _, r := range psa.Status.reasons {
+		if !flavorReasons.Has(r) {
+			result.Reasons = append(result.Reasons, r)
+		}
+	}
+	sort.Strings(result.Reasons)
+	result.Reasons = slices.Compact(result.Reasons)
+	if len(result.Flavors) == 0 && len(result.Reasons) == 0 {
+		return nil
+	}
+	return result
+}
+
+func (fa *flavorAttempt) toAPI() kueue.FlavorSchedulingAttempt {
+	result := kueue.FlavorSchedulingAttempt{
+		Name:      fa.name,
+		Mode:      fa.mode.schedulingAttemptMode(),
+		Borrowing: fa.borrow,
+		Reasons:   slices.Sorted(slices.Values(fa.reasons)),
+	}
+	for _, s := range fa.shortages {
+		result.PreemptionConsidered = result.PreemptionConsidered || s.preemptionConsidered
+		available := max(0, s.available)
+		result.Resources = append(result.Resources, kueue.ResourceSchedulingAttempt{
+			Name:        s.resource,
+			Requested:   resources.ResourceQuantity(s.resource, s.requested),
+			Available:   resources.ResourceQuantity(s.resource, available),
+			Missing:     resources.ResourceQuantity(s.resource, max(0, s.requested-available)),
+			MaxCapacity: resources.ResourceQuantity(s.resource, s.maxCapacity),
+		})
+	}
+	sort.Slice(result.Resources, func(i, j int) bool {
+		return result.Resources[i].Name < result.Resources[j].Name
+	})
+	return result
+}
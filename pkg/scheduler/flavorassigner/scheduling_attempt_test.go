@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flavorassigner
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestSchedulingAttemptToAPI(t *testing.T) {
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"one": utiltesting.MakeResourceFlavor("one").Obj(),
+		"two": utiltesting.MakeResourceFlavor("two").Obj(),
+		"tainted": utiltesting.MakeResourceFlavor("tainted").
+			Taint(corev1.Taint{
+				Key:    "instance",
+				Value:  "spot",
+				Effect: corev1.TaintEffectNoSchedule,
+			}).Obj(),
+	}
+	clusterQueue := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("tainted").Resource(corev1.ResourceCPU, "10").Obj(),
+			*utiltesting.MakeFlavorQuotas("one").Resource(corev1.ResourceCPU, "4").Obj(),
+			*utiltesting.MakeFlavorQuotas("two").Resource(corev1.ResourceCPU, "2").Obj(),
+		).
+		Obj()
+	usage := resources.FlavorResourceQuantities{
+		{Flavor: "one", Resource: corev1.ResourceCPU}: 2_000,
+	}
+
+	cases := map[string]struct {
+		podSets []kueue.PodSet
+		want    *kueue.SchedulingAttempt
+	}{
+		"all pod sets fit": {
+			podSets: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 1).Request(corev1.ResourceCPU, "1").Obj(),
+			},
+		},
+		"flavors don't fit": {
+			podSets: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 1).Request(corev1.ResourceCPU, "4").Obj(),
+				*utiltesting.MakePodSet("gpu", 1).Request("example.com/gpu", "1").Obj(),
+			},
+			want: &kueue.SchedulingAttempt{
+				ClusterQueue: "cq",
+				PodSets: []kueue.PodSetSchedulingAttempt{
+					{
+						Name: "main",
+						Flavors: []kueue.FlavorSchedulingAttempt{
+							{
+								Name:    "tainted",
+								Mode:    kueue.FlavorSchedulingAttemptNoFit,
+								Reasons: []string{"untolerated taint {instance spot NoSchedule <nil>} in flavor tainted"},
+							},
+							{
+								Name:                 "one",
+								Mode:                 kueue.FlavorSchedulingAttemptPreempt,
+								PreemptionConsidered: true,
+								Resources: []kueue.ResourceSchedulingAttempt{{
+									Name:        corev1.ResourceCPU,
+									Requested:   resource.MustParse("4"),
+									Available:   resource.MustParse("2"),
+									Missing:     resource.MustParse("2"),
+									MaxCapacity: resource.MustParse("4"),
+								}},
+								Reasons: []string{"insufficient unused quota for cpu in flavor one, 2 more needed"},
+							},
+							{
+								Name: "two",
+								Mode: kueue.FlavorSchedulingAttemptNoFit,
+								Resources: []kueue.ResourceSchedulingAttempt{{
+									Name:        corev1.ResourceCPU,
+									Requested:   resource.MustParse("4"),
+									Available:   resource.MustParse("2"),
+									Missing:     resource.MustParse("2"),
+									MaxCapacity: resource.MustParse("2"),
+								}},
+								Reasons: []string{"insufficient quota for cpu in flavor two, request > maximum capacity (4 > 2)"},
+							},
+						},
+					},
+					{
+						Name:    "gpu",
+						Reasons: []string{"resource example.com/gpu unavailable in ClusterQueue"},
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			log := testr.New(t)
+			wlInfo := workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").PodSets(tc.podSets...).Obj())
+
+			cache := cache.New(utiltesting.NewFakeClient())
+			if err := cache.AddClusterQueue(ctx, clusterQueue); err != nil {
+				t.Fatalf("Failed to add CQ to cache: %v", err)
+			}
+			for _, rf := range resourceFlavors {
+				cache.AddOrUpdateResourceFlavor(rf)
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error while building snapshot: %v", err)
+			}
+			cqSnapshot := snapshot.ClusterQueues[clusterQueue.Name]
+			cqSnapshot.AddUsage(usage)
+
+			assignment := New(wlInfo, cqSnapshot, resourceFlavors, false, &testOracle{}, nil).Assign(log, nil)
+			got := assignment.SchedulingAttemptToAPI(kueue.ClusterQueueReference(clusterQueue.Name))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected scheduling attempt (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -25,14 +25,15 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
-func assignTopology(log logr.Logger,
+func (a *FlavorAssigner) assignTopology(log logr.Logger,
 	psAssignment *PodSetAssignment,
-	cq *cache.ClusterQueueSnapshot,
 	psResources workload.PodSetResources,
 	podSet *kueue.PodSet) {
+	cq := a.cq
 	switch {
 	case psAssignment.Status.IsError():
 		log.V(2).Info("There is no resource quota assignment for the workload. No need to check TAS.", "message", psAssignment.Status.Message())
@@ -64,6 +65,20 @@ func assignTopology(log logr.Logger,
 			psAssignment.Flavors = nil
 			return
 		}
+		if cq.HasMultiKueueAdmissionCheck() {
+			// The workload is dispatched to a worker cluster, which computes
+			// the topology assignment against its own nodes.
+			log.V(3).Info("TAS PodSet assignment skipped as the topology is assigned by the MultiKueue worker cluster")
+			return
+		}
+		if cq.HasProvRequestAdmissionCheck(*tasFlvr) {
+			// The nodes are going to be provisioned by the ProvisioningRequest,
+			// so the topology assignment is computed once the AdmissionCheck
+			// is Ready.
+			psAssignment.DelayedTopologyRequest = ptr.To(kueue.DelayedTopologyRequestStatePending)
+			log.V(3).Info("TAS PodSet assignment delayed until the nodes are provisioned")
+			return
+		}
 		var reason string
 		psAssignment.TopologyAssignment, reason = snapshot.FindTopologyAssignment(podSet.TopologyRequest,
 			singlePodRequests, podCount, podSet.Template.Spec.Tolerations)
@@ -72,12 +87,87 @@ func assignTopology(log logr.Logger,
 				psAssignment.Status = &Status{}
 			}
 			psAssignment.Status.append(reason)
-			psAssignment.Flavors = nil
+			if a.canPreemptForTopology(psAssignment, psResources.Requests) {
+				// The topology domains might be freed by preempting other
+				// workloads. The preemptor verifies that the topology
+				// assignment is found once the targets are evicted.
+				for _, flvAssignment := range psAssignment.Flavors {
+					flvAssignment.Mode = min(flvAssignment.Mode, Preempt)
+				}
+			} else {
+				psAssignment.Flavors = nil
+			}
 		}
 		log.Info("TAS PodSet assignment", "tasAssignment", psAssignment.TopologyAssignment)
 	}
 }
 
+// TASUsage returns the topology usage of the assignment per TAS flavor.
+func (a *Assignment) TASUsage() map[kueue.ResourceFlavorReference][]workload.TopologyDomainRequests {
+	result := make(map[kueue.ResourceFlavorReference][]workload.TopologyDomainRequests)
+	for _, psa := range a.PodSets {
+		if psa.TopologyAssignment == nil || psa.Count == 0 {
+			continue
+		}
+		tasFlvr, err := onlyFlavor(psa.Flavors)
+		if err != nil {
+			continue
+		}
+		singlePodRequests := resources.NewRequests(psa.Requests)
+		singlePodRequests.Divide(int64(psa.Count))
+		result[*tasFlvr] = append(result[*tasFlvr], workload.TopologyDomainRequestsFor(psa.TopologyAssignment, singlePodRequests)...)
+	}
+	return result
+}
+
+// WorkloadsTopologyRequests returns the topology requests of the PodSets of
+// the workload which require Topology Aware Scheduling, for the flavors of
+// the assignment.
+func (a *Assignment) WorkloadsTopologyRequests(wl *workload.Info) []cache.TASPodSetRequests {
+	var result []cache.TASPodSetRequests
+	for i, psa := range a.PodSets {
+		if i >= len(wl.Obj.Spec.PodSets) || psa.Count == 0 {
+			continue
+		}
+		podSet := &wl.Obj.Spec.PodSets[i]
+		if podSet.TopologyRequest == nil || psa.DelayedTopologyRequest != nil {
+			continue
+		}
+		tasFlvr, err := onlyFlavor(psa.Flavors)
+		if err != nil {
+			continue
+		}
+		singlePodRequests := resources.NewRequests(psa.Requests)
+		singlePodRequests.Divide(int64(psa.Count))
+		result = append(result, cache.TASPodSetRequests{
+			PodSet:            podSet,
+			SinglePodRequests: singlePodRequests,
+			Count:             psa.Count,
+			Flavor:            *tasFlvr,
+		})
+	}
+	return result
+}
+
+// canPreemptForTopology returns true if the ClusterQueue could preempt
+// workloads to free the topology domains, either within the ClusterQueue
+// or within the cohort. As for the quota, preempting while the requests
+// exceed the nominal quota is only allowed by the borrowWithinCohort policy.
+func (a *FlavorAssigner) canPreemptForTopology(psAssignment *PodSetAssignment, requests resources.Requests) bool {
+	cq := a.cq
+	if cq.Preemption.WithinClusterQueue == kueue.PreemptionPolicyNever &&
+		(!cq.HasParent() || cq.Preemption.ReclaimWithinCohort == kueue.PreemptionPolicyNever) {
+		return false
+	}
+	for rName, flvAssignment := range psAssignment.Flavors {
+		fr := resources.FlavorResource{Flavor: flvAssignment.Name, Resource: rName}
+		if requests[rName] > cq.QuotaFor(fr).Nominal {
+			return a.canPreemptWhileBorrowing()
+		}
+	}
+	return true
+}
+
 func onlyFlavor(ra ResourceAssignment) (*kueue.ResourceFlavorReference, error) {
 	var result *kueue.ResourceFlavorReference
 	for _, v := range ra {
@@ -0,0 +1,241 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+var (
+	errDuplicatePluginName = errors.New("duplicate plugin name")
+	errPluginNotFound      = errors.New("plugin not found")
+	errExtensionPoint      = errors.New("plugin doesn't implement the extension point")
+)
+
+// Registry maps the plugin names to their factories.
+type Registry map[string]PluginFactory
+
+var (
+	registryMu sync.Mutex
+	registry   = Registry{}
+)
+
+// RegisterPlugin registers the factory of a plugin, so that it can be enabled
+// through the Configuration API. It is meant to be called from the init
+// function of the package implementing the plugin.
+func RegisterPlugin(name string, factory PluginFactory) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[name]; exists {
+		return fmt.Errorf("%w %q", errDuplicatePluginName, name)
+	}
+	registry[name] = factory
+	return nil
+}
+
+// New creates the framework with the plugins registered with RegisterPlugin.
+func New(cfg *config.Scheduling, h Handle) (*Framework, error) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return registry.NewFramework(cfg, h)
+}
+
+type weightedFlavorScorePlugin struct {
+	FlavorScorePlugin
+	weight int64
+}
+
+// Framework runs the plugins enabled at each extension point. A nil
+// Framework runs no plugins.
+type Framework struct {
+	queueSort       []QueueSortPlugin
+	flavorFilter    []FlavorFilterPlugin
+	flavorScore     []weightedFlavorScorePlugin
+	preemptionOrder []PreemptionOrderPlugin
+	postAdmission   []PostAdmissionPlugin
+}
+
+// NewFramework instantiates the plugins enabled in the configuration. A
+// plugin enabled at several extension points is instantiated once.
+func (r Registry) NewFramework(cfg *config.Scheduling, h Handle) (*Framework, error) {
+	fw := &Framework{}
+	if cfg == nil || cfg.Plugins == nil {
+		return fw, nil
+	}
+	args := make(map[string]runtime.RawExtension, len(cfg.PluginConfig))
+	for _, pc := range cfg.PluginConfig {
+		args[pc.Name] = pc.Args
+	}
+	instances := make(map[string]Plugin)
+	get := func(name string) (Plugin, error) {
+		if p, found := instances[name]; found {
+			return p, nil
+		}
+		factory, found := r[name]
+		if !found {
+			return nil, fmt.Errorf("%w %q", errPluginNotFound, name)
+		}
+		p, err := factory(args[name], h)
+		if err != nil {
+			return nil, fmt.Errorf("creating the plugin %q: %w", name, err)
+		}
+		instances[name] = p
+		return p, nil
+	}
+
+	for _, ep := range cfg.Plugins.QueueSort {
+		p, err := get(ep.Name)
+		if err != nil {
+			return nil, err
+		}
+		qs, ok := p.(QueueSortPlugin)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q, queueSort", errExtensionPoint, ep.Name)
+		}
+		fw.queueSort = append(fw.queueSort, qs)
+	}
+	for _, ep := range cfg.Plugins.FlavorFilter {
+		p, err := get(ep.Name)
+		if err != nil {
+			return nil, err
+		}
+		ff, ok := p.(FlavorFilterPlugin)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q, flavorFilter", errExtensionPoint, ep.Name)
+		}
+		fw.flavorFilter = append(fw.flavorFilter, ff)
+	}
+	for _, ep := range cfg.Plugins.FlavorScore {
+		p, err := get(ep.Name)
+		if err != nil {
+			return nil, err
+		}
+		fs, ok := p.(FlavorScorePlugin)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q, flavorScore", errExtensionPoint, ep.Name)
+		}
+		fw.flavorScore = append(fw.flavorScore, weightedFlavorScorePlugin{
+			FlavorScorePlugin: fs,
+			weight:            int64(ptr.Deref(ep.Weight, 1)),
+		})
+	}
+	for _, ep := range cfg.Plugins.PreemptionOrder {
+		p, err := get(ep.Name)
+		if err != nil {
+			return nil, err
+		}
+		po, ok := p.(PreemptionOrderPlugin)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q, preemptionOrder", errExtensionPoint, ep.Name)
+		}
+		fw.preemptionOrder = append(fw.preemptionOrder, po)
+	}
+	for _, ep := range cfg.Plugins.PostAdmission {
+		p, err := get(ep.Name)
+		if err != nil {
+			return nil, err
+		}
+		pa, ok := p.(PostAdmissionPlugin)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q, postAdmission", errExtensionPoint, ep.Name)
+		}
+		fw.postAdmission = append(fw.postAdmission, pa)
+	}
+	return fw, nil
+}
+
+// CompareWorkloads returns the result of the first queueSort plugin with a
+// preference between a and b.
+func (f *Framework) CompareWorkloads(a, b *workload.Info) int {
+	if f == nil {
+		return 0
+	}
+	for _, p := range f.queueSort {
+		if c := p.Compare(a, b); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// FilterFlavor returns the reason of the first flavorFilter plugin rejecting
+// the flavor, or an empty string if all of them accept it.
+func (f *Framework) FilterFlavor(wl *workload.Info, podSet *kueue.PodSet, flavor *kueue.ResourceFlavor) string {
+	if f == nil {
+		return ""
+	}
+	for _, p := range f.flavorFilter {
+		if reason := p.FilterFlavor(wl, podSet, flavor); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+// HasFlavorScorePlugins returns true if any flavorScore plugin is enabled.
+func (f *Framework) HasFlavorScorePlugins() bool {
+	return f != nil && len(f.flavorScore) > 0
+}
+
+// ScoreFlavor returns the sum of the weighted scores of the flavorScore
+// plugins.
+func (f *Framework) ScoreFlavor(wl *workload.Info, podSet *kueue.PodSet, flavor *kueue.ResourceFlavor, requests resources.Requests, cq *cache.ClusterQueueSnapshot) int64 {
+	if f == nil {
+		return 0
+	}
+	var score int64
+	for _, p := range f.flavorScore {
+		score += p.weight * p.ScoreFlavor(wl, podSet, flavor, requests, cq)
+	}
+	return score
+}
+
+// ComparePreemptionCandidates returns the result of the first
+// preemptionOrder plugin with a preference between a and b.
+func (f *Framework) ComparePreemptionCandidates(a, b *workload.Info) int {
+	if f == nil {
+		return 0
+	}
+	for _, p := range f.preemptionOrder {
+		if c := p.Compare(a, b); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// PostAdmit runs the postAdmission plugins.
+func (f *Framework) PostAdmit(ctx context.Context, wl *kueue.Workload) {
+	if f == nil {
+		return
+	}
+	for _, p := range f.postAdmission {
+		p.PostAdmit(ctx, wl)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// byNamePlugin orders the workloads by name, and scores the flavors by the
+// length of their name.
+type byNamePlugin struct {
+	args string
+}
+
+func (*byNamePlugin) Name() string { return "ByName" }
+
+func (*byNamePlugin) Compare(a, b *workload.Info) int {
+	switch {
+	case a.Obj.Name < b.Obj.Name:
+		return -1
+	case a.Obj.Name > b.Obj.Name:
+		return 1
+	}
+	return 0
+}
+
+func (*byNamePlugin) ScoreFlavor(_ *workload.Info, _ *kueue.PodSet, flavor *kueue.ResourceFlavor, _ resources.Requests, _ *cache.ClusterQueueSnapshot) int64 {
+	return int64(len(flavor.Name))
+}
+
+// rejectPlugin rejects the flavors named in its args.
+type rejectPlugin struct {
+	flavor string
+}
+
+func (*rejectPlugin) Name() string { return "Reject" }
+
+func (p *rejectPlugin) FilterFlavor(_ *workload.Info, _ *kueue.PodSet, flavor *kueue.ResourceFlavor) string {
+	if flavor.Name == p.flavor {
+		return "rejected by the Reject plugin"
+	}
+	return ""
+}
+
+func testRegistry() Registry {
+	return Registry{
+		"ByName": func(args runtime.RawExtension, _ Handle) (Plugin, error) {
+			return &byNamePlugin{args: string(args.Raw)}, nil
+		},
+		"Reject": func(args runtime.RawExtension, _ Handle) (Plugin, error) {
+			return &rejectPlugin{flavor: string(args.Raw)}, nil
+		},
+		"Broken": func(runtime.RawExtension, Handle) (Plugin, error) {
+			return nil, errors.New("broken")
+		},
+	}
+}
+
+func TestNewFramework(t *testing.T) {
+	cases := map[string]struct {
+		cfg     *config.Scheduling
+		wantErr error
+	}{
+		"no configuration": {},
+		"plugins enabled at their extension points": {
+			cfg: &config.Scheduling{
+				Plugins: &config.SchedulingPlugins{
+					QueueSort:    []config.Plugin{{Name: "ByName"}},
+					FlavorScore:  []config.Plugin{{Name: "ByName", Weight: ptr.To[int32](2)}},
+					FlavorFilter: []config.Plugin{{Name: "Reject"}},
+				},
+			},
+		},
+		"unknown plugin": {
+			cfg: &config.Scheduling{
+				Plugins: &config.SchedulingPlugins{
+					QueueSort: []config.Plugin{{Name: "Unknown"}},
+				},
+			},
+			wantErr: errPluginNotFound,
+		},
+		"plugin not implementing the extension point": {
+			cfg: &config.Scheduling{
+				Plugins: &config.SchedulingPlugins{
+					PostAdmission: []config.Plugin{{Name: "Reject"}},
+				},
+			},
+			wantErr: errExtensionPoint,
+		},
+		"plugin failing to be created": {
+			cfg: &config.Scheduling{
+				Plugins: &config.SchedulingPlugins{
+					FlavorFilter: []config.Plugin{{Name: "Broken"}},
+				},
+			},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := testRegistry().NewFramework(tc.cfg, Handle{})
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Unexpected error (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFrameworkPlugins(t *testing.T) {
+	fw, err := testRegistry().NewFramework(&config.Scheduling{
+		Plugins: &config.SchedulingPlugins{
+			QueueSort:       []config.Plugin{{Name: "ByName"}},
+			FlavorFilter:    []config.Plugin{{Name: "Reject"}},
+			FlavorScore:     []config.Plugin{{Name: "ByName", Weight: ptr.To[int32](3)}},
+			PreemptionOrder: []config.Plugin{{Name: "ByName"}},
+		},
+		PluginConfig: []config.PluginConfig{
+			{Name: "Reject", Args: runtime.RawExtension{Raw: []byte("spot")}},
+		},
+	}, Handle{})
+	if err != nil {
+		t.Fatalf("Failed to create the framework: %v", err)
+	}
+
+	a := workload.NewInfo(utiltesting.MakeWorkload("a", "ns").Obj())
+	b := workload.NewInfo(utiltesting.MakeWorkload("b", "ns").Obj())
+	if got := fw.CompareWorkloads(a, b); got >= 0 {
+		t.Errorf("Unexpected CompareWorkloads(a, b) = %d, want negative", got)
+	}
+	if got := fw.ComparePreemptionCandidates(b, a); got <= 0 {
+		t.Errorf("Unexpected ComparePreemptionCandidates(b, a) = %d, want positive", got)
+	}
+
+	spot := utiltesting.MakeResourceFlavor("spot").Obj()
+	onDemand := utiltesting.MakeResourceFlavor("on-demand").Obj()
+	if got := fw.FilterFlavor(a, nil, spot); got == "" {
+		t.Error("Expected the spot flavor to be rejected")
+	}
+	if got := fw.FilterFlavor(a, nil, onDemand); got != "" {
+		t.Errorf("Unexpected rejection of the on-demand flavor: %s", got)
+	}
+
+	if !fw.HasFlavorScorePlugins() {
+		t.Error("Expected flavorScore plugins to be enabled")
+	}
+	if got := fw.ScoreFlavor(a, nil, onDemand, nil, nil); got != 27 {
+		t.Errorf("Unexpected ScoreFlavor = %d, want 27", got)
+	}
+}
+
+func TestNilFramework(t *testing.T) {
+	var fw *Framework
+	a := workload.NewInfo(utiltesting.MakeWorkload("a", "ns").Obj())
+	if got := fw.CompareWorkloads(a, a); got != 0 {
+		t.Errorf("Unexpected CompareWorkloads = %d", got)
+	}
+	if got := fw.FilterFlavor(a, nil, utiltesting.MakeResourceFlavor("rf").Obj()); got != "" {
+		t.Errorf("Unexpected FilterFlavor = %q", got)
+	}
+	if fw.HasFlavorScorePlugins() {
+		t.Error("Unexpected flavorScore plugins")
+	}
+}
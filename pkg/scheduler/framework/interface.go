@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// Plugin is the parent type of all the scheduling plugins. The plugins are
+// called concurrently for the workloads of different Cohort trees, when they
+// are scheduled in parallel, so they must be safe for concurrent use.
+type Plugin interface {
+	// Name returns the name under which the plugin is registered.
+	Name() string
+}
+
+// QueueSortPlugin orders the pending workloads when the built-in criteria,
+// up to the priority, tie. It is used both to order the workloads within a
+// ClusterQueue, and the heads of the ClusterQueues in a scheduling cycle.
+type QueueSortPlugin interface {
+	Plugin
+	// Compare returns a negative number if a should be admitted before b, a
+	// positive number if b should be admitted before a, or zero if the plugin
+	// has no preference.
+	Compare(a, b *workload.Info) int
+}
+
+// FlavorFilterPlugin excludes the flavors which can't be assigned to a pod
+// set, in addition to the built-in taints and node affinity checks.
+type FlavorFilterPlugin interface {
+	Plugin
+	// FilterFlavor returns the reason why the flavor can't be assigned to the
+	// pod set, or an empty string if it can.
+	FilterFlavor(wl *workload.Info, podSet *kueue.PodSet, flavor *kueue.ResourceFlavor) string
+}
+
+// FlavorScorePlugin scores the flavors which can be assigned to the resources
+// of a pod set. When score plugins are enabled, the flavor assigner evaluates
+// all the flavors of the resource group, and picks the one with the highest
+// weighted score among the ones with the best assignment mode.
+type FlavorScorePlugin interface {
+	Plugin
+	// ScoreFlavor returns the score of assigning the flavor to the requests,
+	// which hold the requests of the pod set covered by the resource group.
+	ScoreFlavor(wl *workload.Info, podSet *kueue.PodSet, flavor *kueue.ResourceFlavor, requests resources.Requests, cq *cache.ClusterQueueSnapshot) int64
+}
+
+// PreemptionOrderPlugin orders the preemption candidates when the built-in
+// criteria, up to the priority, tie.
+type PreemptionOrderPlugin interface {
+	Plugin
+	// Compare returns a negative number if a should be preempted before b, a
+	// positive number if b should be preempted before a, or zero if the plugin
+	// has no preference.
+	Compare(a, b *workload.Info) int
+}
+
+// PostAdmissionPlugin is notified of the workloads which reserved quota.
+type PostAdmissionPlugin interface {
+	Plugin
+	// PostAdmit is called once the quota reservation of the workload was
+	// applied in the API server.
+	PostAdmit(ctx context.Context, wl *kueue.Workload)
+}
+
+// Handle provides the plugins with access to the cluster.
+type Handle struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+}
+
+// PluginFactory creates a plugin from its arguments, which are empty if the
+// plugin isn't listed in the pluginConfig.
+type PluginFactory func(args runtime.RawExtension, h Handle) (Plugin, error)
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/trace"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// admitMore admits more workloads from the ClusterQueues whose head was
+// admitted in the cycle, up to maxAdmissionsPerCycle workloads per
+// ClusterQueue. In each round, the next workload of each ClusterQueue is
+// nominated, and the entries are admitted in the same order as the heads.
+// A ClusterQueue stops at the first workload which doesn't fit the available
+// quota without preemption.
+// It returns true if at least one workload was admitted.
+func (s *Scheduler) admitMore(ctx context.Context, heads []entry, snapshot *cache.Snapshot) bool {
+	if s.maxAdmissionsPerCycle <= 1 {
+		return false
+	}
+	var cqNames []string
+	for i := range heads {
+		if heads[i].status == assumed {
+			cqNames = append(cqNames, heads[i].ClusterQueue)
+		}
+	}
+	log := ctrl.LoggerFrom(ctx)
+	admitted := false
+	for count := int32(1); count < s.maxAdmissionsPerCycle && len(cqNames) > 0; count++ {
+		if !s.cache.PodsReadyForAllAdmittedWorkloads(log) {
+			log.V(5).Info("Skipping further admissions while waiting for all admitted workloads to be in the PodsReady condition")
+			break
+		}
+		workloads := make([]workload.Info, 0, len(cqNames))
+		for _, cqName := range cqNames {
+			if wl := s.queues.Pop(cqName); wl != nil {
+				workloads = append(workloads, *wl)
+			}
+		}
+		entries := s.nominate(ctx, workloads, snapshot)
+		s.sortEntries(entries)
+		cqNames = cqNames[:0]
+		for i := range entries {
+			if s.admitNext(ctx, &entries[i], snapshot) {
+				admitted = true
+				cqNames = append(cqNames, entries[i].ClusterQueue)
+			}
+		}
+	}
+	return admitted
+}
+
+// admitNext admits the entry if it fits the available quota without
+// preemption. Otherwise, the workload is requeued. It returns true if the
+// workload was assumed in the cache.
+func (s *Scheduler) admitNext(ctx context.Context, e *entry, snapshot *cache.Snapshot) bool {
+	defer logAdmissionAttemptIfVerbose(ctrl.LoggerFrom(ctx), e)
+	defer s.traceAdmissionAttempt(e, trace.PhaseAdmitMore)
+	log := ctrl.LoggerFrom(ctx).WithValues("workload", klog.KObj(e.Obj), "clusterQueue", klog.KRef("", e.ClusterQueue))
+	ctx = ctrl.LoggerInto(ctx, log)
+	if !s.reserveIfFits(e, snapshot) {
+		if e.assignment.RepresentativeMode() == flavorassigner.Fit || len(e.preemptionTargets) > 0 {
+			// The workload no longer fits after the previous admissions, or
+			// it needs preemption, which is only attempted for the heads of
+			// the ClusterQueues.
+			setSkipped(e, "Workload deferred to the next scheduling cycle")
+		}
+		s.requeueAndUpdate(ctx, *e)
+		return false
+	}
+	e.status = nominated
+	if err := s.admit(ctx, e, snapshot.ClusterQueues[e.ClusterQueue]); err != nil {
+		e.inadmissibleMsg = fmt.Sprintf("Failed to admit workload: %v", err)
+		s.requeueAndUpdate(ctx, *e)
+		return false
+	}
+	return true
+}
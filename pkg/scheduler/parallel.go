@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/util/parallelize"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// partitionResult holds the outcome of scheduling the heads of a partition.
+type partitionResult struct {
+	admitted           bool
+	skippedPreemptions map[string]int
+}
+
+// schedulePartitions schedules the heads of each independent Cohort tree
+// concurrently, if enabled. Otherwise, all the heads are scheduled at once.
+func (s *Scheduler) schedulePartitions(ctx context.Context, heads []workload.Info, snapshot *cache.Snapshot) []partitionResult {
+	if !s.parallelSchedulingEnabled(snapshot) {
+		admitted, skipped := s.scheduleHeads(ctx, heads, snapshot)
+		return []partitionResult{{admitted: admitted, skippedPreemptions: skipped}}
+	}
+	partitions := partitionHeads(heads, snapshot)
+	results := make([]partitionResult, len(partitions))
+	if len(partitions) == 1 {
+		results[0].admitted, results[0].skippedPreemptions = s.scheduleHeads(ctx, partitions[0], snapshot)
+		return results
+	}
+	ctrl.LoggerFrom(ctx).V(3).Info("Scheduling the Cohort trees concurrently", "partitions", len(partitions))
+	_ = parallelize.Until(ctx, len(partitions), func(i int) error {
+		results[i].admitted, results[i].skippedPreemptions = s.scheduleHeads(ctx, partitions[i], snapshot)
+		return nil
+	})
+	return results
+}
+
+// parallelSchedulingEnabled returns true if the Cohort trees can be scheduled
+// concurrently. The Cohort trees don't share quota, but they share the
+// capacity of the TAS flavors, and the blocking of the admission until the
+// admitted workloads are in the PodsReady condition; the heads are scheduled
+// at once when any of them is in use.
+func (s *Scheduler) parallelSchedulingEnabled(snapshot *cache.Snapshot) bool {
+	if !features.Enabled(features.ParallelCohortScheduling) || s.cache.PodsReadyTracking() {
+		return false
+	}
+	if features.Enabled(features.TopologyAwareScheduling) {
+		for _, cq := range snapshot.ClusterQueues {
+			if len(cq.TASFlavors) > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// partitionHeads groups the heads by the root Cohort of their ClusterQueue,
+// keeping their order. The heads of a ClusterQueue which doesn't belong to a
+// Cohort, or isn't in the snapshot, are in a partition of their own.
+func partitionHeads(heads []workload.Info, snapshot *cache.Snapshot) [][]workload.Info {
+	type key struct {
+		cohort       string
+		clusterQueue string
+	}
+	index := make(map[key]int)
+	var partitions [][]workload.Info
+	for _, wl := range heads {
+		k := key{clusterQueue: wl.ClusterQueue}
+		if root, ok := snapshot.RootCohort(wl.ClusterQueue); ok {
+			k = key{cohort: root.Name}
+		}
+		i, found := index[k]
+		if !found {
+			i = len(partitions)
+			index[k] = i
+			partitions = append(partitions, nil)
+		}
+		partitions[i] = append(partitions[i], wl)
+	}
+	return partitions
+}
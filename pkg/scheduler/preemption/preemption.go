@@ -38,10 +38,11 @@ import (
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
-	"sigs.k8s.io/kueue/pkg/util/heap"
+	"sigs.k8s.io/kueue/pkg/scheduler/framework"
 	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/util/routine"
 	"sigs.k8s.io/kueue/pkg/workload"
@@ -58,6 +59,7 @@ type Preemptor struct {
 	workloadOrdering  workload.Ordering
 	enableFairSharing bool
 	fsStrategies      []fsStrategy
+	framework         *framework.Framework
 
 	// stubs
 	applyPreemption func(ctx context.Context, w *kueue.Workload, reason, message string) error
@@ -69,6 +71,7 @@ func New(
 	recorder record.EventRecorder,
 	fs config.FairSharing,
 	clock clock.Clock,
+	fw *framework.Framework,
 ) *Preemptor {
 	p := &Preemptor{
 		clock:             clock,
@@ -77,6 +80,7 @@ func New(
 		workloadOrdering:  workloadOrdering,
 		enableFairSharing: fs.Enable,
 		fsStrategies:      parseStrategies(fs.PreemptionStrategies),
+		framework:         fw,
 	}
 	p.applyPreemption = p.applyPreemptionWithSSA
 	return p
@@ -99,13 +103,23 @@ func candidatesOnlyFromQueue(candidates []*workload.Info, clusterQueue string) [
 func candidatesFromCQOrUnderThreshold(candidates []*workload.Info, clusterQueue string, threshold int32) []*workload.Info {
 	result := make([]*workload.Info, 0, len(candidates))
 	for _, wi := range candidates {
-		if wi.ClusterQueue == clusterQueue || priority.Priority(wi.Obj) < threshold {
+		if wi.ClusterQueue == clusterQueue || priority.Effective(wi.Obj) < threshold {
 			result = append(result, wi)
 		}
 	}
 	return result
 }
 
+// preemptionRequests holds the requests of the preemptor which need to fit
+// in the ClusterQueue once the targets are evicted.
+type preemptionRequests struct {
+	quota resources.FlavorResourceQuantities
+	// tas holds the requests of the PodSets which require Topology Aware
+	// Scheduling. The topology domains freed by the targets need to
+	// accommodate these PodSets.
+	tas []cache.TASPodSetRequests
+}
+
 type Target struct {
 	WorkloadInfo *workload.Info
 	Reason       string
@@ -115,18 +129,21 @@ type Target struct {
 // order to make room for wl.
 func (p *Preemptor) GetTargets(log logr.Logger, wl workload.Info, assignment flavorassigner.Assignment, snapshot *cache.Snapshot) []*Target {
 	frsNeedPreemption := flavorResourcesNeedPreemption(assignment)
-	requests := assignment.TotalRequestsFor(&wl)
+	requests := preemptionRequests{quota: assignment.TotalRequestsFor(&wl)}
+	if features.Enabled(features.TopologyAwareScheduling) {
+		requests.tas = assignment.WorkloadsTopologyRequests(&wl)
+	}
 	return p.getTargets(log, wl, requests, frsNeedPreemption, snapshot)
 }
 
-func (p *Preemptor) getTargets(log logr.Logger, wl workload.Info, requests resources.FlavorResourceQuantities,
+func (p *Preemptor) getTargets(log logr.Logger, wl workload.Info, requests preemptionRequests,
 	frsNeedPreemption sets.Set[resources.FlavorResource], snapshot *cache.Snapshot) []*Target {
 	cq := snapshot.ClusterQueues[wl.ClusterQueue]
 	candidates := p.findCandidates(wl.Obj, cq, frsNeedPreemption)
 	if len(candidates) == 0 {
 		return nil
 	}
-	sort.Slice(candidates, candidatesOrdering(candidates, cq.Name, p.clock.Now()))
+	sort.Slice(candidates, candidatesOrdering(candidates, cq.Name, p.clock.Now(), p.framework))
 
 	sameQueueCandidates := candidatesOnlyFromQueue(candidates, wl.ClusterQueue)
 
@@ -177,7 +194,7 @@ func canBorrowWithinCohort(cq *cache.ClusterQueueSnapshot, wl *kueue.Workload) (
 	if borrowWithinCohort == nil || borrowWithinCohort.Policy == kueue.BorrowWithinCohortPolicyNever {
 		return false, nil
 	}
-	threshold := priority.Priority(wl)
+	threshold := priority.Effective(wl)
 	if borrowWithinCohort.MaxPriorityThreshold != nil && *borrowWithinCohort.MaxPriorityThreshold < threshold {
 		threshold = *borrowWithinCohort.MaxPriorityThreshold + 1
 	}
@@ -189,6 +206,8 @@ var HumanReadablePreemptionReasons = map[string]string{
 	kueue.InCohortReclamationReason:           "reclamation within the cohort",
 	kueue.InCohortFairSharingReason:           "fair sharing within the cohort",
 	kueue.InCohortReclaimWhileBorrowingReason: "reclamation within the cohort while borrowing",
+	kueue.InReservationReason:                 "the start of a reservation",
+	kueue.InQuotaReductionReason:              "the reduction of the ClusterQueue quota",
 }
 
 // IssuePreemptions marks the target workloads as evicted.
@@ -235,7 +254,7 @@ func (p *Preemptor) applyPreemptionWithSSA(ctx context.Context, w *kueue.Workloa
 // Once the Workload fits, the heuristic tries to add Workloads back, in the
 // reverse order in which they were removed, while the incoming Workload still
 // fits.
-func minimalPreemptions(log logr.Logger, requests resources.FlavorResourceQuantities, cq *cache.ClusterQueueSnapshot, snapshot *cache.Snapshot, frsNeedPreemption sets.Set[resources.FlavorResource], candidates []*workload.Info, allowBorrowing bool, allowBorrowingBelowPriority *int32) []*Target {
+func minimalPreemptions(log logr.Logger, requests preemptionRequests, cq *cache.ClusterQueueSnapshot, snapshot *cache.Snapshot, frsNeedPreemption sets.Set[resources.FlavorResource], candidates []*workload.Info, allowBorrowing bool, allowBorrowingBelowPriority *int32) []*Target {
 	if logV := log.V(5); logV.Enabled() {
 		logV.Info("Simulating preemption", "candidates", workload.References(candidates), "resourcesRequiringPreemption", frsNeedPreemption, "allowBorrowing", allowBorrowing, "allowBorrowingBelowPriority", allowBorrowingBelowPriority)
 	}
@@ -251,7 +270,7 @@ func minimalPreemptions(log logr.Logger, requests resources.FlavorResourceQuanti
 			}
 			reason = kueue.InCohortReclamationReason
 			if allowBorrowingBelowPriority != nil {
-				if priority.Priority(candWl.Obj) >= *allowBorrowingBelowPriority {
+				if priority.Effective(candWl.Obj) >= *allowBorrowingBelowPriority {
 					// We set allowBorrowing=false if there is a candidate with priority
 					// exceeding allowBorrowingBelowPriority added to targets.
 					//
@@ -289,7 +308,7 @@ func minimalPreemptions(log logr.Logger, requests resources.FlavorResourceQuanti
 	return targets
 }
 
-func fillBackWorkloads(targets []*Target, requests resources.FlavorResourceQuantities, cq *cache.ClusterQueueSnapshot, snapshot *cache.Snapshot, allowBorrowing bool) []*Target {
+func fillBackWorkloads(targets []*Target, requests preemptionRequests, cq *cache.ClusterQueueSnapshot, snapshot *cache.Snapshot, allowBorrowing bool) []*Target {
 	// In the reverse order, check if any of the workloads can be added back.
 	for i := len(targets) - 2; i >= 0; i-- {
 		snapshot.AddWorkload(targets[i].WorkloadInfo)
@@ -341,13 +360,12 @@ func parseStrategies(s []config.PreemptionStrategy) []fsStrategy {
 	return strategies
 }
 
-func (p *Preemptor) fairPreemptions(log logr.Logger, wl workload.Info, requests resources.FlavorResourceQuantities, snapshot *cache.Snapshot, frsNeedPreemption sets.Set[resources.FlavorResource], candidates []*workload.Info, allowBorrowingBelowPriority *int32) []*Target {
+func (p *Preemptor) fairPreemptions(log logr.Logger, wl workload.Info, requests preemptionRequests, snapshot *cache.Snapshot, frsNeedPreemption sets.Set[resources.FlavorResource], candidates []*workload.Info, allowBorrowingBelowPriority *int32) []*Target {
 	if logV := log.V(5); logV.Enabled() {
 		logV.Info("Simulating fair preemption", "candidates", workload.References(candidates), "resourcesRequiringPreemption", frsNeedPreemption, "allowBorrowingBelowPriority", allowBorrowingBelowPriority)
 	}
-	cqHeap := cqHeapFromCandidates(candidates, false, snapshot)
 	nominatedCQ := snapshot.ClusterQueues[wl.ClusterQueue]
-	newNominatedShareValue, _ := nominatedCQ.DominantResourceShareWith(requests)
+	cqHeap := newCandidateCQs(candidates, false, snapshot, nominatedCQ)
 	var targets []*Target
 	fits := false
 	var retryCandidates []*workload.Info
@@ -365,18 +383,21 @@ func (p *Preemptor) fairPreemptions(log logr.Logger, wl workload.Info, requests
 				fits = true
 				break
 			}
-			newNominatedShareValue, _ = nominatedCQ.DominantResourceShareWith(requests)
 			candCQ.workloads = candCQ.workloads[1:]
 			if len(candCQ.workloads) > 0 {
-				candCQ.share, _ = candCQ.cq.DominantResourceShare()
 				cqHeap.PushIfNotPresent(candCQ)
 			}
 			continue
 		}
 
+		// The shares are compared between the nodes through which the
+		// ClusterQueues compete, at the level of their lowest common
+		// ancestor Cohort.
+		nominatedNode, candNode := cache.FairSharingSiblings(nominatedCQ, candCQ.cq)
+		newNominatedShareValue, _ := nominatedNode.DominantResourceShareWith(requests.quota)
 		for i, candWl := range candCQ.workloads {
-			belowThreshold := allowBorrowingBelowPriority != nil && priority.Priority(candWl.Obj) < *allowBorrowingBelowPriority
-			newCandShareVal, _ := candCQ.cq.DominantResourceShareWithout(candWl.FlavorResourceUsage())
+			belowThreshold := allowBorrowingBelowPriority != nil && priority.Effective(candWl.Obj) < *allowBorrowingBelowPriority
+			newCandShareVal, _ := candNode.DominantResourceShareWithout(candWl.FlavorResourceUsage())
 			strategy := p.fsStrategies[0](newNominatedShareValue, candCQ.share, newCandShareVal)
 			if belowThreshold || strategy {
 				snapshot.RemoveWorkload(candWl)
@@ -395,7 +416,6 @@ func (p *Preemptor) fairPreemptions(log logr.Logger, wl workload.Info, requests
 				}
 				candCQ.workloads = candCQ.workloads[i+1:]
 				if len(candCQ.workloads) > 0 && cqIsBorrowing(candCQ.cq, frsNeedPreemption) {
-					candCQ.share = newCandShareVal
 					cqHeap.PushIfNotPresent(candCQ)
 				}
 				// Might need to pick a different CQ due to changing values.
@@ -407,10 +427,12 @@ func (p *Preemptor) fairPreemptions(log logr.Logger, wl workload.Info, requests
 	}
 	if !fits && len(p.fsStrategies) > 1 {
 		// Try next strategy if the previous strategy wasn't enough
-		cqHeap = cqHeapFromCandidates(retryCandidates, true, snapshot)
+		cqHeap = newCandidateCQs(retryCandidates, true, snapshot, nominatedCQ)
 
 		for cqHeap.Len() > 0 && !fits {
 			candCQ := cqHeap.Pop()
+			nominatedNode, _ := cache.FairSharingSiblings(nominatedCQ, candCQ.cq)
+			newNominatedShareValue, _ := nominatedNode.DominantResourceShareWith(requests.quota)
 			// Due to API validation, we can only reach here if the second strategy is LessThanInitialShare,
 			// in which case the last parameter for the strategy function is irrelevant.
 			if p.fsStrategies[1](newNominatedShareValue, candCQ.share, 0) {
@@ -441,34 +463,133 @@ func (p *Preemptor) fairPreemptions(log logr.Logger, wl workload.Info, requests
 type candidateCQ struct {
 	cq        *cache.ClusterQueueSnapshot
 	workloads []*workload.Info
-	share     int
-}
-
-func cqHeapFromCandidates(candidates []*workload.Info, firstOnly bool, snapshot *cache.Snapshot) *heap.Heap[candidateCQ] {
-	cqHeap := heap.New(
-		func(c *candidateCQ) string {
-			return c.cq.Name
-		},
-		func(c1, c2 *candidateCQ) bool {
-			return c1.share > c2.share
-		},
-	)
+	// share is the share of the node through which the ClusterQueue competes
+	// with the nominated ClusterQueue, as of when it was popped.
+	share int
+	// order is the position of the first candidate of the ClusterQueue,
+	// used to break ties between equal shares.
+	order int
+}
+
+// candidateCQs holds the ClusterQueues with candidates for fair preemption.
+type candidateCQs struct {
+	nominated *cache.ClusterQueueSnapshot
+	root      *cache.CohortSnapshot
+	byName    map[string]*candidateCQ
+}
+
+func newCandidateCQs(candidates []*workload.Info, firstOnly bool, snapshot *cache.Snapshot, nominated *cache.ClusterQueueSnapshot) *candidateCQs {
+	cqs := &candidateCQs{
+		nominated: nominated,
+		byName:    make(map[string]*candidateCQ),
+	}
+	if nominated.HasParent() {
+		cqs.root = nominated.Parent().Root()
+	}
 	for _, cand := range candidates {
-		candCQ := cqHeap.GetByKey(cand.ClusterQueue)
+		candCQ := cqs.byName[cand.ClusterQueue]
 		if candCQ == nil {
-			cq := snapshot.ClusterQueues[cand.ClusterQueue]
-			share, _ := cq.DominantResourceShare()
-			candCQ = &candidateCQ{
-				cq:        cq,
-				share:     share,
+			cqs.byName[cand.ClusterQueue] = &candidateCQ{
+				cq:        snapshot.ClusterQueues[cand.ClusterQueue],
 				workloads: []*workload.Info{cand},
+				order:     len(cqs.byName),
 			}
-			cqHeap.PushOrUpdate(candCQ)
 		} else if !firstOnly {
 			candCQ.workloads = append(candCQ.workloads, cand)
 		}
 	}
-	return cqHeap
+	return cqs
+}
+
+func (c *candidateCQs) Len() int {
+	return len(c.byName)
+}
+
+func (c *candidateCQs) PushIfNotPresent(candCQ *candidateCQ) {
+	if _, found := c.byName[candCQ.cq.Name]; !found {
+		c.byName[candCQ.cq.Name] = candCQ
+	}
+}
+
+// Pop removes and returns the ClusterQueue to preempt from next. It walks
+// the Cohort tree top-down, and at each level descends into the child
+// subtree with candidates which has the highest share, so that the
+// preemptions target the subtrees using the most resources above their fair
+// share, regardless of the number of ClusterQueues in them.
+func (c *candidateCQs) Pop() *candidateCQ {
+	result := c.popTopDown()
+	if result == nil {
+		for _, candCQ := range c.byName {
+			if result == nil || candCQ.order < result.order {
+				result = candCQ
+			}
+		}
+	}
+	delete(c.byName, result.cq.Name)
+	_, candNode := cache.FairSharingSiblings(c.nominated, result.cq)
+	result.share, _ = candNode.DominantResourceShare()
+	return result
+}
+
+func (c *candidateCQs) popTopDown() *candidateCQ {
+	if c.root == nil {
+		return nil
+	}
+	// The lowest order of the candidates in each Cohort subtree.
+	subtreeOrder := make(map[*cache.CohortSnapshot]int)
+	for _, candCQ := range c.byName {
+		if !candCQ.cq.HasParent() {
+			continue
+		}
+		for node := candCQ.cq.Parent(); node != nil; node = node.Parent() {
+			if order, found := subtreeOrder[node]; !found || candCQ.order < order {
+				subtreeOrder[node] = candCQ.order
+			}
+			if !node.HasParent() {
+				break
+			}
+		}
+	}
+	if _, found := subtreeOrder[c.root]; !found {
+		return nil
+	}
+	node := c.root
+	for {
+		var nextCohort *cache.CohortSnapshot
+		var nextCQ *candidateCQ
+		bestShare, bestOrder := -1, 0
+		isBetter := func(share, order int) bool {
+			return share > bestShare || (share == bestShare && order < bestOrder)
+		}
+		for _, child := range node.ChildCohorts() {
+			order, found := subtreeOrder[child]
+			if !found {
+				continue
+			}
+			if share, _ := child.DominantResourceShare(); isBetter(share, order) {
+				bestShare, bestOrder = share, order
+				nextCohort, nextCQ = child, nil
+			}
+		}
+		for _, child := range node.ChildCQs() {
+			candCQ, found := c.byName[child.Name]
+			if !found {
+				continue
+			}
+			if share, _ := child.DominantResourceShare(); isBetter(share, candCQ.order) {
+				bestShare, bestOrder = share, candCQ.order
+				nextCohort, nextCQ = nil, candCQ
+			}
+		}
+		switch {
+		case nextCQ != nil:
+			return nextCQ
+		case nextCohort != nil:
+			node = nextCohort
+		default:
+			return nil
+		}
+	}
 }
 
 func flavorResourcesNeedPreemption(assignment flavorassigner.Assignment) sets.Set[resources.FlavorResource] {
@@ -488,14 +609,14 @@ func flavorResourcesNeedPreemption(assignment flavorassigner.Assignment) sets.Se
 // preempting workload needs.
 func (p *Preemptor) findCandidates(wl *kueue.Workload, cq *cache.ClusterQueueSnapshot, frsNeedPreemption sets.Set[resources.FlavorResource]) []*workload.Info {
 	var candidates []*workload.Info
-	wlPriority := priority.Priority(wl)
+	wlPriority := priority.Effective(wl)
 
 	if cq.Preemption.WithinClusterQueue != kueue.PreemptionPolicyNever {
 		considerSamePrio := (cq.Preemption.WithinClusterQueue == kueue.PreemptionPolicyLowerOrNewerEqualPriority)
 		preemptorTS := p.workloadOrdering.GetQueueOrderTimestamp(wl)
 
 		for _, candidateWl := range cq.Workloads {
-			candidatePriority := priority.Priority(candidateWl.Obj)
+			candidatePriority := priority.Effective(candidateWl.Obj)
 			if candidatePriority > wlPriority {
 				continue
 			}
@@ -519,7 +640,7 @@ func (p *Preemptor) findCandidates(wl *kueue.Workload, cq *cache.ClusterQueueSna
 				continue
 			}
 			for _, candidateWl := range cohortCQ.Workloads {
-				if onlyLowerPriority && priority.Priority(candidateWl.Obj) >= priority.Priority(wl) {
+				if onlyLowerPriority && priority.Effective(candidateWl.Obj) >= priority.Effective(wl) {
 					continue
 				}
 				if !workloadUsesResources(candidateWl, frsNeedPreemption) {
@@ -557,9 +678,10 @@ func workloadUsesResources(wl *workload.Info, frsNeedPreemption sets.Set[resourc
 
 // workloadFits determines if the workload requests would fit given the
 // requestable resources and simulated usage of the ClusterQueue and its cohort,
-// if it belongs to one.
-func workloadFits(requests resources.FlavorResourceQuantities, cq *cache.ClusterQueueSnapshot, allowBorrowing bool) bool {
-	for fr, v := range requests {
+// if it belongs to one. For workloads using TAS it also determines if the
+// PodSets would fit in the simulated free capacity of the topology domains.
+func workloadFits(requests preemptionRequests, cq *cache.ClusterQueueSnapshot, allowBorrowing bool) bool {
+	for fr, v := range requests.quota {
 		if !allowBorrowing && cq.BorrowingWith(fr, v) {
 			return false
 		}
@@ -567,6 +689,11 @@ func workloadFits(requests resources.FlavorResourceQuantities, cq *cache.Cluster
 			return false
 		}
 	}
+	if len(requests.tas) > 0 {
+		if _, reason := cq.FindTopologyAssignmentsForWorkload(requests.tas); len(reason) > 0 {
+			return false
+		}
+	}
 	return true
 }
 
@@ -584,8 +711,9 @@ func queueUnderNominalInResourcesNeedingPreemption(frsNeedPreemption sets.Set[re
 // 1. Workloads from other ClusterQueues in the cohort before the ones in the
 // same ClusterQueue as the preemptor.
 // 2. Workloads with lower priority first.
-// 3. Workloads admitted more recently first.
-func candidatesOrdering(candidates []*workload.Info, cq string, now time.Time) func(int, int) bool {
+// 3. Workloads preferred by the preemptionOrder plugins first.
+// 4. Workloads admitted more recently first.
+func candidatesOrdering(candidates []*workload.Info, cq string, now time.Time, fw *framework.Framework) func(int, int) bool {
 	return func(i, j int) bool {
 		a := candidates[i]
 		b := candidates[j]
@@ -599,11 +727,14 @@ func candidatesOrdering(candidates []*workload.Info, cq string, now time.Time) f
 		if aInCQ != bInCQ {
 			return !aInCQ
 		}
-		pa := priority.Priority(a.Obj)
-		pb := priority.Priority(b.Obj)
+		pa := priority.Effective(a.Obj)
+		pb := priority.Effective(b.Obj)
 		if pa != pb {
 			return pa < pb
 		}
+		if c := fw.ComparePreemptionCandidates(a, b); c != 0 {
+			return c < 0
+		}
 		timeA := quotaReservationTime(a.Obj, now)
 		timeB := quotaReservationTime(b.Obj, now)
 		if !timeA.Equal(timeB) {
@@ -42,7 +42,7 @@ func (p *PreemptionOracle) IsReclaimPossible(log logr.Logger, cq *cache.ClusterQ
 		return false
 	}
 
-	for _, candidate := range p.preemptor.getTargets(log, wl, resources.FlavorResourceQuantities{fr: quantity}, sets.New(fr), p.snapshot) {
+	for _, candidate := range p.preemptor.getTargets(log, wl, preemptionRequests{quota: resources.FlavorResourceQuantities{fr: quantity}}, sets.New(fr), p.snapshot) {
 		if candidate.WorkloadInfo.ClusterQueue == cq.Name {
 			return false
 		}
@@ -55,6 +55,7 @@ var snapCmpOpts = []cmp.Option{
 	cmpopts.IgnoreUnexported(hierarchy.ClusterQueue[*cache.CohortSnapshot]{}),
 	cmpopts.IgnoreUnexported(hierarchy.Manager[*cache.ClusterQueueSnapshot, *cache.CohortSnapshot]{}),
 	cmpopts.IgnoreUnexported(hierarchy.CycleChecker{}),
+	cmpopts.IgnoreUnexported(cache.ClusterQueueSnapshot{}),
 	cmpopts.IgnoreFields(cache.ClusterQueueSnapshot{}, "AllocatableResourceGeneration"),
 	cmp.Transformer("Cohort.Members", func(s sets.Set[*cache.ClusterQueueSnapshot]) sets.Set[string] {
 		result := make(sets.Set[string], len(s))
@@ -288,7 +289,54 @@ func TestPreemption(t *testing.T) {
 		assignment          flavorassigner.Assignment
 		wantPreempted       sets.Set[string]
 		disableLendingLimit bool
+		enablePriorityAging bool
 	}{
+		"preempt lower priority than the effective priority of the incoming workload": {
+			clusterQueues: defaultClusterQueues,
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("mid", "").
+					Request(corev1.ResourceCPU, "6").
+					ReserveQuota(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "6").Obj()).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(-1).
+				EffectivePriority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted:       sets.New(targetKeyReason("/mid", kueue.InClusterQueueReason)),
+			enablePriorityAging: true,
+		},
+		"can't preempt a workload admitted with a higher effective priority": {
+			clusterQueues: defaultClusterQueues,
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("aged-low", "").
+					Priority(-1).
+					EffectivePriority(5).
+					Request(corev1.ResourceCPU, "6").
+					ReserveQuota(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "6").Obj()).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			enablePriorityAging: true,
+		},
 		"preempt lowest priority": {
 			clusterQueues: defaultClusterQueues,
 			admitted: []kueue.Workload{
@@ -1506,6 +1554,7 @@ func TestPreemption(t *testing.T) {
 			if tc.disableLendingLimit {
 				features.SetFeatureGateDuringTest(t, features.LendingLimit, false)
 			}
+			features.SetFeatureGateDuringTest(t, features.PriorityAging, tc.enablePriorityAging)
 			ctx, log := utiltesting.ContextWithLog(t)
 			cl := utiltesting.NewClientBuilder().
 				WithLists(&kueue.WorkloadList{Items: tc.admitted}).
@@ -1534,7 +1583,7 @@ func TestPreemption(t *testing.T) {
 				t.Fatalf("Failed adding kueue scheme: %v", err)
 			}
 			recorder := broadcaster.NewRecorder(scheme, corev1.EventSource{Component: constants.AdmissionName})
-			preemptor := New(cl, workload.Ordering{}, recorder, config.FairSharing{}, clocktesting.NewFakeClock(now))
+			preemptor := New(cl, workload.Ordering{}, recorder, config.FairSharing{}, clocktesting.NewFakeClock(now), nil)
 			preemptor.applyPreemption = func(ctx context.Context, w *kueue.Workload, reason, _ string) error {
 				lock.Lock()
 				gotPreempted.Insert(targetKeyReason(workload.Key(w), reason))
@@ -1625,12 +1674,63 @@ func TestFairPreemptions(t *testing.T) {
 	unitWl := *utiltesting.MakeWorkload("unit", "").Request(corev1.ResourceCPU, "1")
 	cases := map[string]struct {
 		clusterQueues []*kueue.ClusterQueue
+		cohorts       []*kueuealpha.Cohort
 		strategies    []config.PreemptionStrategy
 		admitted      []kueue.Workload
 		incoming      *kueue.Workload
 		targetCQ      string
 		wantPreempted sets.Set[string]
 	}{
+		"reclaim from the Cohort subtree using the most": {
+			// c1 has the highest share among the ClusterQueues, but org-a
+			// has the highest share among the Cohorts competing with org-b.
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("a1").
+					Cohort("org-a").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj()).
+					Obj(),
+				utiltesting.MakeClusterQueue("a2").
+					Cohort("org-a").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj()).
+					Obj(),
+				utiltesting.MakeClusterQueue("a3").
+					Cohort("org-a").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj()).
+					Obj(),
+				utiltesting.MakeClusterQueue("b1").
+					Cohort("org-b").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "3").Obj()).
+					Preemption(kueue.ClusterQueuePreemption{
+						ReclaimWithinCohort: kueue.PreemptionPolicyAny,
+					}).
+					Obj(),
+				utiltesting.MakeClusterQueue("c1").
+					Cohort("org-c").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+					Obj(),
+			},
+			cohorts: []*kueuealpha.Cohort{
+				utiltesting.MakeCohort("root").Obj(),
+				utiltesting.MakeCohort("org-a").Parent("root").Obj(),
+				utiltesting.MakeCohort("org-b").Parent("root").Obj(),
+				utiltesting.MakeCohort("org-c").
+					Parent("root").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj()).
+					Obj(),
+			},
+			admitted: []kueue.Workload{
+				*unitWl.Clone().Name("a1-1").SimpleReserveQuota("a1", "default", now).Obj(),
+				*unitWl.Clone().Name("a1-2").SimpleReserveQuota("a1", "default", now).Obj(),
+				*unitWl.Clone().Name("a2-1").SimpleReserveQuota("a2", "default", now).Obj(),
+				*unitWl.Clone().Name("a2-2").SimpleReserveQuota("a2", "default", now).Obj(),
+				*unitWl.Clone().Name("a3-1").SimpleReserveQuota("a3", "default", now).Obj(),
+				*unitWl.Clone().Name("c1-1").SimpleReserveQuota("c1", "default", now).Obj(),
+				*unitWl.Clone().Name("c1-2").SimpleReserveQuota("c1", "default", now).Obj(),
+			},
+			incoming:      unitWl.Clone().Name("b_incoming").Obj(),
+			targetCQ:      "b1",
+			wantPreempted: sets.New(targetKeyReason("/a1-1", kueue.InCohortFairSharingReason)),
+		},
 		"reclaim nominal from user using the most": {
 			clusterQueues: baseCQs,
 			admitted: []kueue.Workload{
@@ -2047,6 +2147,11 @@ func TestFairPreemptions(t *testing.T) {
 			for _, flv := range flavors {
 				cqCache.AddOrUpdateResourceFlavor(flv)
 			}
+			for _, cohort := range tc.cohorts {
+				if err := cqCache.AddOrUpdateCohort(cohort); err != nil {
+					t.Fatalf("Couldn't add Cohort to cache: %v", err)
+				}
+			}
 			for _, cq := range tc.clusterQueues {
 				if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
 					t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
@@ -2059,7 +2164,7 @@ func TestFairPreemptions(t *testing.T) {
 			preemptor := New(cl, workload.Ordering{}, recorder, config.FairSharing{
 				Enable:               true,
 				PreemptionStrategies: tc.strategies,
-			}, clocktesting.NewFakeClock(now))
+			}, clocktesting.NewFakeClock(now), nil)
 
 			snapshot, err := cqCache.Snapshot(ctx)
 			if err != nil {
@@ -2126,7 +2231,7 @@ func TestCandidatesOrdering(t *testing.T) {
 			}).
 			Obj()),
 	}
-	sort.Slice(candidates, candidatesOrdering(candidates, "self", now))
+	sort.Slice(candidates, candidatesOrdering(candidates, "self", now, nil))
 	gotNames := make([]string, len(candidates))
 	for i, c := range candidates {
 		gotNames[i] = workload.Key(c.Obj)
@@ -17,6 +17,7 @@ limitations under the License.
 package scheduler
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"maps"
@@ -45,7 +46,9 @@ import (
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/framework"
 	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/scheduler/trace"
 	"sigs.k8s.io/kueue/pkg/util/api"
 	"sigs.k8s.io/kueue/pkg/util/limitrange"
 	"sigs.k8s.io/kueue/pkg/util/priority"
@@ -72,11 +75,19 @@ type Scheduler struct {
 	preemptor               *preemption.Preemptor
 	workloadOrdering        workload.Ordering
 	fairSharing             config.FairSharing
+	admissionFairSharing    bool
+	framework               *framework.Framework
+	maxAdmissionsPerCycle   int32
 	clock                   clock.Clock
 
 	// attemptCount identifies the number of scheduling attempt in logs, from the last restart.
 	attemptCount int64
 
+	// traceRecorder is nil unless the scheduling cycles are traced.
+	traceRecorder *trace.Recorder
+	// cycleTrace collects the evaluations of the current cycle, if sampled.
+	cycleTrace *cycleTrace
+
 	// Stubs.
 	applyAdmission func(context.Context, *kueue.Workload) error
 }
@@ -84,6 +95,10 @@ type Scheduler struct {
 type options struct {
 	podsReadyRequeuingTimestamp config.RequeuingTimestamp
 	fairSharing                 config.FairSharing
+	admissionFairSharing        bool
+	framework                   *framework.Framework
+	maxAdmissionsPerCycle       int32
+	traceRecorder               *trace.Recorder
 	clock                       clock.Clock
 }
 
@@ -92,6 +107,7 @@ type Option func(*options)
 
 var defaultOptions = options{
 	podsReadyRequeuingTimestamp: config.EvictionTimestamp,
+	maxAdmissionsPerCycle:       1,
 	clock:                       realClock,
 }
 
@@ -111,12 +127,52 @@ func WithFairSharing(fs *config.FairSharing) Option {
 	}
 }
 
+// WithAdmissionFairSharing enables ordering the heads of the ClusterQueues
+// by their historical resource consumption.
+func WithAdmissionFairSharing(afs *config.AdmissionFairSharing) Option {
+	return func(o *options) {
+		o.admissionFairSharing = afs != nil
+	}
+}
+
+// WithFramework sets the framework running the scheduling plugins.
+func WithFramework(fw *framework.Framework) Option {
+	return func(o *options) {
+		o.framework = fw
+	}
+}
+
+// WithMaxAdmissionsPerCycle sets the maximum number of workloads admitted
+// from the same ClusterQueue in a scheduling cycle.
+func WithMaxAdmissionsPerCycle(n int32) Option {
+	return func(o *options) {
+		o.maxAdmissionsPerCycle = n
+	}
+}
+
+// WithTraceRecorder sets the recorder of the decisions taken in the sampled
+// scheduling cycles.
+func WithTraceRecorder(r *trace.Recorder) Option {
+	return func(o *options) {
+		o.traceRecorder = r
+	}
+}
+
 func WithClock(_ testing.TB, c clock.Clock) Option {
 	return func(o *options) {
 		o.clock = c
 	}
 }
 
+// WithSimulationClock sets the clock of an offline simulation of the
+// scheduler, which drives time, like WithClock does in the tests. The
+// controllers always use the real clock.
+func WithSimulationClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
 func New(queues *queue.Manager, cache *cache.Cache, cl client.Client, recorder record.EventRecorder, opts ...Option) *Scheduler {
 	options := defaultOptions
 	for _, opt := range opts {
@@ -127,13 +183,17 @@ func New(queues *queue.Manager, cache *cache.Cache, cl client.Client, recorder r
 	}
 	s := &Scheduler{
 		fairSharing:             options.fairSharing,
+		admissionFairSharing:    options.admissionFairSharing,
 		queues:                  queues,
 		cache:                   cache,
 		client:                  cl,
 		recorder:                recorder,
-		preemptor:               preemption.New(cl, wo, recorder, options.fairSharing, options.clock),
+		preemptor:               preemption.New(cl, wo, recorder, options.fairSharing, options.clock, options.framework),
 		admissionRoutineWrapper: routine.DefaultWrapper,
 		workloadOrdering:        wo,
+		framework:               options.framework,
+		maxAdmissionsPerCycle:   options.maxAdmissionsPerCycle,
+		traceRecorder:           options.traceRecorder,
 		clock:                   options.clock,
 	}
 	s.applyAdmission = s.applyAdmissionWithSSA
@@ -175,10 +235,6 @@ func reportSkippedPreemptions(p map[string]int) {
 }
 
 func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
-	s.attemptCount++
-	log := ctrl.LoggerFrom(ctx).WithValues("attemptCount", s.attemptCount)
-	ctx = ctrl.LoggerInto(ctx, log)
-
 	// 1. Get the heads from the queues, including their desired clusterQueue.
 	// This operation blocks while the queues are empty.
 	headWorkloads := s.queues.Heads(ctx)
@@ -186,7 +242,20 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 	if len(headWorkloads) == 0 {
 		return wait.KeepGoing
 	}
+	return s.scheduleCycle(ctx, headWorkloads)
+}
+
+// scheduleCycle runs the steps of a scheduling cycle following the retrieval
+// of the heads.
+func (s *Scheduler) scheduleCycle(ctx context.Context, headWorkloads []workload.Info) wait.SpeedSignal {
+	s.attemptCount++
+	log := ctrl.LoggerFrom(ctx).WithValues("attemptCount", s.attemptCount)
+	ctx = ctrl.LoggerInto(ctx, log)
+
 	startTime := s.clock.Now()
+	s.startTrace(startTime)
+	defer s.finishTrace(ctx)
+	headWorkloads, secondPassWorkloads := splitSecondPass(headWorkloads)
 
 	// 2. Take a snapshot of the cache.
 	snapshot, err := s.cache.Snapshot(ctx)
@@ -196,15 +265,39 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 	}
 	logSnapshotIfVerbose(log, snapshot)
 
+	// Compute the delayed topology assignments for the workloads which
+	// already reserved quota, so that the following entries account for them.
+	secondPassAssigned := s.scheduleSecondPass(ctx, secondPassWorkloads, snapshot)
+
+	// 3-8. Nominate, admit and requeue the heads, concurrently for the
+	// independent Cohort trees if enabled.
+	result := metrics.AdmissionResultInadmissible
+	skippedPreemptions := make(map[string]int)
+	for _, r := range s.schedulePartitions(ctx, headWorkloads, snapshot) {
+		if r.admitted {
+			result = metrics.AdmissionResultSuccess
+		}
+		maps.Copy(skippedPreemptions, r.skippedPreemptions)
+	}
+	reportSkippedPreemptions(skippedPreemptions)
+	metrics.AdmissionAttempt(result, s.clock.Since(startTime))
+	if result != metrics.AdmissionResultSuccess && !secondPassAssigned {
+		return wait.SlowDown
+	}
+	return wait.KeepGoing
+}
+
+// scheduleHeads nominates and admits the heads, and requeues the heads which
+// couldn't be admitted. It returns whether any workload was admitted, and the
+// number of preemptions skipped per ClusterQueue.
+func (s *Scheduler) scheduleHeads(ctx context.Context, headWorkloads []workload.Info, snapshot *cache.Snapshot) (bool, map[string]int) {
+	log := ctrl.LoggerFrom(ctx)
+
 	// 3. Calculate requirements (resource flavors, borrowing) for admitting workloads.
 	entries := s.nominate(ctx, headWorkloads, snapshot)
 
 	// 4. Sort entries based on borrowing, priorities (if enabled) and timestamps.
-	sort.Sort(entryOrdering{
-		enableFairSharing: s.fairSharing.Enable,
-		entries:           entries,
-		workloadOrdering:  s.workloadOrdering,
-	})
+	s.sortEntries(entries)
 
 	// 5. Admit entries, ensuring that no more than one workload gets
 	// admitted by a cohort (if borrowing).
@@ -229,7 +322,8 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 			// we use resourcesToReserve to block capacity up to either the nominal capacity,
 			// or the borrowing limit when borrowing, so that a lower priority workload cannot
 			// admit before us.
-			cq.AddUsage(resourcesToReserve(e, cq))
+			e.reservedUsage = resourcesToReserve(e, cq)
+			cq.AddUsage(e.reservedUsage)
 			continue
 		}
 
@@ -245,15 +339,31 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 		}
 
 		usage := e.netUsage()
+		restoreReservation := s.releaseReservation(e, snapshot)
 		if !cq.Fits(usage) {
+			restoreReservation(nil)
 			setSkipped(e, "Workload no longer fits after processing another workload")
 			if mode == flavorassigner.Preempt {
 				skippedPreemptions[cq.Name]++
 			}
 			continue
 		}
+		var tasUsage map[kueue.ResourceFlavorReference][]workload.TopologyDomainRequests
+		if features.Enabled(features.TopologyAwareScheduling) {
+			tasUsage = e.assignment.TASUsage()
+			if !cq.FitsTAS(tasUsage) {
+				restoreReservation(nil)
+				setSkipped(e, "Workload no longer fits the topology after processing another workload")
+				if mode == flavorassigner.Preempt {
+					skippedPreemptions[cq.Name]++
+				}
+				continue
+			}
+		}
 		preemptedWorkloads.Insert(pendingPreemptions...)
 		cq.AddUsage(usage)
+		cq.AddTASUsage(tasUsage)
+		restoreReservation(usage)
 
 		if e.assignment.RepresentativeMode() == flavorassigner.Preempt {
 			// If preemptions are issued, the next attempt should try all the flavors.
@@ -286,22 +396,26 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 		}
 	}
 
-	// 6. Requeue the heads that were not scheduled.
-	result := metrics.AdmissionResultInadmissible
+	// 6. Admit more workloads from the ClusterQueues whose head was admitted,
+	// as long as they fit the remaining quota without preemption.
+	admittedMore := s.admitMore(ctx, entries, snapshot)
+
+	// 7. Backfill the ClusterQueues whose head couldn't be admitted, with
+	// the workloads expected to finish before the head could be admitted.
+	backfilled := s.backfill(ctx, entries, snapshot)
+
+	// 8. Requeue the heads that were not scheduled.
+	admitted := backfilled || admittedMore
 	for _, e := range entries {
 		logAdmissionAttemptIfVerbose(log, &e)
+		s.traceAdmissionAttempt(&e, trace.PhaseHead)
 		if e.status != assumed {
 			s.requeueAndUpdate(ctx, e)
 		} else {
-			result = metrics.AdmissionResultSuccess
+			admitted = true
 		}
 	}
-	reportSkippedPreemptions(skippedPreemptions)
-	metrics.AdmissionAttempt(result, s.clock.Since(startTime))
-	if result != metrics.AdmissionResultSuccess {
-		return wait.SlowDown
-	}
-	return wait.KeepGoing
+	return admitted, skippedPreemptions
 }
 
 type entryStatus string
@@ -324,11 +438,23 @@ type entry struct {
 	workload.Info
 	dominantResourceShare int
 	dominantResourceName  corev1.ResourceName
-	assignment            flavorassigner.Assignment
-	status                entryStatus
-	inadmissibleMsg       string
-	requeueReason         queue.RequeueReason
-	preemptionTargets     []*preemption.Target
+	// fairSharingShares holds the share of each node from the root of the
+	// Cohort tree down to the ClusterQueue, including the workload requests.
+	fairSharingShares []int
+	// consumedShare is the share of the historical resource consumption
+	// of the ClusterQueue, used by the admission fair sharing.
+	consumedShare     float64
+	assignment        flavorassigner.Assignment
+	status            entryStatus
+	inadmissibleMsg   string
+	requeueReason     queue.RequeueReason
+	preemptionTargets []*preemption.Target
+	// reservation is the Reservation referenced by the workload, whose
+	// quota the workload can use.
+	reservation *cache.ReservationSnapshot
+	// reservedUsage is the capacity blocked in the snapshot for the head
+	// which is waiting for quota.
+	reservedUsage resources.FlavorResourceQuantities
 }
 
 // netUsage returns how much capacity this entry will require from the ClusterQueue/Cohort.
@@ -351,6 +477,17 @@ func (e *entry) netUsage() resources.FlavorResourceQuantities {
 	return usage
 }
 
+// sortEntries sorts the entries in the order in which they are admitted.
+func (s *Scheduler) sortEntries(entries []entry) {
+	sort.Sort(entryOrdering{
+		enableFairSharing:          s.fairSharing.Enable,
+		enableAdmissionFairSharing: s.admissionFairSharing,
+		entries:                    entries,
+		workloadOrdering:           s.workloadOrdering,
+		framework:                  s.framework,
+	})
+}
+
 // nominate returns the workloads with their requirements (resource flavors, borrowing) if
 // they were admitted by the clusterQueues in the snapshot.
 func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, snap *cache.Snapshot) []entry {
@@ -379,12 +516,26 @@ func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, sna
 			e.inadmissibleMsg = err.Error()
 		} else if err := s.validateLimitRange(ctx, &w); err != nil {
 			e.inadmissibleMsg = err.Error()
+		} else if reservation, msg := snap.ReservationFor(&w); msg != "" {
+			e.inadmissibleMsg = msg
 		} else {
+			e.reservation = reservation
+			restoreReservation := s.releaseReservation(&e, snap)
 			e.assignment, e.preemptionTargets = s.getAssignments(log, &e.Info, snap)
+			restoreReservation(nil)
 			e.inadmissibleMsg = e.assignment.Message()
 			e.Info.LastAssignment = &e.assignment.LastState
 			if s.fairSharing.Enable && e.assignment.RepresentativeMode() != flavorassigner.NoFit {
-				e.dominantResourceShare, e.dominantResourceName = cq.DominantResourceShareWith(e.assignment.TotalRequestsFor(&w))
+				wlReq := e.assignment.TotalRequestsFor(&w)
+				e.dominantResourceShare, e.dominantResourceName = cq.DominantResourceShareWith(wlReq)
+				path := cq.FairSharingPath()
+				e.fairSharingShares = make([]int, len(path))
+				for i, node := range path {
+					e.fairSharingShares[i], _ = node.DominantResourceShareWith(wlReq)
+				}
+			}
+			if s.admissionFairSharing {
+				e.consumedShare = cq.ConsumedShare()
 			}
 		}
 		entries = append(entries, e)
@@ -392,6 +543,16 @@ func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, sna
 	return entries
 }
 
+// releaseReservation makes the quota held by the Reservation referenced by
+// the entry available in the snapshot. The returned function holds the
+// quota again, minus the given usage of the entry.
+func (s *Scheduler) releaseReservation(e *entry, snap *cache.Snapshot) func(used resources.FlavorResourceQuantities) {
+	if e.reservation == nil {
+		return func(resources.FlavorResourceQuantities) {}
+	}
+	return snap.ReleaseReservation(e.reservation)
+}
+
 // resourcesToReserve calculates how much of the available resources in cq/cohort assignment should be reserved.
 func resourcesToReserve(e *entry, cq *cache.ClusterQueueSnapshot) resources.FlavorResourceQuantities {
 	if e.assignment.RepresentativeMode() != flavorassigner.Preempt {
@@ -420,7 +581,7 @@ type partialAssignment struct {
 
 func (s *Scheduler) getAssignments(log logr.Logger, wl *workload.Info, snap *cache.Snapshot) (flavorassigner.Assignment, []*preemption.Target) {
 	cq := snap.ClusterQueues[wl.ClusterQueue]
-	flvAssigner := flavorassigner.New(wl, cq, snap.ResourceFlavors, s.fairSharing.Enable, preemption.NewOracle(s.preemptor, snap))
+	flvAssigner := flavorassigner.New(wl, cq, snap.ResourceFlavors, s.fairSharing.Enable, preemption.NewOracle(s.preemptor, snap), s.framework)
 	fullAssignment := flvAssigner.Assign(log, nil)
 	var faPreemptionTargets []*preemption.Target
 
@@ -565,6 +726,7 @@ func (s *Scheduler) admit(ctx context.Context, e *entry, cq *cache.ClusterQueueS
 				}
 			}
 			log.V(2).Info("Workload successfully admitted and assigned flavors", "assignments", admission.PodSetAssignments)
+			s.framework.PostAdmit(ctx, newWorkload)
 			return
 		}
 		// Ignore errors because the workload or clusterQueue could have been deleted
@@ -587,9 +749,11 @@ func (s *Scheduler) applyAdmissionWithSSA(ctx context.Context, w *kueue.Workload
 }
 
 type entryOrdering struct {
-	enableFairSharing bool
-	entries           []entry
-	workloadOrdering  workload.Ordering
+	enableFairSharing          bool
+	enableAdmissionFairSharing bool
+	entries                    []entry
+	workloadOrdering           workload.Ordering
+	framework                  *framework.Framework
 }
 
 func (e entryOrdering) Len() int {
@@ -602,8 +766,11 @@ func (e entryOrdering) Swap(i, j int) {
 
 // Less is the ordering criteria:
 // 1. request under nominal quota before borrowing.
-// 2. higher priority first.
-// 3. FIFO on eviction or creation timestamp.
+// 2. lower fair share first, if enabled.
+// 3. lower historical resource consumption first, if enabled.
+// 4. higher priority first.
+// 5. preferred by the queueSort plugins first.
+// 6. FIFO on eviction or creation timestamp.
 func (e entryOrdering) Less(i, j int) bool {
 	a := e.entries[i]
 	b := e.entries[j]
@@ -616,25 +783,60 @@ func (e entryOrdering) Less(i, j int) bool {
 	}
 
 	// 2. Fair share, if enabled.
-	if e.enableFairSharing && a.dominantResourceShare != b.dominantResourceShare {
-		return a.dominantResourceShare < b.dominantResourceShare
+	if e.enableFairSharing {
+		if c := compareFairSharingShares(a.fairSharingShares, b.fairSharingShares); c != 0 {
+			return c < 0
+		}
 	}
 
-	// 3. Higher priority first if not disabled.
+	// 3. Historical resource consumption, if enabled.
+	if e.enableAdmissionFairSharing && a.consumedShare != b.consumedShare {
+		return a.consumedShare < b.consumedShare
+	}
+
+	// 4. Higher effective priority first if not disabled.
 	if features.Enabled(features.PrioritySortingWithinCohort) {
-		p1 := priority.Priority(a.Obj)
-		p2 := priority.Priority(b.Obj)
+		p1 := priority.Effective(a.Obj)
+		p2 := priority.Effective(b.Obj)
 		if p1 != p2 {
 			return p1 > p2
 		}
 	}
 
-	// 4. FIFO.
+	// 5. Scheduling plugins.
+	if c := e.framework.CompareWorkloads(&a.Info, &b.Info); c != 0 {
+		return c < 0
+	}
+
+	// 6. FIFO.
 	aComparisonTimestamp := e.workloadOrdering.GetQueueOrderTimestamp(a.Obj)
 	bComparisonTimestamp := e.workloadOrdering.GetQueueOrderTimestamp(b.Obj)
 	return aComparisonTimestamp.Before(bComparisonTimestamp)
 }
 
+// compareFairSharingShares compares the shares of the entries level by level,
+// from the root of their Cohort trees down to their ClusterQueues. For the
+// entries of the same tree, the first different share is usually the one of
+// the children of the lowest common ancestor Cohort, so the fair sharing is
+// applied at every level of the tree. The shorter path is padded with zeros,
+// the share of a node which doesn't borrow, so that the comparison is a total
+// order, also for the entries of different trees.
+func compareFairSharingShares(a, b []int) int {
+	for i := range max(len(a), len(b)) {
+		var aShare, bShare int
+		if i < len(a) {
+			aShare = a[i]
+		}
+		if i < len(b) {
+			bShare = b[i]
+		}
+		if aShare != bShare {
+			return cmp.Compare(aShare, bShare)
+		}
+	}
+	return 0
+}
+
 func (s *Scheduler) requeueAndUpdate(ctx context.Context, e entry) {
 	log := ctrl.LoggerFrom(ctx)
 	if e.status != notNominated && e.requeueReason == queue.RequeueReasonGeneric {
@@ -649,7 +851,8 @@ func (s *Scheduler) requeueAndUpdate(ctx context.Context, e entry) {
 		workload.AdmissionStatusPatch(e.Obj, patch, true)
 		reservationIsChanged := workload.UnsetQuotaReservationWithCondition(patch, "Pending", e.inadmissibleMsg, s.clock.Now())
 		resourceRequestsIsChanged := workload.PropagateResourceRequests(patch, &e.Info)
-		if reservationIsChanged || resourceRequestsIsChanged {
+		schedulingAttemptIsChanged := workload.PropagateSchedulingAttempt(patch, e.assignment.SchedulingAttemptToAPI(kueue.ClusterQueueReference(e.ClusterQueue)))
+		if reservationIsChanged || resourceRequestsIsChanged || schedulingAttemptIsChanged {
 			if err := workload.ApplyAdmissionStatusPatch(ctx, s.client, patch); err != nil {
 				log.Error(err, "Could not update Workload status")
 			}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/util/api"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// secondPassRetryDelay is the delay after which the second pass of scheduling
+// is retried for a workload whose delayed topology assignment couldn't be
+// computed, for example because the provisioned nodes are not yet observed.
+const secondPassRetryDelay = 5 * time.Second
+
+// splitSecondPass separates the workloads which require the second pass of
+// scheduling from the workloads pending quota reservation.
+func splitSecondPass(workloads []workload.Info) ([]workload.Info, []workload.Info) {
+	if !features.Enabled(features.TopologyAwareScheduling) {
+		return workloads, nil
+	}
+	var heads, secondPass []workload.Info
+	for _, wl := range workloads {
+		if workload.HasQuotaReservation(wl.Obj) {
+			secondPass = append(secondPass, wl)
+		} else {
+			heads = append(heads, wl)
+		}
+	}
+	return heads, secondPass
+}
+
+// scheduleSecondPass computes the delayed topology assignments for the
+// workloads whose AdmissionChecks, such as ProvisioningRequest, are Ready.
+// It returns true if any of the topology assignments was found.
+func (s *Scheduler) scheduleSecondPass(ctx context.Context, workloads []workload.Info, snapshot *cache.Snapshot) bool {
+	assigned := false
+	for i := range workloads {
+		if s.assignDelayedTopology(ctx, &workloads[i], snapshot) {
+			assigned = true
+		}
+	}
+	return assigned
+}
+
+func (s *Scheduler) assignDelayedTopology(ctx context.Context, wl *workload.Info, snapshot *cache.Snapshot) bool {
+	log := ctrl.LoggerFrom(ctx).WithValues("workload", klog.KObj(wl.Obj), "clusterQueue", klog.KRef("", wl.ClusterQueue))
+	ctx = ctrl.LoggerInto(ctx, log)
+	cq := snapshot.ClusterQueues[wl.ClusterQueue]
+	if cq == nil {
+		log.V(2).Info("ClusterQueue of the workload not found in the snapshot, skipping the second pass of scheduling")
+		return false
+	}
+	cachedWl, found := cq.Workloads[workload.Key(wl.Obj)]
+	if !found || !workload.NeedsSecondPass(cachedWl.Obj) {
+		log.V(3).Info("Workload no longer requires the second pass of scheduling")
+		return false
+	}
+	oldWorkload := cachedWl.Obj
+	tasRequests := delayedTopologyRequests(oldWorkload)
+	assignments, reason := cq.FindTopologyAssignmentsForWorkload(tasRequests)
+	if assignments == nil {
+		log.V(2).Info("Failed to compute the delayed topology assignment", "reason", reason)
+		s.recorder.Eventf(oldWorkload, corev1.EventTypeWarning, "TopologyAssignmentFailed",
+			api.TruncateEventMessage(fmt.Sprintf("Failed to compute the delayed topology assignment: %s", reason)))
+		s.requeueSecondPass(ctx, oldWorkload)
+		return false
+	}
+
+	newWorkload := oldWorkload.DeepCopy()
+	tasUsage := make(map[kueue.ResourceFlavorReference][]workload.TopologyDomainRequests)
+	for _, tr := range tasRequests {
+		tasUsage[tr.Flavor] = append(tasUsage[tr.Flavor], workload.TopologyDomainRequestsFor(assignments[tr.PodSet.Name], tr.SinglePodRequests)...)
+	}
+	for i := range newWorkload.Status.Admission.PodSetAssignments {
+		psa := &newWorkload.Status.Admission.PodSetAssignments[i]
+		if ta, found := assignments[psa.Name]; found {
+			psa.TopologyAssignment = ta
+			psa.DelayedTopologyRequest = ptr.To(kueue.DelayedTopologyRequestStateReady)
+		}
+	}
+	_ = workload.SyncAdmittedCondition(newWorkload, s.clock.Now())
+	if err := s.cache.UpdateWorkload(oldWorkload, newWorkload); err != nil {
+		log.Error(err, "Failed to update the workload in the cache")
+		return false
+	}
+	cq.AddTASUsage(tasUsage)
+	log.V(2).Info("Delayed topology assignment computed", "assignments", newWorkload.Status.Admission.PodSetAssignments)
+
+	s.admissionRoutineWrapper.Run(func() {
+		err := s.applyAdmission(ctx, newWorkload)
+		if err == nil {
+			if workload.IsAdmitted(newWorkload) {
+				queuedWaitTime := workload.QueuedWaitTime(newWorkload)
+				quotaReservedCondition := apimeta.FindStatusCondition(newWorkload.Status.Conditions, kueue.WorkloadQuotaReserved)
+				quotaReservedWaitTime := s.clock.Since(quotaReservedCondition.LastTransitionTime.Time)
+				s.recorder.Eventf(newWorkload, corev1.EventTypeNormal, "Admitted", "Admitted by ClusterQueue %v, wait time since reservation was %.0fs", newWorkload.Status.Admission.ClusterQueue, quotaReservedWaitTime.Seconds())
+				metrics.AdmittedWorkload(newWorkload.Status.Admission.ClusterQueue, queuedWaitTime)
+				metrics.AdmissionChecksWaitTime(newWorkload.Status.Admission.ClusterQueue, quotaReservedWaitTime)
+				if features.Enabled(features.LocalQueueMetrics) {
+					metrics.LocalQueueAdmittedWorkload(metrics.LQRefFromWorkload(newWorkload), queuedWaitTime)
+					metrics.LocalQueueAdmissionChecksWaitTime(metrics.LQRefFromWorkload(newWorkload), quotaReservedWaitTime)
+				}
+			}
+			return
+		}
+		// Ignore errors because the workload or clusterQueue could have been deleted
+		// by an event.
+		_ = s.cache.UpdateWorkload(newWorkload, oldWorkload)
+		if errors.IsNotFound(err) {
+			log.V(2).Info("Topology not assigned because the workload was deleted")
+			return
+		}
+		log.Error(err, "Could not update the delayed topology assignment in apiserver")
+		s.requeueSecondPass(ctx, oldWorkload)
+	})
+	return true
+}
+
+// requeueSecondPass queues the workload for the second pass of scheduling
+// again after the retry delay.
+func (s *Scheduler) requeueSecondPass(ctx context.Context, wl *kueue.Workload) {
+	s.queues.QueueSecondPassAfter(ctx, wl, secondPassRetryDelay)
+}
+
+// delayedTopologyRequests returns the topology requests for the PodSets of the
+// workload for which the topology assignment is delayed, based on the flavors
+// and resources already assigned to them.
+func delayedTopologyRequests(wl *kueue.Workload) []cache.TASPodSetRequests {
+	var result []cache.TASPodSetRequests
+	for _, psa := range wl.Status.Admission.PodSetAssignments {
+		if psa.TopologyAssignment != nil ||
+			ptr.Deref(psa.DelayedTopologyRequest, "") != kueue.DelayedTopologyRequestStatePending {
+			continue
+		}
+		podSet := findPodSet(wl, psa.Name)
+		count := ptr.Deref(psa.Count, 0)
+		if podSet == nil || podSet.TopologyRequest == nil || count == 0 {
+			continue
+		}
+		var flavor kueue.ResourceFlavorReference
+		for _, f := range psa.Flavors {
+			flavor = f
+			break
+		}
+		singlePodRequests := resources.NewRequests(psa.ResourceUsage)
+		singlePodRequests.Divide(int64(count))
+		result = append(result, cache.TASPodSetRequests{
+			PodSet:            podSet,
+			SinglePodRequests: singlePodRequests,
+			Count:             count,
+			Flavor:            flavor,
+		})
+	}
+	return result
+}
+
+func findPodSet(wl *kueue.Workload, name string) *kueue.PodSet {
+	for i := range wl.Spec.PodSets {
+		if wl.Spec.PodSets[i].Name == name {
+			return &wl.Spec.PodSets[i]
+		}
+	}
+	return nil
+}
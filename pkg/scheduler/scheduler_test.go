@@ -243,6 +243,11 @@ func TestSchedule(t *testing.T) {
 		disableLendingLimit     bool
 		disablePartialAdmission bool
 		enableFairSharing       bool
+		admissionFairSharing    *config.AdmissionFairSharing
+		enableBackfill          bool
+		enableReservations      bool
+		maxAdmissionsPerCycle   int32
+		enableParallelCohorts   bool
 
 		workloads      []kueue.Workload
 		admissionError error
@@ -250,6 +255,8 @@ func TestSchedule(t *testing.T) {
 		// additional*Queues can hold any extra queues needed by the tc
 		additionalClusterQueues []kueue.ClusterQueue
 		additionalLocalQueues   []kueue.LocalQueue
+		cohorts                 []kueuealpha.Cohort
+		reservations            []kueuealpha.Reservation
 
 		// wantAssignments is a summary of all the admissions in the cache after this cycle.
 		wantAssignments map[string]kueue.Admission
@@ -2167,6 +2174,490 @@ func TestSchedule(t *testing.T) {
 				"other-gamma": 0,
 			},
 		},
+		"hierarchical fair sharing prefers the Cohort with the lower share": {
+			// org-a uses more resources than org-b in total, but spread
+			// across multiple ClusterQueues, so a2 has a lower share than
+			// b1 when compared flat.
+			enableFairSharing: true,
+			cohorts: []kueuealpha.Cohort{
+				*utiltesting.MakeCohort("root").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "6").Obj()).
+					Obj(),
+				*utiltesting.MakeCohort("org-a").
+					Parent("root").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+					Obj(),
+				*utiltesting.MakeCohort("org-b").
+					Parent("root").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+					Obj(),
+			},
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("a1").
+					Cohort("org-a").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("a2").
+					Cohort("org-a").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("a3").
+					Cohort("org-a").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("b1").
+					Cohort("org-b").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-a1", "eng-alpha").ClusterQueue("a1").Obj(),
+				*utiltesting.MakeLocalQueue("lq-a2", "eng-alpha").ClusterQueue("a2").Obj(),
+				*utiltesting.MakeLocalQueue("lq-a3", "eng-alpha").ClusterQueue("a3").Obj(),
+				*utiltesting.MakeLocalQueue("lq-b1", "eng-beta").ClusterQueue("b1").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("admitted-a1", "eng-alpha").
+					Queue("lq-a1").
+					Request(corev1.ResourceCPU, "3").
+					ReserveQuota(utiltesting.MakeAdmission("a1").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("admitted-a3", "eng-alpha").
+					Queue("lq-a3").
+					Request(corev1.ResourceCPU, "3").
+					ReserveQuota(utiltesting.MakeAdmission("a3").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("admitted-b1", "eng-beta").
+					Queue("lq-b1").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuota(utiltesting.MakeAdmission("b1").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("pending-a2", "eng-alpha").
+					Queue("lq-a2").
+					Creation(now).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+				*utiltesting.MakeWorkload("pending-b1", "eng-beta").
+					Queue("lq-b1").
+					Creation(now.Add(time.Second)).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+			},
+			wantScheduled: []string{"eng-beta/pending-b1"},
+			wantLeft: map[string][]string{
+				"a2": {"eng-alpha/pending-a2"},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/admitted-a1": *utiltesting.MakeAdmission("a1").Assignment(corev1.ResourceCPU, "default", "3").Obj(),
+				"eng-alpha/admitted-a3": *utiltesting.MakeAdmission("a3").Assignment(corev1.ResourceCPU, "default", "3").Obj(),
+				"eng-beta/admitted-b1":  *utiltesting.MakeAdmission("b1").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+				"eng-beta/pending-b1":   *utiltesting.MakeAdmission("b1").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+			},
+		},
+		"admission fair sharing prefers the ClusterQueue with the lower consumption": {
+			admissionFairSharing: &config.AdmissionFairSharing{
+				UsageHalfLifeTime:     &metav1.Duration{Duration: time.Hour},
+				UsageSamplingInterval: &metav1.Duration{Duration: time.Minute},
+			},
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("afs-a").
+					Cohort("afs").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+					ConsumedResources(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3600")}, now).
+					Obj(),
+				*utiltesting.MakeClusterQueue("afs-b").
+					Cohort("afs").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+					ConsumedResources(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("60")}, now).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-a", "eng-alpha").ClusterQueue("afs-a").Obj(),
+				*utiltesting.MakeLocalQueue("lq-b", "eng-beta").ClusterQueue("afs-b").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("pending-a", "eng-alpha").
+					Queue("lq-a").
+					Creation(now).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+				*utiltesting.MakeWorkload("pending-b", "eng-beta").
+					Queue("lq-b").
+					Creation(now.Add(time.Second)).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			wantScheduled: []string{"eng-beta/pending-b"},
+			wantLeft: map[string][]string{
+				"afs-a": {"eng-alpha/pending-a"},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-beta/pending-b": *utiltesting.MakeAdmission("afs-b").Assignment(corev1.ResourceCPU, "default", "3").Obj(),
+			},
+		},
+		"multiple admissions per ClusterQueue up to the cap": {
+			maxAdmissionsPerCycle: 3,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("multi").
+					QueueingStrategy(kueue.StrictFIFO).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-multi", "eng-alpha").ClusterQueue("multi").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "eng-alpha").
+					Queue("lq-multi").
+					Creation(now).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+				*utiltesting.MakeWorkload("b", "eng-alpha").
+					Queue("lq-multi").
+					Creation(now.Add(time.Second)).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+				*utiltesting.MakeWorkload("c", "eng-alpha").
+					Queue("lq-multi").
+					Creation(now.Add(2*time.Second)).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+				*utiltesting.MakeWorkload("d", "eng-alpha").
+					Queue("lq-multi").
+					Creation(now.Add(3*time.Second)).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+			},
+			wantScheduled: []string{"eng-alpha/a", "eng-alpha/b", "eng-alpha/c"},
+			wantLeft: map[string][]string{
+				"multi": {"eng-alpha/d"},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/a": *utiltesting.MakeAdmission("multi").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+				"eng-alpha/b": *utiltesting.MakeAdmission("multi").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+				"eng-alpha/c": *utiltesting.MakeAdmission("multi").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+			},
+		},
+		"independent cohort trees are scheduled concurrently": {
+			enableParallelCohorts: true,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("main").
+					Request(corev1.ResourceCPU, "10").
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("main").
+					Request(corev1.ResourceCPU, "40").
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-beta").
+					Queue("main").
+					Request(corev1.ResourceCPU, "50").
+					Obj(),
+				*utiltesting.MakeWorkload("a", "lend").
+					Queue("lend-a-queue").
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			wantScheduled: []string{"sales/new", "eng-alpha/new", "eng-beta/new", "lend/a"},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/new":     *utiltesting.MakeAdmission("sales").Assignment(corev1.ResourceCPU, "default", "10").Obj(),
+				"eng-alpha/new": *utiltesting.MakeAdmission("eng-alpha").Assignment(corev1.ResourceCPU, "on-demand", "40").Obj(),
+				"eng-beta/new":  *utiltesting.MakeAdmission("eng-beta").Assignment(corev1.ResourceCPU, "on-demand", "50").Obj(),
+				"lend/a":        *utiltesting.MakeAdmission("lend-a").Assignment(corev1.ResourceCPU, "default", "3").Obj(),
+			},
+		},
+		"heads of the same cohort tree compete for the quota when scheduled concurrently": {
+			enableParallelCohorts: true,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("main").
+					Request(corev1.ResourceCPU, "10").
+					Obj(),
+				*utiltesting.MakeWorkload("borrowing", "eng-alpha").
+					Queue("main").
+					Request(corev1.ResourceCPU, "60").
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-beta").
+					Queue("main").
+					Request(corev1.ResourceCPU, "50").
+					Obj(),
+			},
+			wantScheduled: []string{"sales/new", "eng-beta/new"},
+			wantLeft: map[string][]string{
+				"eng-alpha": {"eng-alpha/borrowing"},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/new":    *utiltesting.MakeAdmission("sales").Assignment(corev1.ResourceCPU, "default", "10").Obj(),
+				"eng-beta/new": *utiltesting.MakeAdmission("eng-beta").Assignment(corev1.ResourceCPU, "on-demand", "50").Obj(),
+			},
+		},
+		"multiple admissions stop at the first workload that doesn't fit": {
+			maxAdmissionsPerCycle: 5,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("multi").
+					QueueingStrategy(kueue.BestEffortFIFO).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-multi", "eng-alpha").ClusterQueue("multi").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "eng-alpha").
+					Queue("lq-multi").
+					Creation(now).
+					Request(corev1.ResourceCPU, "4").
+					Obj(),
+				*utiltesting.MakeWorkload("b", "eng-alpha").
+					Queue("lq-multi").
+					Creation(now.Add(time.Second)).
+					Request(corev1.ResourceCPU, "8").
+					Obj(),
+				*utiltesting.MakeWorkload("c", "eng-alpha").
+					Queue("lq-multi").
+					Creation(now.Add(2*time.Second)).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			wantScheduled: []string{"eng-alpha/a"},
+			wantLeft: map[string][]string{
+				"multi": {"eng-alpha/c"},
+			},
+			wantInadmissibleLeft: map[string][]string{
+				"multi": {"eng-alpha/b"},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/a": *utiltesting.MakeAdmission("multi").Assignment(corev1.ResourceCPU, "default", "4").Obj(),
+			},
+		},
+		"backfill admits the workloads expected to finish before the blocked head": {
+			enableBackfill: true,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("backfill").
+					QueueingStrategy(kueue.StrictFIFO).
+					Backfill(10).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-backfill", "eng-alpha").ClusterQueue("backfill").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("running", "eng-alpha").
+					Queue("lq-backfill").
+					Request(corev1.ResourceCPU, "6").
+					MaximumExecutionTimeSeconds(600).
+					ReserveQuota(utiltesting.MakeAdmission("backfill").Assignment(corev1.ResourceCPU, "default", "6").Obj()).
+					Admitted(true).
+					Obj(),
+				*utiltesting.MakeWorkload("head", "eng-alpha").
+					Queue("lq-backfill").
+					Creation(now).
+					Request(corev1.ResourceCPU, "8").
+					Obj(),
+				*utiltesting.MakeWorkload("short", "eng-alpha").
+					Queue("lq-backfill").
+					Creation(now.Add(time.Second)).
+					Request(corev1.ResourceCPU, "2").
+					MaximumExecutionTimeSeconds(300).
+					Obj(),
+				*utiltesting.MakeWorkload("long", "eng-alpha").
+					Queue("lq-backfill").
+					Creation(now.Add(2*time.Second)).
+					Request(corev1.ResourceCPU, "2").
+					MaximumExecutionTimeSeconds(3600).
+					Obj(),
+				*utiltesting.MakeWorkload("unbounded", "eng-alpha").
+					Queue("lq-backfill").
+					Creation(now.Add(3*time.Second)).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+				*utiltesting.MakeWorkload("short-too-big", "eng-alpha").
+					Queue("lq-backfill").
+					Creation(now.Add(4*time.Second)).
+					Request(corev1.ResourceCPU, "3").
+					MaximumExecutionTimeSeconds(60).
+					Obj(),
+			},
+			wantScheduled: []string{"eng-alpha/short"},
+			wantLeft: map[string][]string{
+				"backfill": {"eng-alpha/head", "eng-alpha/long", "eng-alpha/short-too-big", "eng-alpha/unbounded"},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/running": *utiltesting.MakeAdmission("backfill").Assignment(corev1.ResourceCPU, "default", "6").Obj(),
+				"eng-alpha/short":   *utiltesting.MakeAdmission("backfill").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+			},
+		},
+		"backfill doesn't let the other ClusterQueues of the cohort take the capacity of the blocked head": {
+			enableBackfill: true,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("backfill").
+					Cohort("backfill-cohort").
+					QueueingStrategy(kueue.StrictFIFO).
+					Backfill(10).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("borrower").
+					Cohort("backfill-cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-backfill", "eng-alpha").ClusterQueue("backfill").Obj(),
+				*utiltesting.MakeLocalQueue("lq-borrower", "eng-alpha").ClusterQueue("borrower").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("running", "eng-alpha").
+					Queue("lq-backfill").
+					Request(corev1.ResourceCPU, "6").
+					MaximumExecutionTimeSeconds(600).
+					ReserveQuota(utiltesting.MakeAdmission("backfill").Assignment(corev1.ResourceCPU, "default", "6").Obj()).
+					Admitted(true).
+					Obj(),
+				*utiltesting.MakeWorkload("head", "eng-alpha").
+					Queue("lq-backfill").
+					Creation(now).
+					Request(corev1.ResourceCPU, "8").
+					Obj(),
+				*utiltesting.MakeWorkload("short", "eng-alpha").
+					Queue("lq-backfill").
+					Creation(now.Add(time.Second)).
+					Request(corev1.ResourceCPU, "2").
+					MaximumExecutionTimeSeconds(300).
+					Obj(),
+				*utiltesting.MakeWorkload("borrowing", "eng-alpha").
+					Queue("lq-borrower").
+					Creation(now.Add(time.Second)).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+			},
+			wantScheduled: []string{"eng-alpha/short"},
+			wantLeft: map[string][]string{
+				"backfill": {"eng-alpha/head"},
+				"borrower": {"eng-alpha/borrowing"},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/running": *utiltesting.MakeAdmission("backfill").Assignment(corev1.ResourceCPU, "default", "6").Obj(),
+				"eng-alpha/short":   *utiltesting.MakeAdmission("backfill").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+			},
+		},
+		"backfill disabled keeps the StrictFIFO head blocking": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("backfill").
+					QueueingStrategy(kueue.StrictFIFO).
+					Backfill(10).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-backfill", "eng-alpha").ClusterQueue("backfill").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("running", "eng-alpha").
+					Queue("lq-backfill").
+					Request(corev1.ResourceCPU, "6").
+					MaximumExecutionTimeSeconds(600).
+					ReserveQuota(utiltesting.MakeAdmission("backfill").Assignment(corev1.ResourceCPU, "default", "6").Obj()).
+					Admitted(true).
+					Obj(),
+				*utiltesting.MakeWorkload("head", "eng-alpha").
+					Queue("lq-backfill").
+					Creation(now).
+					Request(corev1.ResourceCPU, "8").
+					Obj(),
+				*utiltesting.MakeWorkload("short", "eng-alpha").
+					Queue("lq-backfill").
+					Creation(now.Add(time.Second)).
+					Request(corev1.ResourceCPU, "2").
+					MaximumExecutionTimeSeconds(300).
+					Obj(),
+			},
+			wantLeft: map[string][]string{
+				"backfill": {"eng-alpha/head", "eng-alpha/short"},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/running": *utiltesting.MakeAdmission("backfill").Assignment(corev1.ResourceCPU, "default", "6").Obj(),
+			},
+		},
+		"reservation blocks the workloads not referencing it": {
+			enableReservations: true,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("reserved").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-reserved", "eng-alpha").ClusterQueue("reserved").Obj(),
+			},
+			reservations: []kueuealpha.Reservation{
+				*utiltesting.MakeReservation("maintenance", "reserved", now.Add(time.Hour), now.Add(2*time.Hour)).
+					Flavor("default", corev1.ResourceCPU, "6").
+					LeadTime(2 * time.Hour).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("other", "eng-alpha").
+					Queue("lq-reserved").
+					Request(corev1.ResourceCPU, "6").
+					Obj(),
+			},
+			wantInadmissibleLeft: map[string][]string{
+				"reserved": {"eng-alpha/other"},
+			},
+		},
+		"reservation admits the workloads referencing it during its window": {
+			enableReservations: true,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("reserved").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-reserved", "eng-alpha").ClusterQueue("reserved").Obj(),
+			},
+			reservations: []kueuealpha.Reservation{
+				*utiltesting.MakeReservation("maintenance", "reserved", now.Add(-time.Hour), now.Add(time.Hour)).
+					Flavor("default", corev1.ResourceCPU, "6").
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("referencing", "eng-alpha").
+					Queue("lq-reserved").
+					Label(kueuealpha.ReservationLabel, "maintenance").
+					Request(corev1.ResourceCPU, "8").
+					Obj(),
+			},
+			wantScheduled: []string{"eng-alpha/referencing"},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/referencing": *utiltesting.MakeAdmission("reserved").Assignment(corev1.ResourceCPU, "default", "8").Obj(),
+			},
+		},
+		"workload referencing a reservation before its window stays pending": {
+			enableReservations: true,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("reserved").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-reserved", "eng-alpha").ClusterQueue("reserved").Obj(),
+			},
+			reservations: []kueuealpha.Reservation{
+				*utiltesting.MakeReservation("maintenance", "reserved", now.Add(time.Hour), now.Add(2*time.Hour)).
+					Flavor("default", corev1.ResourceCPU, "6").
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("referencing", "eng-alpha").
+					Queue("lq-reserved").
+					Label(kueuealpha.ReservationLabel, "maintenance").
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			wantInadmissibleLeft: map[string][]string{
+				"reserved": {"eng-alpha/referencing"},
+			},
+		},
 		"not enough resources": {
 			workloads: []kueue.Workload{
 				*utiltesting.MakeWorkload("new", "sales").
@@ -2498,6 +2989,15 @@ func TestSchedule(t *testing.T) {
 			if tc.disablePartialAdmission {
 				features.SetFeatureGateDuringTest(t, features.PartialAdmission, false)
 			}
+			if tc.enableBackfill {
+				features.SetFeatureGateDuringTest(t, features.StrictFIFOBackfill, true)
+			}
+			if tc.enableReservations {
+				features.SetFeatureGateDuringTest(t, features.AdvanceReservations, true)
+			}
+			if tc.enableParallelCohorts {
+				features.SetFeatureGateDuringTest(t, features.ParallelCohortScheduling, true)
+			}
 			ctx, _ := utiltesting.ContextWithLog(t)
 
 			allQueues := append(queues, tc.additionalLocalQueues...)
@@ -2514,7 +3014,7 @@ func TestSchedule(t *testing.T) {
 				)
 			cl := clientBuilder.Build()
 			recorder := &utiltesting.EventRecorder{}
-			cqCache := cache.New(cl)
+			cqCache := cache.New(cl, cache.WithClock(t, fakeClock))
 			qManager := queue.NewManager(cl, cqCache)
 			// Workloads are loaded into queues or clusterQueues as we add them.
 			for _, q := range allQueues {
@@ -2525,6 +3025,14 @@ func TestSchedule(t *testing.T) {
 			for i := range resourceFlavors {
 				cqCache.AddOrUpdateResourceFlavor(resourceFlavors[i])
 			}
+			for i := range tc.reservations {
+				cqCache.AddOrUpdateReservation(&tc.reservations[i])
+			}
+			for i := range tc.cohorts {
+				if err := cqCache.AddOrUpdateCohort(&tc.cohorts[i]); err != nil {
+					t.Fatalf("Inserting cohort %s in cache: %v", tc.cohorts[i].Name, err)
+				}
+			}
 			for _, cq := range allClusterQueues {
 				if err := cqCache.AddClusterQueue(ctx, &cq); err != nil {
 					t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
@@ -2536,7 +3044,15 @@ func TestSchedule(t *testing.T) {
 					t.Errorf("couldn't create the cluster queue: %v", err)
 				}
 			}
-			scheduler := New(qManager, cqCache, cl, recorder, WithFairSharing(&config.FairSharing{Enable: tc.enableFairSharing}), WithClock(t, fakeClock))
+			opts := []Option{
+				WithFairSharing(&config.FairSharing{Enable: tc.enableFairSharing}),
+				WithAdmissionFairSharing(tc.admissionFairSharing),
+				WithClock(t, fakeClock),
+			}
+			if tc.maxAdmissionsPerCycle > 0 {
+				opts = append(opts, WithMaxAdmissionsPerCycle(tc.maxAdmissionsPerCycle))
+			}
+			scheduler := New(qManager, cqCache, cl, recorder, opts...)
 			gotScheduled := make(map[string]kueue.Admission)
 			var mu sync.Mutex
 			scheduler.applyAdmission = func(ctx context.Context, w *kueue.Workload) error {
@@ -2907,6 +3423,59 @@ func TestEntryOrdering(t *testing.T) {
 	}
 }
 
+func TestEntryOrderingFairSharing(t *testing.T) {
+	now := time.Now()
+	// The shares go from the root of the Cohort tree down to the ClusterQueue.
+	// "tree1-*" are in a tree with a Cohort and ClusterQueues, "tree2-*" in a
+	// tree with nested Cohorts.
+	shares := map[string][]int{
+		"standalone":        {0},
+		"tree1-not-borrow":  {0, 0},
+		"tree1-borrow":      {0, 300},
+		"tree2-low-cohort":  {0, 100, 500},
+		"tree2-high-cohort": {0, 200, 0},
+	}
+	var input []entry
+	for i, name := range []string{"standalone", "tree1-not-borrow", "tree1-borrow", "tree2-low-cohort", "tree2-high-cohort"} {
+		input = append(input, entry{
+			Info: workload.Info{
+				Obj: &kueue.Workload{ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					CreationTimestamp: metav1.NewTime(now.Add(time.Duration(i) * time.Second)),
+				}},
+			},
+			fairSharingShares: shares[name],
+		})
+	}
+	wantOrder := []string{"standalone", "tree1-not-borrow", "tree2-low-cohort", "tree2-high-cohort", "tree1-borrow"}
+
+	// The order must not depend on the order of the input.
+	var permute func(int)
+	permute = func(k int) {
+		if k == len(input) {
+			entries := append([]entry(nil), input...)
+			sort.Sort(entryOrdering{
+				enableFairSharing: true,
+				entries:           entries,
+			})
+			order := make([]string, len(entries))
+			for i, e := range entries {
+				order[i] = e.Obj.Name
+			}
+			if diff := cmp.Diff(wantOrder, order); diff != "" {
+				t.Errorf("Unexpected order (-want,+got):\n%s", diff)
+			}
+			return
+		}
+		for i := k; i < len(input); i++ {
+			input[k], input[i] = input[i], input[k]
+			permute(k + 1)
+			input[k], input[i] = input[i], input[k]
+		}
+	}
+	permute(0)
+}
+
 func TestLastSchedulingContext(t *testing.T) {
 	now := time.Now()
 	fakeClock := testingclock.NewFakeClock(now)
@@ -3800,6 +4369,59 @@ func TestResourcesToReserve(t *testing.T) {
 	}
 }
 
+func TestPartitionHeads(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cqCache := cache.New(utiltesting.NewFakeClient())
+	for _, cohort := range []*kueuealpha.Cohort{
+		utiltesting.MakeCohort("org").Obj(),
+		utiltesting.MakeCohort("eng").Parent("org").Obj(),
+		utiltesting.MakeCohort("research").Parent("org").Obj(),
+	} {
+		if err := cqCache.AddOrUpdateCohort(cohort); err != nil {
+			t.Fatalf("Inserting cohort %s in cache: %v", cohort.Name, err)
+		}
+	}
+	for _, cq := range []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("eng-a").Cohort("eng").Obj(),
+		utiltesting.MakeClusterQueue("eng-b").Cohort("eng").Obj(),
+		utiltesting.MakeClusterQueue("research-a").Cohort("research").Obj(),
+		utiltesting.MakeClusterQueue("sales-a").Cohort("sales").Obj(),
+		utiltesting.MakeClusterQueue("standalone").Obj(),
+	} {
+		if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+		}
+	}
+	snapshot, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+
+	var heads []workload.Info
+	for _, cqName := range []string{"eng-a", "sales-a", "standalone", "research-a", "unknown", "eng-b"} {
+		wl := workload.NewInfo(utiltesting.MakeWorkload("wl-"+cqName, "ns").Obj())
+		wl.ClusterQueue = cqName
+		heads = append(heads, *wl)
+	}
+	want := [][]string{
+		{"eng-a", "research-a", "eng-b"},
+		{"sales-a"},
+		{"standalone"},
+		{"unknown"},
+	}
+	var got [][]string
+	for _, partition := range partitionHeads(heads, snapshot) {
+		var cqNames []string
+		for _, wl := range partition {
+			cqNames = append(cqNames, wl.ClusterQueue)
+		}
+		got = append(got, cqNames)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected partitions (-want,+got):\n%s", diff)
+	}
+}
+
 func TestScheduleForTAS(t *testing.T) {
 	const (
 		tasRackLabel = "cloud.provider.com/rack"
@@ -3843,13 +4465,68 @@ func TestScheduleForTAS(t *testing.T) {
 				ClusterQueue: "tas-main",
 			},
 		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "tas-second",
+			},
+			Spec: kueue.LocalQueueSpec{
+				ClusterQueue: "tas-second",
+			},
+		},
 	}
 	eventIgnoreMessage := cmpopts.IgnoreFields(utiltesting.EventRecord{}, "Message")
+	provReqAdmissionCheck := *utiltesting.MakeAdmissionCheck("prov-check").
+		ControllerName(kueue.ProvisioningRequestControllerName).
+		Active(metav1.ConditionTrue).
+		Obj()
+	multiKueueAdmissionCheck := *utiltesting.MakeAdmissionCheck("mk-check").
+		ControllerName(kueue.MultiKueueControllerName).
+		Active(metav1.ConditionTrue).
+		Obj()
+	rackNodes := make([]corev1.Node, 0, 4)
+	for _, n := range []struct{ name, rack string }{{"x1", "r1"}, {"x2", "r1"}, {"x3", "r2"}, {"x4", "r2"}} {
+		rackNodes = append(rackNodes, *testingnode.MakeNode(n.name).
+			Label("tas-node", "true").
+			Label(tasRackLabel, n.rack).
+			Label(corev1.LabelHostname, n.name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			}).
+			Ready().
+			Obj())
+	}
+	admittedOnNode := func(name string, priority int32, node string) kueue.Workload {
+		return *utiltesting.MakeWorkload(name, "default").
+			Queue("tas-main").
+			Priority(priority).
+			ReserveQuota(
+				utiltesting.MakeAdmission("tas-main", "one").
+					Assignment(corev1.ResourceCPU, "tas-default", "1000m").
+					AssignmentPodCount(1).
+					TopologyAssignment(&kueue.TopologyAssignment{
+						Levels: []string{corev1.LabelHostname},
+						Domains: []kueue.TopologyDomainAssignment{
+							{
+								Count:  1,
+								Values: []string{node},
+							},
+						},
+					}).Obj(),
+			).
+			Admitted(true).
+			PodSets(*utiltesting.MakePodSet("one", 1).
+				RequiredTopologyRequest(corev1.LabelHostname).
+				Request(corev1.ResourceCPU, "1").
+				Obj()).
+			Obj()
+	}
 	cases := map[string]struct {
 		nodes           []corev1.Node
 		pods            []corev1.Pod
 		topologies      []kueuealpha.Topology
 		resourceFlavors []kueue.ResourceFlavor
+		admissionChecks []kueue.AdmissionCheck
 		clusterQueues   []kueue.ClusterQueue
 		workloads       []kueue.Workload
 
@@ -3859,11 +4536,191 @@ func TestScheduleForTAS(t *testing.T) {
 		wantLeft map[string][]string
 		// wantInadmissibleLeft is the workload keys that are left in the inadmissible state after this cycle.
 		wantInadmissibleLeft map[string][]string
+		// wantPreempted is the keys of the workloads that get preempted in the scheduling cycle.
+		wantPreempted sets.Set[string]
 		// wantEvents asserts on the events, the comparison options are passed by eventCmpOpts
 		wantEvents []utiltesting.EventRecord
 		// eventCmpOpts are the comparison options for the events
 		eventCmpOpts []cmp.Option
 	}{
+		"workload in ClusterQueue with ProvisioningRequest AdmissionCheck delays the topology assignment": {
+			nodes:           defaultSingleNode,
+			topologies:      []kueuealpha.Topology{defaultSingleLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{defaultTASFlavor},
+			admissionChecks: []kueue.AdmissionCheck{provReqAdmissionCheck},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tas-main").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-default").
+						Resource(corev1.ResourceCPU, "50").Obj()).
+					AdmissionChecks("prov-check").
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					PodSets(*utiltesting.MakePodSet("one", 3).
+						RequiredTopologyRequest(corev1.LabelHostname).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantNewAssignments: map[string]kueue.Admission{
+				"default/foo": *utiltesting.MakeAdmission("tas-main", "one").
+					Assignment(corev1.ResourceCPU, "tas-default", "3000m").
+					AssignmentPodCount(3).
+					DelayedTopologyRequest(kueue.DelayedTopologyRequestStatePending).
+					Obj(),
+			},
+			eventCmpOpts: []cmp.Option{eventIgnoreMessage},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "QuotaReserved",
+					EventType: corev1.EventTypeNormal,
+				},
+			},
+		},
+		"workload in ClusterQueue with MultiKueue AdmissionCheck leaves the topology assignment to the worker": {
+			topologies:      []kueuealpha.Topology{defaultSingleLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{defaultTASFlavor},
+			admissionChecks: []kueue.AdmissionCheck{multiKueueAdmissionCheck},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tas-main").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-default").
+						Resource(corev1.ResourceCPU, "50").Obj()).
+					AdmissionChecks("mk-check").
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					PodSets(*utiltesting.MakePodSet("one", 3).
+						RequiredTopologyRequest(corev1.LabelHostname).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantNewAssignments: map[string]kueue.Admission{
+				"default/foo": *utiltesting.MakeAdmission("tas-main", "one").
+					Assignment(corev1.ResourceCPU, "tas-default", "3000m").
+					AssignmentPodCount(3).
+					Obj(),
+			},
+			eventCmpOpts: []cmp.Option{eventIgnoreMessage},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "QuotaReserved",
+					EventType: corev1.EventTypeNormal,
+				},
+			},
+		},
+		"delayed topology assignment is computed once the ProvisioningRequest is Ready": {
+			nodes:           defaultSingleNode,
+			topologies:      []kueuealpha.Topology{defaultSingleLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{defaultTASFlavor},
+			admissionChecks: []kueue.AdmissionCheck{provReqAdmissionCheck},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tas-main").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-default").
+						Resource(corev1.ResourceCPU, "50").Obj()).
+					AdmissionChecks("prov-check").
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						RequiredTopologyRequest(corev1.LabelHostname).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					ReserveQuota(
+						utiltesting.MakeAdmission("tas-main", "one").
+							Assignment(corev1.ResourceCPU, "tas-default", "1000m").
+							AssignmentPodCount(1).
+							DelayedTopologyRequest(kueue.DelayedTopologyRequestStatePending).
+							Obj(),
+					).
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:  "prov-check",
+						State: kueue.CheckStateReady,
+					}).
+					Obj(),
+			},
+			wantNewAssignments: map[string]kueue.Admission{
+				"default/foo": *utiltesting.MakeAdmission("tas-main", "one").
+					Assignment(corev1.ResourceCPU, "tas-default", "1000m").
+					AssignmentPodCount(1).
+					DelayedTopologyRequest(kueue.DelayedTopologyRequestStateReady).
+					TopologyAssignment(&kueue.TopologyAssignment{
+						Levels: utiltas.Levels(&defaultSingleLevelTopology),
+						Domains: []kueue.TopologyDomainAssignment{
+							{
+								Count: 1,
+								Values: []string{
+									"x1",
+								},
+							},
+						},
+					}).Obj(),
+			},
+			eventCmpOpts: []cmp.Option{eventIgnoreMessage},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "Admitted",
+					EventType: corev1.EventTypeNormal,
+				},
+			},
+		},
+		"delayed topology assignment fails when the provisioned nodes are not found": {
+			nodes:           defaultSingleNode,
+			topologies:      []kueuealpha.Topology{defaultSingleLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{defaultTASFlavor},
+			admissionChecks: []kueue.AdmissionCheck{provReqAdmissionCheck},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tas-main").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-default").
+						Resource(corev1.ResourceCPU, "50").Obj()).
+					AdmissionChecks("prov-check").
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					PodSets(*utiltesting.MakePodSet("one", 2).
+						RequiredTopologyRequest(corev1.LabelHostname).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					ReserveQuota(
+						utiltesting.MakeAdmission("tas-main", "one").
+							Assignment(corev1.ResourceCPU, "tas-default", "2000m").
+							AssignmentPodCount(2).
+							DelayedTopologyRequest(kueue.DelayedTopologyRequestStatePending).
+							Obj(),
+					).
+					AdmissionCheck(kueue.AdmissionCheckState{
+						Name:  "prov-check",
+						State: kueue.CheckStateReady,
+					}).
+					Obj(),
+			},
+			wantNewAssignments: map[string]kueue.Admission{
+				"default/foo": *utiltesting.MakeAdmission("tas-main", "one").
+					Assignment(corev1.ResourceCPU, "tas-default", "2000m").
+					AssignmentPodCount(2).
+					DelayedTopologyRequest(kueue.DelayedTopologyRequestStatePending).
+					Obj(),
+			},
+			eventCmpOpts: []cmp.Option{eventIgnoreMessage},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "TopologyAssignmentFailed",
+					EventType: corev1.EventTypeWarning,
+				},
+			},
+		},
 		"workload requiring TAS skips the non-TAS flavor": {
 			nodes:           defaultSingleNode,
 			topologies:      []kueuealpha.Topology{defaultSingleLevelTopology},
@@ -4574,6 +5431,357 @@ func TestScheduleForTAS(t *testing.T) {
 				},
 			},
 		},
+		"workload borrows TAS quota from another ClusterQueue in the cohort": {
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("x1").
+					Label("tas-node", "true").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("4"),
+					}).
+					Ready().
+					Obj(),
+			},
+			topologies:      []kueuealpha.Topology{defaultSingleLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{defaultTASFlavor},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tas-main").
+					Cohort("tas-cohort").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("tas-default").
+							Resource(corev1.ResourceCPU, "1").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("tas-second").
+					Cohort("tas-cohort").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("tas-default").
+							Resource(corev1.ResourceCPU, "5").Obj()).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					PodSets(*utiltesting.MakePodSet("one", 3).
+						RequiredTopologyRequest(corev1.LabelHostname).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantNewAssignments: map[string]kueue.Admission{
+				"default/foo": *utiltesting.MakeAdmission("tas-main", "one").
+					Assignment(corev1.ResourceCPU, "tas-default", "3000m").
+					AssignmentPodCount(3).
+					TopologyAssignment(&kueue.TopologyAssignment{
+						Levels: utiltas.Levels(&defaultSingleLevelTopology),
+						Domains: []kueue.TopologyDomainAssignment{
+							{
+								Count: 3,
+								Values: []string{
+									"x1",
+								},
+							},
+						},
+					}).Obj(),
+			},
+			eventCmpOpts: []cmp.Option{eventIgnoreMessage},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "QuotaReserved",
+					EventType: corev1.EventTypeNormal,
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "Admitted",
+					EventType: corev1.EventTypeNormal,
+				},
+			},
+		},
+		"workload from another ClusterQueue in the cohort is skipped as the topology is already used in the cycle": {
+			nodes:           defaultSingleNode,
+			topologies:      []kueuealpha.Topology{defaultSingleLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{defaultTASFlavor},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tas-main").
+					Cohort("tas-cohort").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("tas-default").
+							Resource(corev1.ResourceCPU, "5").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("tas-second").
+					Cohort("tas-cohort").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("tas-default").
+							Resource(corev1.ResourceCPU, "5").Obj()).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					Creation(time.Now().Add(-time.Second)).
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						RequiredTopologyRequest(corev1.LabelHostname).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("bar", "default").
+					Queue("tas-second").
+					Creation(time.Now()).
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						RequiredTopologyRequest(corev1.LabelHostname).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantNewAssignments: map[string]kueue.Admission{
+				"default/foo": *utiltesting.MakeAdmission("tas-main", "one").
+					Assignment(corev1.ResourceCPU, "tas-default", "1000m").
+					AssignmentPodCount(1).
+					TopologyAssignment(&kueue.TopologyAssignment{
+						Levels: utiltas.Levels(&defaultSingleLevelTopology),
+						Domains: []kueue.TopologyDomainAssignment{
+							{
+								Count: 1,
+								Values: []string{
+									"x1",
+								},
+							},
+						},
+					}).Obj(),
+			},
+			wantLeft: map[string][]string{
+				"tas-second": {"default/bar"},
+			},
+			eventCmpOpts: []cmp.Option{
+				cmpopts.IgnoreFields(utiltesting.EventRecord{}, "Message"),
+				cmpopts.SortSlices(func(a, b utiltesting.EventRecord) bool {
+					return a.Key.String() < b.Key.String() || (a.Key == b.Key && a.Reason < b.Reason)
+				}),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "bar"},
+					Reason:    "Pending",
+					EventType: corev1.EventTypeWarning,
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "Admitted",
+					EventType: corev1.EventTypeNormal,
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "QuotaReserved",
+					EventType: corev1.EventTypeNormal,
+				},
+			},
+		},
+		"workload preempts lower priority workloads to free a rack": {
+			nodes:           rackNodes,
+			topologies:      []kueuealpha.Topology{defaultTwoLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{defaultTASTwoLevelFlavor},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tas-main").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-default").
+						Resource(corev1.ResourceCPU, "50").Obj()).
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+					}).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					Priority(10).
+					PodSets(*utiltesting.MakePodSet("one", 2).
+						RequiredTopologyRequest(tasRackLabel).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				// Evicting "low" frees x3, but x4 in the same rack is used by
+				// "high", which cannot be preempted, so the workloads in rack r1
+				// need to be evicted instead.
+				admittedOnNode("low", 0, "x3"),
+				admittedOnNode("mid-1", 1, "x1"),
+				admittedOnNode("mid-2", 2, "x2"),
+				admittedOnNode("high", 100, "x4"),
+			},
+			wantLeft: map[string][]string{
+				"tas-main": {"default/foo"},
+			},
+			wantPreempted: sets.New("default/mid-1", "default/mid-2"),
+			eventCmpOpts: []cmp.Option{
+				eventIgnoreMessage,
+				cmpopts.SortSlices(func(a, b utiltesting.EventRecord) bool {
+					return a.Key.String() < b.Key.String()
+				}),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "Pending",
+					EventType: corev1.EventTypeWarning,
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "mid-1"},
+					Reason:    "Preempted",
+					EventType: corev1.EventTypeNormal,
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "mid-2"},
+					Reason:    "Preempted",
+					EventType: corev1.EventTypeNormal,
+				},
+			},
+		},
+		"workload borrowing in the cohort preempts to free a rack when allowed by borrowWithinCohort": {
+			nodes:           rackNodes,
+			topologies:      []kueuealpha.Topology{defaultTwoLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{defaultTASTwoLevelFlavor},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tas-main").
+					Cohort("tas-cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-default").
+						Resource(corev1.ResourceCPU, "1").Obj()).
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+						BorrowWithinCohort: &kueue.BorrowWithinCohort{
+							Policy: kueue.BorrowWithinCohortPolicyLowerPriority,
+						},
+					}).
+					Obj(),
+				*utiltesting.MakeClusterQueue("tas-lender").
+					Cohort("tas-cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-default").
+						Resource(corev1.ResourceCPU, "49").Obj()).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					Priority(10).
+					PodSets(*utiltesting.MakePodSet("one", 2).
+						RequiredTopologyRequest(tasRackLabel).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				admittedOnNode("low", 0, "x3"),
+				admittedOnNode("mid-1", 1, "x1"),
+				admittedOnNode("mid-2", 2, "x2"),
+				admittedOnNode("high", 100, "x4"),
+			},
+			wantLeft: map[string][]string{
+				"tas-main": {"default/foo"},
+			},
+			wantPreempted: sets.New("default/mid-1", "default/mid-2"),
+			eventCmpOpts: []cmp.Option{
+				eventIgnoreMessage,
+				cmpopts.SortSlices(func(a, b utiltesting.EventRecord) bool {
+					return a.Key.String() < b.Key.String()
+				}),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "Pending",
+					EventType: corev1.EventTypeWarning,
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "mid-1"},
+					Reason:    "Preempted",
+					EventType: corev1.EventTypeNormal,
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "mid-2"},
+					Reason:    "Preempted",
+					EventType: corev1.EventTypeNormal,
+				},
+			},
+		},
+		"workload borrowing in the cohort does not preempt to free a rack without borrowWithinCohort": {
+			nodes:           rackNodes,
+			topologies:      []kueuealpha.Topology{defaultTwoLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{defaultTASTwoLevelFlavor},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tas-main").
+					Cohort("tas-cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-default").
+						Resource(corev1.ResourceCPU, "1").Obj()).
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+					}).
+					Obj(),
+				*utiltesting.MakeClusterQueue("tas-lender").
+					Cohort("tas-cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-default").
+						Resource(corev1.ResourceCPU, "49").Obj()).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					Priority(10).
+					PodSets(*utiltesting.MakePodSet("one", 2).
+						RequiredTopologyRequest(tasRackLabel).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				admittedOnNode("low", 0, "x3"),
+				admittedOnNode("mid-1", 1, "x1"),
+				admittedOnNode("mid-2", 2, "x2"),
+				admittedOnNode("high", 100, "x4"),
+			},
+			wantInadmissibleLeft: map[string][]string{
+				"tas-main": {"default/foo"},
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "Pending",
+					EventType: corev1.EventTypeWarning,
+					Message:   `couldn't assign flavors to pod set one: topology "tas-two-level" doesn't allow to fit any of 2 pod(s)`,
+				},
+			},
+		},
+		"workload does not preempt when evicting the candidates does not free a rack": {
+			nodes:           rackNodes,
+			topologies:      []kueuealpha.Topology{defaultTwoLevelTopology},
+			resourceFlavors: []kueue.ResourceFlavor{defaultTASTwoLevelFlavor},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("tas-main").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-default").
+						Resource(corev1.ResourceCPU, "50").Obj()).
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+					}).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "default").
+					Queue("tas-main").
+					Priority(10).
+					PodSets(*utiltesting.MakePodSet("one", 2).
+						RequiredTopologyRequest(tasRackLabel).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				admittedOnNode("low-1", 0, "x1"),
+				admittedOnNode("low-2", 0, "x3"),
+				admittedOnNode("high-1", 100, "x2"),
+				admittedOnNode("high-2", 100, "x4"),
+			},
+			wantInadmissibleLeft: map[string][]string{
+				"tas-main": {"default/foo"},
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "default", Name: "foo"},
+					Reason:    "Pending",
+					EventType: corev1.EventTypeWarning,
+					Message:   `couldn't assign flavors to pod set one: topology "tas-two-level" doesn't allow to fit any of 2 pod(s)`,
+				},
+			},
+		},
 		"scheduling workload on a tainted node when the toleration is on ResourceFlavor": {
 			nodes: []corev1.Node{
 				*testingnode.MakeNode("x1").
@@ -4690,6 +5898,9 @@ func TestScheduleForTAS(t *testing.T) {
 					tasCache.Set(kueue.ResourceFlavorReference(flavor.Name), tasFlavorCache)
 				}
 			}
+			for _, ac := range tc.admissionChecks {
+				cqCache.AddOrUpdateAdmissionCheck(&ac)
+			}
 			for _, cq := range tc.clusterQueues {
 				if err := cqCache.AddClusterQueue(ctx, &cq); err != nil {
 					t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
@@ -4711,6 +5922,7 @@ func TestScheduleForTAS(t *testing.T) {
 				if workload.IsAdmitted(&w) {
 					initiallyAdmittedWorkloads.Insert(workload.Key(&w))
 				}
+				qManager.QueueSecondPassIfNeeded(ctx, &w)
 			}
 			scheduler := New(qManager, cqCache, cl, recorder)
 			gotScheduled := make([]string, 0)
@@ -4721,6 +5933,13 @@ func TestScheduleForTAS(t *testing.T) {
 				mu.Unlock()
 				return nil
 			}
+			gotPreempted := sets.New[string]()
+			scheduler.preemptor.OverrideApply(func(_ context.Context, w *kueue.Workload, _, _ string) error {
+				mu.Lock()
+				gotPreempted.Insert(workload.Key(w))
+				mu.Unlock()
+				return nil
+			})
 			wg := sync.WaitGroup{}
 			scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
 				func() { wg.Add(1) },
@@ -4756,6 +5975,9 @@ func TestScheduleForTAS(t *testing.T) {
 			if diff := cmp.Diff(tc.wantNewAssignments, gotAssignments, cmpopts.EquateEmpty()); diff != "" {
 				t.Errorf("Unexpected assigned clusterQueues in cache (-want,+got):\n%s", diff)
 			}
+			if diff := cmp.Diff(tc.wantPreempted, gotPreempted, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected preemptions (-want,+got):\n%s", diff)
+			}
 			qDump := qManager.Dump()
 			if diff := cmp.Diff(tc.wantLeft, qDump, cmpDump...); diff != "" {
 				t.Errorf("Unexpected elements left in the queue (-want,+got):\n%s", diff)
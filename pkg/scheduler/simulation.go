@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/util/routine"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// ErrWorkloadAlreadyAdmitted is returned by SimulateAdmission when a workload
+// with the same namespace and name is already assumed or admitted.
+var ErrWorkloadAlreadyAdmitted = errors.New("a workload with the same name is already assumed or admitted")
+
+// SimulationResult holds the outcome of a simulated admission.
+type SimulationResult struct {
+	// Assignment is the flavor assignment computed for the workload.
+	Assignment flavorassigner.Assignment
+	// PreemptionTargets are the workloads that would be preempted.
+	PreemptionTargets []*preemption.Target
+	// Message explains why the workload can't be admitted, if that's the case.
+	Message string
+}
+
+// Mode returns the representative mode of the simulated assignment.
+func (r *SimulationResult) Mode() flavorassigner.FlavorAssignmentMode {
+	return r.Assignment.RepresentativeMode()
+}
+
+// SimulateAdmission evaluates whether wl would be admitted by the ClusterQueue
+// cqName if it were at the head of the queue, using a fresh snapshot of the cache.
+// It follows the same steps as a scheduling cycle up to the calculation of the
+// preemption targets, but it doesn't modify the cache, the queues nor the
+// objects in the API server.
+func (s *Scheduler) SimulateAdmission(ctx context.Context, wl *kueue.Workload, cqName string) (*SimulationResult, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("workload", klog.KObj(wl), "clusterQueue", klog.KRef("", cqName))
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	snapshot, err := s.cache.Snapshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("building snapshot: %w", err)
+	}
+	if snapshot.ClusterQueues[cqName] == nil && !snapshot.InactiveClusterQueueSets.Has(cqName) {
+		return nil, cache.ErrCqNotFound
+	}
+	// The simulated workload would be indistinguishable from an admitted
+	// workload with the same key, both in the snapshot and in the cache.
+	key := workload.Key(wl)
+	for _, cq := range snapshot.ClusterQueues {
+		if _, found := cq.Workloads[key]; found {
+			return nil, fmt.Errorf("%w: %s in ClusterQueue %s", ErrWorkloadAlreadyAdmitted, key, cq.Name)
+		}
+	}
+
+	// The simulated workload is always evaluated as a new workload, regardless
+	// of the status sent by the caller.
+	wl = wl.DeepCopy()
+	wl.Status = kueue.WorkloadStatus{}
+	wInfo := s.queues.NewWorkloadInfo(wl)
+	wInfo.ClusterQueue = cqName
+
+	entries := s.nominate(ctx, []workload.Info{*wInfo}, snapshot)
+	if len(entries) == 0 {
+		// nominate skips the workloads that are assumed or admitted in the cache.
+		return nil, fmt.Errorf("%w: %s", ErrWorkloadAlreadyAdmitted, key)
+	}
+	e := entries[0]
+	return &SimulationResult{
+		Assignment:        e.assignment,
+		PreemptionTargets: e.preemptionTargets,
+		Message:           e.inadmissibleMsg,
+	}, nil
+}
+
+// RunCycle runs a single scheduling cycle and waits until the admissions of
+// the cycle are applied. Unlike the scheduling loop started by Start, it
+// doesn't block when the queues are empty: it returns false instead.
+//
+// RunCycle lets an offline simulation drive the scheduler, along with a fake
+// clock and client. It must not be called once the scheduler is started.
+func (s *Scheduler) RunCycle(ctx context.Context) bool {
+	headWorkloads := s.queues.TryHeads(ctx)
+	if len(headWorkloads) == 0 {
+		return false
+	}
+	var wg sync.WaitGroup
+	wrapper := s.admissionRoutineWrapper
+	s.admissionRoutineWrapper = routine.NewWrapper(func() { wg.Add(1) }, wg.Done)
+	defer func() { s.admissionRoutineWrapper = wrapper }()
+	s.scheduleCycle(ctx, headWorkloads)
+	wg.Wait()
+	return true
+}
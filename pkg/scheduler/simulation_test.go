@@ -0,0 +1,294 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestSimulateAdmission(t *testing.T) {
+	resourceFlavors := []*kueue.ResourceFlavor{
+		utiltesting.MakeResourceFlavor("on-demand").Obj(),
+		utiltesting.MakeResourceFlavor("spot").Obj(),
+	}
+	clusterQueues := []kueue.ClusterQueue{
+		*utiltesting.MakeClusterQueue("cq-a").
+			Cohort("cohort").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4").Obj(),
+				*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "4").Obj(),
+			).Obj(),
+		*utiltesting.MakeClusterQueue("cq-b").
+			Cohort("cohort").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4").Obj(),
+			).Obj(),
+		*utiltesting.MakeClusterQueue("cq-c").
+			Preemption(kueue.ClusterQueuePreemption{
+				WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+			}).
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4").Obj(),
+			).Obj(),
+	}
+	admitted := []kueue.Workload{
+		*utiltesting.MakeWorkload("busy", "default").
+			Request(corev1.ResourceCPU, "4").
+			ReserveQuota(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "on-demand", "4").Obj()).
+			Admitted(true).
+			Obj(),
+		*utiltesting.MakeWorkload("low", "default").
+			Priority(-1).
+			Request(corev1.ResourceCPU, "4").
+			ReserveQuota(utiltesting.MakeAdmission("cq-c").Assignment(corev1.ResourceCPU, "on-demand", "4").Obj()).
+			Admitted(true).
+			Obj(),
+	}
+
+	type simulatedPodSet struct {
+		Name    string
+		Flavors map[corev1.ResourceName]kueue.ResourceFlavorReference
+		Count   int32
+	}
+	type simulationOutcome struct {
+		Mode              flavorassigner.FlavorAssignmentMode
+		Borrowing         bool
+		PodSets           []simulatedPodSet
+		PreemptionTargets []string
+		Message           string
+	}
+
+	cases := map[string]struct {
+		workload     *kueue.Workload
+		clusterQueue string
+		want         *simulationOutcome
+		wantErr      error
+	}{
+		"fits in the remaining quota": {
+			workload:     utiltesting.MakeWorkload("new", "default").Request(corev1.ResourceCPU, "2").Obj(),
+			clusterQueue: "cq-b",
+			want: &simulationOutcome{
+				Mode: flavorassigner.Fit,
+				PodSets: []simulatedPodSet{{
+					Name:    kueue.DefaultPodSetName,
+					Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "on-demand"},
+					Count:   1,
+				}},
+			},
+		},
+		"fits by borrowing from the cohort": {
+			workload:     utiltesting.MakeWorkload("new", "default").Request(corev1.ResourceCPU, "2").Obj(),
+			clusterQueue: "cq-a",
+			want: &simulationOutcome{
+				Mode:      flavorassigner.Fit,
+				Borrowing: true,
+				PodSets: []simulatedPodSet{{
+					Name:    kueue.DefaultPodSetName,
+					Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "on-demand"},
+					Count:   1,
+				}},
+			},
+		},
+		"needs to preempt a lower priority workload": {
+			workload:     utiltesting.MakeWorkload("new", "default").Request(corev1.ResourceCPU, "2").Obj(),
+			clusterQueue: "cq-c",
+			want: &simulationOutcome{
+				Mode: flavorassigner.Preempt,
+				PodSets: []simulatedPodSet{{
+					Name:    kueue.DefaultPodSetName,
+					Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "on-demand"},
+					Count:   1,
+				}},
+				PreemptionTargets: []string{"default/low"},
+				Message:           "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor on-demand, 2 more needed",
+			},
+		},
+		"doesn't fit": {
+			workload:     utiltesting.MakeWorkload("new", "default").Request(corev1.ResourceCPU, "20").Obj(),
+			clusterQueue: "cq-c",
+			want: &simulationOutcome{
+				Mode: flavorassigner.NoFit,
+				PodSets: []simulatedPodSet{{
+					Name:  kueue.DefaultPodSetName,
+					Count: 1,
+				}},
+				Message: "couldn't assign flavors to pod set main: insufficient quota for cpu in flavor on-demand, request > maximum capacity (20 > 4)",
+			},
+		},
+		"namespace doesn't match": {
+			workload:     utiltesting.MakeWorkload("new", "other").Request(corev1.ResourceCPU, "1").Obj(),
+			clusterQueue: "cq-b",
+			want: &simulationOutcome{
+				Mode:    flavorassigner.NoFit,
+				Message: `Could not obtain workload namespace: namespaces "other" not found`,
+			},
+		},
+		"cluster queue not found": {
+			workload:     utiltesting.MakeWorkload("new", "default").Request(corev1.ResourceCPU, "1").Obj(),
+			clusterQueue: "cq-d",
+			wantErr:      cache.ErrCqNotFound,
+		},
+		"same name as an admitted workload": {
+			workload:     utiltesting.MakeWorkload("busy", "default").Request(corev1.ResourceCPU, "1").Obj(),
+			clusterQueue: "cq-b",
+			wantErr:      ErrWorkloadAlreadyAdmitted,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().
+				WithLists(&kueue.WorkloadList{Items: admitted}).
+				WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}).
+				Build()
+			recorder := record.NewBroadcaster().NewRecorder(runtime.NewScheme(), corev1.EventSource{Component: constants.AdmissionName})
+			cqCache := cache.New(cl)
+			qManager := queue.NewManager(cl, cqCache)
+			for i := range resourceFlavors {
+				cqCache.AddOrUpdateResourceFlavor(resourceFlavors[i])
+			}
+			for i := range clusterQueues {
+				if err := cqCache.AddClusterQueue(ctx, &clusterQueues[i]); err != nil {
+					t.Fatalf("Inserting clusterQueue %s in cache: %v", clusterQueues[i].Name, err)
+				}
+			}
+			scheduler := New(qManager, cqCache, cl, recorder)
+			scheduler.applyAdmission = func(context.Context, *kueue.Workload) error {
+				t.Error("Unexpected admission during simulation")
+				return nil
+			}
+			scheduler.preemptor.OverrideApply(func(context.Context, *kueue.Workload, string, string) error {
+				t.Error("Unexpected preemption during simulation")
+				return nil
+			})
+
+			result, err := scheduler.SimulateAdmission(ctx, tc.workload, tc.clusterQueue)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Unexpected error (want %v): %v", tc.wantErr, err)
+			}
+			var got *simulationOutcome
+			if result != nil {
+				got = &simulationOutcome{
+					Mode:      result.Mode(),
+					Borrowing: result.Assignment.Borrowing,
+					Message:   result.Message,
+				}
+				for _, psa := range result.Assignment.ToAPI() {
+					got.PodSets = append(got.PodSets, simulatedPodSet{
+						Name:    psa.Name,
+						Flavors: psa.Flavors,
+						Count:   *psa.Count,
+					})
+				}
+				for _, target := range result.PreemptionTargets {
+					got.PreemptionTargets = append(got.PreemptionTargets, workload.Key(target.WorkloadInfo.Obj))
+				}
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected simulation result (-want,+got):\n%s", diff)
+			}
+
+			// The simulation must not modify the cache.
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error while building snapshot: %v", err)
+			}
+			for _, cq := range snapshot.ClusterQueues {
+				if got := len(cq.Workloads); got > 1 {
+					t.Errorf("Unexpected number of workloads in %s after the simulation: %d", cq.Name, got)
+				}
+			}
+			if cqCache.IsAssumedOrAdmittedWorkload(*workload.NewInfo(tc.workload)) {
+				t.Error("The simulated workload was assumed in the cache")
+			}
+		})
+	}
+}
+
+func TestRunCycle(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cl := utiltesting.NewClientBuilder().
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}).
+		Build()
+	cqCache := cache.New(cl)
+	qManager := queue.NewManager(cl, cqCache)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+		Obj()
+	lq := utiltesting.MakeLocalQueue("lq", "default").ClusterQueue("cq").Obj()
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+	}
+	if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+	}
+	if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+		t.Fatalf("Inserting queue %s in manager: %v", lq.Name, err)
+	}
+
+	scheduler := New(qManager, cqCache, cl, &utiltesting.EventRecorder{})
+	var admitted []string
+	scheduler.applyAdmission = func(_ context.Context, w *kueue.Workload) error {
+		// The admissions are applied asynchronously; RunCycle waits for them.
+		time.Sleep(10 * time.Millisecond)
+		admitted = append(admitted, w.Name)
+		return nil
+	}
+
+	if scheduler.RunCycle(ctx) {
+		t.Fatal("RunCycle ran a cycle with empty queues")
+	}
+	for _, name := range []string{"first", "second"} {
+		if err := qManager.AddOrUpdateWorkload(utiltesting.MakeWorkload(name, "default").
+			Queue("lq").
+			Creation(time.Now()).
+			Request(corev1.ResourceCPU, "3").
+			Obj()); err != nil {
+			t.Fatalf("Inserting workload %s in manager: %v", name, err)
+		}
+	}
+	var gotCycles int
+	for scheduler.RunCycle(ctx) {
+		gotCycles++
+	}
+	// The second workload is evaluated in a second cycle, and doesn't fit.
+	if gotCycles != 2 {
+		t.Errorf("Unexpected number of cycles, want 2, got %d", gotCycles)
+	}
+	if diff := cmp.Diff([]string{"first"}, admitted); diff != "" {
+		t.Errorf("Unexpected admitted workloads (-want,+got):\n%s", diff)
+	}
+}
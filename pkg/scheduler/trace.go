@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/trace"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// cycleTrace collects the evaluations of the workloads of a sampled cycle,
+// which are reported concurrently when the Cohort trees are scheduled in
+// parallel.
+type cycleTrace struct {
+	sync.Mutex
+	cycle trace.Cycle
+}
+
+// startTrace starts collecting the evaluations of the workloads of the
+// cycle, if the trace is enabled and the cycle is sampled.
+func (s *Scheduler) startTrace(start time.Time) {
+	s.cycleTrace = nil
+	if s.traceRecorder != nil && s.traceRecorder.Sample() {
+		s.cycleTrace = &cycleTrace{cycle: trace.NewCycle(s.attemptCount, start)}
+	}
+}
+
+// finishTrace records the cycle, unless no workload was evaluated.
+func (s *Scheduler) finishTrace(ctx context.Context) {
+	t := s.cycleTrace
+	s.cycleTrace = nil
+	if t == nil || len(t.cycle.Workloads) == 0 {
+		return
+	}
+	t.cycle.Duration = metav1.Duration{Duration: s.clock.Since(t.cycle.StartTime.Time)}
+	if err := s.traceRecorder.Record(t.cycle); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to record the scheduling cycle")
+	}
+}
+
+// traceAdmissionAttempt adds the evaluation of the entry to the trace of the
+// cycle, if it's sampled.
+func (s *Scheduler) traceAdmissionAttempt(e *entry, phase trace.Phase) {
+	t := s.cycleTrace
+	if t == nil {
+		return
+	}
+	wl := trace.Workload{
+		Workload:      workload.Key(e.Obj),
+		ClusterQueue:  e.ClusterQueue,
+		Phase:         phase,
+		Decision:      traceDecision(e),
+		Message:       e.inadmissibleMsg,
+		RequeueReason: string(e.requeueReason),
+		Assignment:    traceAssignment(&e.assignment),
+	}
+	for _, target := range e.preemptionTargets {
+		wl.PreemptionTargets = append(wl.PreemptionTargets, trace.PreemptionTarget{
+			Workload:     workload.Key(target.WorkloadInfo.Obj),
+			ClusterQueue: target.WorkloadInfo.ClusterQueue,
+			Reason:       target.Reason,
+		})
+	}
+	t.Lock()
+	defer t.Unlock()
+	t.cycle.Workloads = append(t.cycle.Workloads, wl)
+}
+
+func traceDecision(e *entry) trace.Decision {
+	switch {
+	case e.status == assumed:
+		return trace.Admitted
+	case e.requeueReason == queue.RequeueReasonPendingPreemption:
+		return trace.Preempting
+	case e.status == skipped:
+		return trace.Skipped
+	default:
+		return trace.Inadmissible
+	}
+}
+
+func traceAssignment(a *flavorassigner.Assignment) *trace.Assignment {
+	if len(a.PodSets) == 0 {
+		return nil
+	}
+	ta := &trace.Assignment{
+		Mode:      a.RepresentativeMode().String(),
+		Borrowing: a.Borrowing,
+		PodSets:   make([]trace.PodSetAssignment, 0, len(a.PodSets)),
+	}
+	for _, ps := range a.PodSets {
+		tps := trace.PodSetAssignment{
+			Name:    ps.Name,
+			Count:   ps.Count,
+			Message: ps.Status.Message(),
+		}
+		if len(ps.Flavors) > 0 {
+			tps.Flavors = make(map[corev1.ResourceName]trace.FlavorAssignment, len(ps.Flavors))
+			for res, flv := range ps.Flavors {
+				tps.Flavors[res] = trace.FlavorAssignment{Flavor: string(flv.Name), Mode: flv.Mode.String()}
+			}
+		}
+		ta.PodSets = append(ta.PodSets, tps)
+	}
+	return ta
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRotatingFile(t *testing.T) {
+	cases := map[string]struct {
+		existing   string
+		maxBackups int
+		writes     []string
+		wantFiles  map[string]string
+	}{
+		"no rotation": {
+			writes:     []string{"aa\n", "bb\n"},
+			maxBackups: 2,
+			wantFiles: map[string]string{
+				"trace": "aa\nbb\n",
+			},
+		},
+		"appends to the existing file": {
+			existing:   "aa\n",
+			writes:     []string{"bb\n"},
+			maxBackups: 2,
+			wantFiles: map[string]string{
+				"trace": "aa\nbb\n",
+			},
+		},
+		"rotation": {
+			writes:     []string{"aa\n", "bb\n", "cc\n", "dd\n", "ee\n"},
+			maxBackups: 2,
+			wantFiles: map[string]string{
+				"trace":   "ee\n",
+				"trace.1": "cc\ndd\n",
+				"trace.2": "aa\nbb\n",
+			},
+		},
+		"oldest backups removed": {
+			writes:     []string{"aa\n", "bb\n", "cc\n", "dd\n", "ee\n", "ff\n", "gg\n"},
+			maxBackups: 2,
+			wantFiles: map[string]string{
+				"trace":   "gg\n",
+				"trace.1": "ee\nff\n",
+				"trace.2": "cc\ndd\n",
+			},
+		},
+		"no backups": {
+			writes: []string{"aa\n", "bb\n", "cc\n"},
+			wantFiles: map[string]string{
+				"trace": "cc\n",
+			},
+		},
+		"write larger than the maximum size": {
+			writes:     []string{"aa\n", "bbbbbbbb\n", "cc\n"},
+			maxBackups: 2,
+			wantFiles: map[string]string{
+				"trace":   "cc\n",
+				"trace.1": "bbbbbbbb\n",
+				"trace.2": "aa\n",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "trace")
+			if tc.existing != "" {
+				if err := os.WriteFile(path, []byte(tc.existing), 0o644); err != nil {
+					t.Fatalf("Unexpected error writing the existing file: %v", err)
+				}
+			}
+			f, err := openRotatingFile(path, 6, tc.maxBackups)
+			if err != nil {
+				t.Fatalf("Unexpected error opening the file: %v", err)
+			}
+			for _, w := range tc.writes {
+				if err := f.write([]byte(w)); err != nil {
+					t.Fatalf("Unexpected error writing %q: %v", w, err)
+				}
+			}
+			if err := f.close(); err != nil {
+				t.Fatalf("Unexpected error closing the file: %v", err)
+			}
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("Unexpected error listing the files: %v", err)
+			}
+			gotFiles := make(map[string]string, len(entries))
+			for _, e := range entries {
+				data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+				if err != nil {
+					t.Fatalf("Unexpected error reading %s: %v", e.Name(), err)
+				}
+				gotFiles[e.Name()] = string(data)
+			}
+			if diff := cmp.Diff(tc.wantFiles, gotFiles); diff != "" {
+				t.Errorf("Unexpected files (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package trace records the decisions taken by the scheduler in a sample of
+// the scheduling cycles, to troubleshoot the admission of the workloads.
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+)
+
+// HandlerPath is the path of the metrics server on which the recorded cycles
+// are served.
+const HandlerPath = "/debug/kueue/scheduling-trace"
+
+// Decision is the outcome of the evaluation of a workload in a cycle.
+type Decision string
+
+const (
+	// Admitted means that the workload reserved quota.
+	Admitted Decision = "Admitted"
+	// Preempting means that the workload waits for the preemption of the
+	// targets to complete.
+	Preempting Decision = "Preempting"
+	// Skipped means that the workload was nominated, but it was skipped in
+	// favor of another workload of the cycle.
+	Skipped Decision = "Skipped"
+	// Inadmissible means that the workload couldn't be admitted.
+	Inadmissible Decision = "Inadmissible"
+)
+
+// Phase is the step of the cycle in which the workload was evaluated.
+type Phase string
+
+const (
+	// PhaseHead is the evaluation of the heads of the ClusterQueues.
+	PhaseHead Phase = "Head"
+	// PhaseAdmitMore is the admission of the workloads behind the admitted
+	// heads, when more than one admission per cycle is allowed.
+	PhaseAdmitMore Phase = "AdmitMore"
+	// PhaseBackfill is the admission of the workloads which are expected to
+	// finish before a blocked head can be admitted.
+	PhaseBackfill Phase = "Backfill"
+)
+
+// Cycle is the record of a scheduling cycle.
+type Cycle struct {
+	// Attempt is the number of the cycle since the scheduler started.
+	Attempt   int64           `json:"attempt"`
+	StartTime metav1.Time     `json:"startTime"`
+	Duration  metav1.Duration `json:"duration"`
+	// Workloads are the workloads evaluated in the cycle, in the order in
+	// which they were decided.
+	Workloads []Workload `json:"workloads"`
+}
+
+// Workload is the record of the evaluation of a workload in a cycle.
+type Workload struct {
+	// Workload is the namespace/name of the workload.
+	Workload     string   `json:"workload"`
+	ClusterQueue string   `json:"clusterQueue"`
+	Phase        Phase    `json:"phase"`
+	Decision     Decision `json:"decision"`
+	// Message explains why the workload wasn't admitted.
+	Message string `json:"message,omitempty"`
+	// RequeueReason is the reason with which the workload was requeued.
+	RequeueReason     string             `json:"requeueReason,omitempty"`
+	Assignment        *Assignment        `json:"assignment,omitempty"`
+	PreemptionTargets []PreemptionTarget `json:"preemptionTargets,omitempty"`
+}
+
+// Assignment is the flavor assignment computed for a workload.
+type Assignment struct {
+	// Mode is the representative mode of the assignment: Fit, Preempt or
+	// NoFit.
+	Mode      string             `json:"mode"`
+	Borrowing bool               `json:"borrowing,omitempty"`
+	PodSets   []PodSetAssignment `json:"podSets,omitempty"`
+}
+
+// PodSetAssignment is the flavor assignment computed for a PodSet.
+type PodSetAssignment struct {
+	Name    string                                   `json:"name"`
+	Count   int32                                    `json:"count"`
+	Flavors map[corev1.ResourceName]FlavorAssignment `json:"flavors,omitempty"`
+	// Message explains why some of the resources don't fit.
+	Message string `json:"message,omitempty"`
+}
+
+// FlavorAssignment is the flavor assigned to a resource.
+type FlavorAssignment struct {
+	Flavor string `json:"flavor"`
+	Mode   string `json:"mode"`
+}
+
+// PreemptionTarget is a workload preempted to make room for another one.
+type PreemptionTarget struct {
+	// Workload is the namespace/name of the workload.
+	Workload     string `json:"workload"`
+	ClusterQueue string `json:"clusterQueue"`
+	Reason       string `json:"reason"`
+}
+
+// Recorder keeps the most recently recorded cycles in a ring buffer, and
+// optionally writes them to a file, as JSON lines.
+type Recorder struct {
+	samplingPercentage int64
+
+	mu sync.Mutex
+	// cycles is the number of cycles offered for sampling.
+	cycles int64
+	buffer []Cycle
+	// next is the position of the buffer in which the next cycle is stored.
+	next int
+	full bool
+	file *rotatingFile
+}
+
+// NewRecorder returns a Recorder configured by the defaulted configuration.
+func NewRecorder(cfg *config.SchedulingTrace) (*Recorder, error) {
+	r := &Recorder{
+		samplingPercentage: int64(ptr.Deref(cfg.SamplingPercentage, config.DefaultSchedulingTraceSamplingPercentage)),
+		buffer:             make([]Cycle, ptr.Deref(cfg.BufferSize, config.DefaultSchedulingTraceBufferSize)),
+	}
+	if f := cfg.File; f != nil {
+		maxSize := int64(ptr.Deref(f.MaxSizeMegabytes, config.DefaultSchedulingTraceFileMaxSizeMegabytes)) << 20
+		file, err := openRotatingFile(f.Path, maxSize, int(ptr.Deref(f.MaxBackups, config.DefaultSchedulingTraceFileMaxBackups)))
+		if err != nil {
+			return nil, fmt.Errorf("opening the scheduling trace file: %w", err)
+		}
+		r.file = file
+	}
+	return r, nil
+}
+
+// Sample returns true if the next cycle should be recorded. The sampled
+// cycles are evenly spread, so that samplingPercentage of any 100 consecutive
+// cycles are recorded.
+func (r *Recorder) Sample() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cycles++
+	return r.cycles*r.samplingPercentage/100 != (r.cycles-1)*r.samplingPercentage/100
+}
+
+// Record stores the cycle in the buffer, evicting the oldest cycle if it's
+// full, and writes it to the file, if configured.
+func (r *Recorder) Record(c Cycle) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.buffer[r.next] = c
+	r.next = (r.next + 1) % len(r.buffer)
+	r.full = r.full || r.next == 0
+	if r.file == nil {
+		return nil
+	}
+	line, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return r.file.write(append(line, '\n'))
+}
+
+// Cycles returns the buffered cycles, from the oldest to the most recent.
+func (r *Recorder) Cycles() []Cycle {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return slices.Clone(r.buffer[:r.next])
+	}
+	return append(slices.Clone(r.buffer[r.next:]), r.buffer[:r.next]...)
+}
+
+// Close closes the file, if configured. The cycles recorded afterwards are
+// only kept in the buffer.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.close()
+	r.file = nil
+	return err
+}
+
+// ServeHTTP writes the buffered cycles as JSON lines, from the oldest to the
+// most recent. The cycles can be filtered by the workload and clusterQueue
+// query parameters, in which case only the cycles which evaluated a matching
+// workload are written.
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wlFilter := req.URL.Query().Get("workload")
+	cqFilter := req.URL.Query().Get("clusterQueue")
+	w.Header().Set("Content-Type", "application/jsonl")
+	enc := json.NewEncoder(w)
+	for _, c := range r.Cycles() {
+		if !c.matches(wlFilter, cqFilter) {
+			continue
+		}
+		if err := enc.Encode(c); err != nil {
+			return
+		}
+	}
+}
+
+func (c *Cycle) matches(wl, cq string) bool {
+	if wl == "" && cq == "" {
+		return true
+	}
+	return slices.ContainsFunc(c.Workloads, func(w Workload) bool {
+		return (wl == "" || w.Workload == wl) && (cq == "" || w.ClusterQueue == cq)
+	})
+}
+
+// NewCycle returns the record of a cycle which started at the given time.
+func NewCycle(attempt int64, start time.Time) Cycle {
+	return Cycle{
+		Attempt:   attempt,
+		StartTime: metav1.NewTime(start),
+	}
+}
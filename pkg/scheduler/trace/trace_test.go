@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+)
+
+func TestSample(t *testing.T) {
+	cases := map[string]struct {
+		samplingPercentage int32
+		wantSampled        []int
+	}{
+		"all the cycles": {
+			samplingPercentage: 100,
+			wantSampled:        []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		"one cycle out of five": {
+			samplingPercentage: 20,
+			wantSampled:        []int{5, 10},
+		},
+		"one cycle out of four": {
+			samplingPercentage: 25,
+			wantSampled:        []int{4, 8},
+		},
+		"less than one cycle out of ten": {
+			samplingPercentage: 1,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, err := NewRecorder(&config.SchedulingTrace{SamplingPercentage: ptr.To(tc.samplingPercentage)})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var sampled []int
+			for cycle := 1; cycle <= 10; cycle++ {
+				if r.Sample() {
+					sampled = append(sampled, cycle)
+				}
+			}
+			if diff := cmp.Diff(tc.wantSampled, sampled); diff != "" {
+				t.Errorf("Unexpected sampled cycles (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecorderBuffer(t *testing.T) {
+	cases := map[string]struct {
+		recorded   int
+		wantCycles []int64
+	}{
+		"not full": {
+			recorded:   2,
+			wantCycles: []int64{1, 2},
+		},
+		"full": {
+			recorded:   3,
+			wantCycles: []int64{1, 2, 3},
+		},
+		"oldest cycles evicted": {
+			recorded:   5,
+			wantCycles: []int64{3, 4, 5},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "trace.jsonl")
+			r, err := NewRecorder(&config.SchedulingTrace{
+				BufferSize: ptr.To[int32](3),
+				File:       &config.SchedulingTraceFile{Path: path},
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var wantWritten []int64
+			for attempt := int64(1); attempt <= int64(tc.recorded); attempt++ {
+				if err := r.Record(NewCycle(attempt, time.Now())); err != nil {
+					t.Fatalf("Unexpected error recording a cycle: %v", err)
+				}
+				wantWritten = append(wantWritten, attempt)
+			}
+			if diff := cmp.Diff(tc.wantCycles, attempts(r.Cycles())); diff != "" {
+				t.Errorf("Unexpected buffered cycles (-want,+got):\n%s", diff)
+			}
+			if err := r.Close(); err != nil {
+				t.Fatalf("Unexpected error closing the recorder: %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Unexpected error reading the trace file: %v", err)
+			}
+			if diff := cmp.Diff(wantWritten, attempts(decodeCycles(t, data))); diff != "" {
+				t.Errorf("Unexpected written cycles (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	r, err := NewRecorder(&config.SchedulingTrace{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for attempt, wls := range [][]Workload{
+		{{Workload: "ns/a", ClusterQueue: "cq-a"}},
+		{{Workload: "ns/b", ClusterQueue: "cq-b"}},
+		{{Workload: "ns/a", ClusterQueue: "cq-a"}, {Workload: "ns/c", ClusterQueue: "cq-b"}},
+	} {
+		c := NewCycle(int64(attempt+1), time.Now())
+		c.Workloads = wls
+		if err := r.Record(c); err != nil {
+			t.Fatalf("Unexpected error recording a cycle: %v", err)
+		}
+	}
+	cases := map[string]struct {
+		query        string
+		wantAttempts []int64
+	}{
+		"all the cycles": {
+			wantAttempts: []int64{1, 2, 3},
+		},
+		"by workload": {
+			query:        "?workload=ns/a",
+			wantAttempts: []int64{1, 3},
+		},
+		"by ClusterQueue": {
+			query:        "?clusterQueue=cq-b",
+			wantAttempts: []int64{2, 3},
+		},
+		"by workload and ClusterQueue": {
+			query: "?workload=ns/a&clusterQueue=cq-b",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, HandlerPath+tc.query, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Unexpected status code %d", rec.Code)
+			}
+			if diff := cmp.Diff(tc.wantAttempts, attempts(decodeCycles(t, rec.Body.Bytes()))); diff != "" {
+				t.Errorf("Unexpected served cycles (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func attempts(cycles []Cycle) []int64 {
+	var result []int64
+	for _, c := range cycles {
+		result = append(result, c.Attempt)
+	}
+	return result
+}
+
+func decodeCycles(t *testing.T, data []byte) []Cycle {
+	t.Helper()
+	var cycles []Cycle
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var c Cycle
+		if err := json.Unmarshal(scanner.Bytes(), &c); err != nil {
+			t.Fatalf("Unexpected error decoding %q: %v", scanner.Text(), err)
+		}
+		cycles = append(cycles, c)
+	}
+	return cycles
+}
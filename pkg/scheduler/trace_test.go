@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler/trace"
+	"sigs.k8s.io/kueue/pkg/util/routine"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestScheduleTrace(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	fakeClock := testingclock.NewFakeClock(now)
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("fit").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("preempt").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+			Preemption(kueue.ClusterQueuePreemption{WithinClusterQueue: kueue.PreemptionPolicyLowerPriority}).
+			Obj(),
+		utiltesting.MakeClusterQueue("nofit").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj()).
+			Obj(),
+	}
+	admitted := utiltesting.MakeWorkload("low", "ns").
+		Request(corev1.ResourceCPU, "2").
+		ReserveQuota(utiltesting.MakeAdmission("preempt").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+		Obj()
+	pending := []*kueue.Workload{
+		utiltesting.MakeWorkload("fits", "ns").Queue("fit").Request(corev1.ResourceCPU, "1").Obj(),
+		utiltesting.MakeWorkload("high", "ns").Queue("preempt").Priority(100).Request(corev1.ResourceCPU, "2").Obj(),
+		utiltesting.MakeWorkload("large", "ns").Queue("nofit").Request(corev1.ResourceCPU, "10").Obj(),
+	}
+
+	cases := map[string]struct {
+		samplingPercentage int32
+		wantCycles         []trace.Cycle
+	}{
+		"cycle sampled": {
+			samplingPercentage: 100,
+			wantCycles: []trace.Cycle{{
+				Attempt:   1,
+				StartTime: metav1.NewTime(now),
+				Workloads: []trace.Workload{
+					{
+						Workload:     "ns/fits",
+						ClusterQueue: "fit",
+						Phase:        trace.PhaseHead,
+						Decision:     trace.Admitted,
+						Assignment: &trace.Assignment{
+							Mode: "Fit",
+							PodSets: []trace.PodSetAssignment{{
+								Name:    kueue.DefaultPodSetName,
+								Count:   1,
+								Flavors: map[corev1.ResourceName]trace.FlavorAssignment{corev1.ResourceCPU: {Flavor: "default", Mode: "Fit"}},
+							}},
+						},
+					},
+					{
+						Workload:      "ns/high",
+						ClusterQueue:  "preempt",
+						Phase:         trace.PhaseHead,
+						Decision:      trace.Preempting,
+						RequeueReason: string(queue.RequeueReasonPendingPreemption),
+						Assignment: &trace.Assignment{
+							Mode: "Preempt",
+							PodSets: []trace.PodSetAssignment{{
+								Name:    kueue.DefaultPodSetName,
+								Count:   1,
+								Flavors: map[corev1.ResourceName]trace.FlavorAssignment{corev1.ResourceCPU: {Flavor: "default", Mode: "Preempt"}},
+							}},
+						},
+						PreemptionTargets: []trace.PreemptionTarget{{
+							Workload:     "ns/low",
+							ClusterQueue: "preempt",
+							Reason:       kueue.InClusterQueueReason,
+						}},
+					},
+					{
+						Workload:     "ns/large",
+						ClusterQueue: "nofit",
+						Phase:        trace.PhaseHead,
+						Decision:     trace.Inadmissible,
+						Assignment: &trace.Assignment{
+							Mode: "NoFit",
+							PodSets: []trace.PodSetAssignment{{
+								Name:  kueue.DefaultPodSetName,
+								Count: 1,
+							}},
+						},
+					},
+				},
+			}},
+		},
+		"cycle not sampled": {
+			samplingPercentage: 50,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().
+				WithObjects(admitted).
+				WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}).
+				Build()
+			cqCache := cache.New(cl, cache.WithClock(t, fakeClock))
+			qManager := queue.NewManager(cl, cqCache)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			for _, cq := range clusterQueues {
+				lq := utiltesting.MakeLocalQueue(cq.Name, "ns").ClusterQueue(cq.Name).Obj()
+				if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+				}
+				if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+				}
+				if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+					t.Fatalf("Inserting queue %s in manager: %v", lq.Name, err)
+				}
+			}
+			cqCache.AddOrUpdateWorkload(admitted)
+			for _, wl := range pending {
+				if err := qManager.AddOrUpdateWorkload(wl); err != nil {
+					t.Fatalf("Inserting workload %s in manager: %v", wl.Name, err)
+				}
+			}
+
+			recorder, err := trace.NewRecorder(&config.SchedulingTrace{SamplingPercentage: ptr.To(tc.samplingPercentage)})
+			if err != nil {
+				t.Fatalf("Creating the trace recorder: %v", err)
+			}
+			scheduler := New(qManager, cqCache, cl, &utiltesting.EventRecorder{}, WithClock(t, fakeClock), WithTraceRecorder(recorder))
+			scheduler.applyAdmission = func(context.Context, *kueue.Workload) error { return nil }
+			wg := sync.WaitGroup{}
+			scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
+				func() { wg.Add(1) },
+				func() { wg.Done() },
+			))
+			scheduler.preemptor.OverrideApply(func(context.Context, *kueue.Workload, string, string) error { return nil })
+
+			ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
+			go qManager.CleanUpOnContext(ctx)
+			defer cancel()
+
+			scheduler.schedule(ctx)
+			wg.Wait()
+
+			opts := []cmp.Option{
+				cmpopts.EquateEmpty(),
+				cmpopts.SortSlices(func(a, b trace.Workload) bool { return a.Workload < b.Workload }),
+				cmpopts.IgnoreFields(trace.Workload{}, "Message"),
+				cmpopts.IgnoreFields(trace.PodSetAssignment{}, "Message"),
+			}
+			if diff := cmp.Diff(tc.wantCycles, recorder.Cycles(), opts...); diff != "" {
+				t.Errorf("Unexpected recorded cycles (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]struct {
+		spec    string
+		wantErr bool
+	}{
+		"every minute":          {spec: "* * * * *"},
+		"business hours":        {spec: "0 9 * * 1-5"},
+		"lists and steps":       {spec: "0,30 */2 1-15/3 1,6 0,7"},
+		"too few fields":        {spec: "0 9 * *", wantErr: true},
+		"value out of range":    {spec: "60 * * * *", wantErr: true},
+		"inverted range":        {spec: "0 18-9 * * *", wantErr: true},
+		"invalid step":          {spec: "*/0 * * * *", wantErr: true},
+		"not a number":          {spec: "0 nine * * *", wantErr: true},
+		"day of month is zero":  {spec: "0 0 0 * *", wantErr: true},
+		"day of week too large": {spec: "0 0 * * 8", wantErr: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(tc.spec)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Unexpected error: %v, want error=%t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	// 2024-11-15 is a Friday.
+	base := time.Date(2024, time.November, 15, 10, 30, 0, 0, time.UTC)
+	cases := map[string]struct {
+		spec     string
+		from     time.Time
+		want     time.Time
+		wantNone bool
+	}{
+		"next minute": {
+			spec: "* * * * *",
+			from: base,
+			want: base.Add(time.Minute),
+		},
+		"later today": {
+			spec: "0 18 * * *",
+			from: base,
+			want: time.Date(2024, time.November, 15, 18, 0, 0, 0, time.UTC),
+		},
+		"next business day": {
+			spec: "0 9 * * 1-5",
+			from: base,
+			want: time.Date(2024, time.November, 18, 9, 0, 0, 0, time.UTC),
+		},
+		"sunday as 7": {
+			spec: "0 0 * * 7",
+			from: base,
+			want: time.Date(2024, time.November, 17, 0, 0, 0, 0, time.UTC),
+		},
+		"day of month or day of week": {
+			spec: "0 0 1 * 6",
+			from: base,
+			want: time.Date(2024, time.November, 16, 0, 0, 0, 0, time.UTC),
+		},
+		"next month": {
+			spec: "0 0 1 * *",
+			from: base,
+			want: time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"never": {
+			spec:     "0 0 30 2 *",
+			from:     base,
+			wantNone: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := Parse(tc.spec)
+			if err != nil {
+				t.Fatalf("Parsing: %v", err)
+			}
+			got, found := s.Next(tc.from)
+			if found == tc.wantNone {
+				t.Fatalf("Unexpected found=%t", found)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("Unexpected next activation %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLastActivation(t *testing.T) {
+	s, err := Parse("0 9 * * 1-5")
+	if err != nil {
+		t.Fatalf("Parsing: %v", err)
+	}
+	cases := map[string]struct {
+		at        time.Time
+		wantStart time.Time
+		wantFound bool
+	}{
+		"within the window": {
+			at:        time.Date(2024, time.November, 15, 12, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2024, time.November, 15, 9, 0, 0, 0, time.UTC),
+			wantFound: true,
+		},
+		"at the start of the window": {
+			at:        time.Date(2024, time.November, 15, 9, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2024, time.November, 15, 9, 0, 0, 0, time.UTC),
+			wantFound: true,
+		},
+		"at the end of the window": {
+			at: time.Date(2024, time.November, 15, 17, 0, 0, 0, time.UTC),
+		},
+		"during the weekend": {
+			at: time.Date(2024, time.November, 16, 12, 0, 0, 0, time.UTC),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, found := s.LastActivation(tc.at, 8*time.Hour)
+			if found != tc.wantFound {
+				t.Fatalf("Unexpected found=%t, want %t", found, tc.wantFound)
+			}
+			if !got.Equal(tc.wantStart) {
+				t.Errorf("Unexpected start %v, want %v", got, tc.wantStart)
+			}
+		})
+	}
+}
@@ -171,6 +171,12 @@ func (h *Heap[T]) List() []*T {
 	return list
 }
 
+// Reorder restores the heap ordering after a change in the less function
+// outcome for the existing items.
+func (h *Heap[T]) Reorder() {
+	heap.Init(&h.data)
+}
+
 // New returns a Heap which can be used to queue up items to process.
 func New[T any](keyFn keyFunc[T], lessFn lessFunc[T]) *Heap[T] {
 	return &Heap[T]{
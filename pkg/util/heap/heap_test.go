@@ -214,6 +214,28 @@ func TestHeap_Update(t *testing.T) {
 	}
 }
 
+func TestHeap_Reorder(t *testing.T) {
+	h := New(testHeapObjectKeyFunc, compareInts)
+	foo := mkHeapObj("foo", 10)
+	bar := mkHeapObj("bar", 1)
+	h.PushOrUpdate(foo)
+	h.PushOrUpdate(bar)
+	h.PushOrUpdate(mkHeapObj("baz", 11))
+
+	// Change the outcome of the comparison without notifying the heap.
+	foo.val = 0
+	bar.val = 20
+	h.Reorder()
+	if h.data.keys[0] != "foo" || h.data.items["foo"].index != 0 {
+		t.Fatalf("expected foo to be at the head")
+	}
+	for _, want := range []string{"foo", "baz", "bar"} {
+		if got := h.Pop(); got.name != want {
+			t.Fatalf("expected %s, got %s", want, got.name)
+		}
+	}
+}
+
 // TestHeap_GetByKey tests Heap.GetByKey and is very similar to TestHeap_Get.
 func TestHeap_GetByKey(t *testing.T) {
 	h := New(testHeapObjectKeyFunc, compareInts)
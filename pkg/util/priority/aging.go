@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priority
+
+import (
+	"math"
+	"time"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+)
+
+// Effective returns the priority used to order the workload and to decide
+// whether it can be preempted: the effective priority from the status, when
+// the priority aging is enabled, or the priority of the workload otherwise.
+func Effective(w *kueue.Workload) int32 {
+	if features.Enabled(features.PriorityAging) && w.Status.EffectivePriority != nil {
+		return *w.Status.EffectivePriority
+	}
+	return Priority(w)
+}
+
+// Aged returns the priority p raised by the aging policy after waiting for
+// the given time, and the additional waiting time after which it's raised
+// again. The returned duration is zero when the increase reached its cap.
+func Aged(p int32, aging *kueue.PriorityAging, waited time.Duration) (int32, time.Duration) {
+	interval := aging.Interval.Duration
+	if interval <= 0 || aging.Increment <= 0 || aging.MaxIncrease <= 0 {
+		return p, 0
+	}
+	waited = max(waited, 0)
+	var increase int64
+	var next time.Duration
+	switch aging.Policy {
+	case kueue.PriorityAgingLinear:
+		increase = int64(math.Min(float64(waited)/float64(interval)*float64(aging.Increment), float64(aging.MaxIncrease)))
+		next = time.Duration(math.Ceil(float64(increase+1)*float64(interval)/float64(aging.Increment))) - waited
+	default:
+		steps := int64(waited / interval)
+		increase = min(steps*int64(aging.Increment), int64(aging.MaxIncrease))
+		next = time.Duration(steps+1)*interval - waited
+	}
+	if increase >= int64(aging.MaxIncrease) {
+		next = 0
+	}
+	return int32(min(int64(p)+increase, math.MaxInt32)), next
+}
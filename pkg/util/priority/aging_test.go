@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priority
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestAged(t *testing.T) {
+	step := &kueue.PriorityAging{
+		Policy:      kueue.PriorityAgingStep,
+		Interval:    metav1.Duration{Duration: 10 * time.Minute},
+		Increment:   5,
+		MaxIncrease: 12,
+	}
+	linear := &kueue.PriorityAging{
+		Policy:      kueue.PriorityAgingLinear,
+		Interval:    metav1.Duration{Duration: 10 * time.Minute},
+		Increment:   5,
+		MaxIncrease: 12,
+	}
+	cases := map[string]struct {
+		priority  int32
+		aging     *kueue.PriorityAging
+		waited    time.Duration
+		want      int32
+		wantAfter time.Duration
+	}{
+		"step, just queued": {
+			priority:  100,
+			aging:     step,
+			want:      100,
+			wantAfter: 10 * time.Minute,
+		},
+		"step, within the first interval": {
+			priority:  100,
+			aging:     step,
+			waited:    9 * time.Minute,
+			want:      100,
+			wantAfter: time.Minute,
+		},
+		"step, after two intervals": {
+			priority:  100,
+			aging:     step,
+			waited:    25 * time.Minute,
+			want:      110,
+			wantAfter: 5 * time.Minute,
+		},
+		"step, capped": {
+			priority: 100,
+			aging:    step,
+			waited:   time.Hour,
+			want:     112,
+		},
+		"linear, within the first interval": {
+			priority:  100,
+			aging:     linear,
+			waited:    5 * time.Minute,
+			want:      102,
+			wantAfter: time.Minute,
+		},
+		"linear, capped": {
+			priority: 100,
+			aging:    linear,
+			waited:   25 * time.Minute,
+			want:     112,
+		},
+		"negative waiting time": {
+			priority:  100,
+			aging:     step,
+			waited:    -time.Minute,
+			want:      100,
+			wantAfter: 10 * time.Minute,
+		},
+		"saturated priority": {
+			priority: math.MaxInt32 - 1,
+			aging:    step,
+			waited:   time.Hour,
+			want:     math.MaxInt32,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotAfter := Aged(tc.priority, tc.aging, tc.waited)
+			if got != tc.want {
+				t.Errorf("Unexpected priority, want=%d, got=%d", tc.want, got)
+			}
+			if gotAfter != tc.wantAfter {
+				t.Errorf("Unexpected time to the next increase, want=%v, got=%v", tc.wantAfter, gotAfter)
+			}
+		})
+	}
+}
+
+func TestEffective(t *testing.T) {
+	wl := utiltesting.MakeWorkload("name", "ns").Priority(100).Obj()
+	wl.Status.EffectivePriority = ptr.To[int32](110)
+	cases := map[string]struct {
+		enableAging bool
+		want        int32
+	}{
+		"aging disabled": {
+			want: 100,
+		},
+		"aging enabled": {
+			enableAging: true,
+			want:        110,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PriorityAging, tc.enableAging)
+			if got := Effective(wl); got != tc.want {
+				t.Errorf("Unexpected effective priority, want=%d, got=%d", tc.want, got)
+			}
+		})
+	}
+}
@@ -227,6 +227,12 @@ func (w *WorkloadWrapper) Priority(priority int32) *WorkloadWrapper {
 	return w
 }
 
+// EffectivePriority sets the effective priority in the status.
+func (w *WorkloadWrapper) EffectivePriority(priority int32) *WorkloadWrapper {
+	w.Status.EffectivePriority = &priority
+	return w
+}
+
 func (w *WorkloadWrapper) PriorityClassSource(source string) *WorkloadWrapper {
 	w.Spec.PriorityClassSource = source
 	return w
@@ -359,6 +365,16 @@ func (w *WorkloadWrapper) MaximumExecutionTimeSeconds(v int32) *WorkloadWrapper
 	return w
 }
 
+func (w *WorkloadWrapper) CompletionDeadline(t time.Time) *WorkloadWrapper {
+	w.Spec.CompletionDeadline = &metav1.Time{Time: t}
+	return w
+}
+
+func (w *WorkloadWrapper) Dependencies(deps ...kueue.WorkloadDependency) *WorkloadWrapper {
+	w.Spec.Dependencies = deps
+	return w
+}
+
 func (w *WorkloadWrapper) PastAdmittedTime(v int32) *WorkloadWrapper {
 	w.Status.AccumulatedPastExexcutionTimeSeconds = &v
 	return w
@@ -563,6 +579,11 @@ func (w *AdmissionWrapper) TopologyAssignment(ts *kueue.TopologyAssignment) *Adm
 	return w
 }
 
+func (w *AdmissionWrapper) DelayedTopologyRequest(state kueue.DelayedTopologyRequestState) *AdmissionWrapper {
+	w.PodSetAssignments[0].DelayedTopologyRequest = ptr.To(state)
+	return w
+}
+
 func (w *AdmissionWrapper) PodSets(podSets ...kueue.PodSetAssignment) *AdmissionWrapper {
 	w.PodSetAssignments = podSets
 	return w
@@ -607,6 +628,15 @@ func (q *LocalQueueWrapper) ClusterQueue(c string) *LocalQueueWrapper {
 	return q
 }
 
+// FairWeight sets the fair sharing weight of the LocalQueue.
+func (q *LocalQueueWrapper) FairWeight(w resource.Quantity) *LocalQueueWrapper {
+	if q.Spec.FairSharing == nil {
+		q.Spec.FairSharing = &kueue.FairSharing{}
+	}
+	q.Spec.FairSharing.Weight = ptr.To(w)
+	return q
+}
+
 // StopPolicy sets the stop policy.
 func (q *LocalQueueWrapper) StopPolicy(p kueue.StopPolicy) *LocalQueueWrapper {
 	q.Spec.StopPolicy = &p
@@ -664,12 +694,124 @@ func (c *CohortWrapper) Parent(parentName string) *CohortWrapper {
 	return c
 }
 
+// FairWeight sets the fair sharing weight of the Cohort.
+func (c *CohortWrapper) FairWeight(w resource.Quantity) *CohortWrapper {
+	if c.Spec.FairSharing == nil {
+		c.Spec.FairSharing = &kueue.FairSharing{}
+	}
+	c.Spec.FairSharing.Weight = ptr.To(w)
+	return c
+}
+
 // ResourceGroup adds a ResourceGroup with flavors.
 func (c *CohortWrapper) ResourceGroup(flavors ...kueue.FlavorQuotas) *CohortWrapper {
 	c.Spec.ResourceGroups = append(c.Spec.ResourceGroups, ResourceGroup(flavors...))
 	return c
 }
 
+// ReservationWrapper wraps a Reservation.
+type ReservationWrapper struct{ kueuealpha.Reservation }
+
+// MakeReservation creates a wrapper for a Reservation of the quota of
+// the ClusterQueue, for the window [start, end).
+func MakeReservation(name, clusterQueue string, start, end time.Time) *ReservationWrapper {
+	return &ReservationWrapper{kueuealpha.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kueuealpha.ReservationSpec{
+			ClusterQueue: kueue.ClusterQueueReference(clusterQueue),
+			StartTime:    metav1.NewTime(start),
+			EndTime:      metav1.NewTime(end),
+		},
+	}}
+}
+
+// Obj returns the inner Reservation.
+func (r *ReservationWrapper) Obj() *kueuealpha.Reservation {
+	return &r.Reservation
+}
+
+// Cohort reserves the quota of the Cohort instead of a ClusterQueue.
+func (r *ReservationWrapper) Cohort(cohort string) *ReservationWrapper {
+	r.Spec.ClusterQueue = ""
+	r.Spec.Cohort = cohort
+	return r
+}
+
+// Flavor adds the quantity of the resource reserved in the flavor.
+func (r *ReservationWrapper) Flavor(flavor string, name corev1.ResourceName, quantity string) *ReservationWrapper {
+	for i := range r.Spec.Flavors {
+		if r.Spec.Flavors[i].Name == kueue.ResourceFlavorReference(flavor) {
+			r.Spec.Flavors[i].Resources[name] = resource.MustParse(quantity)
+			return r
+		}
+	}
+	r.Spec.Flavors = append(r.Spec.Flavors, kueuealpha.ReservedFlavor{
+		Name:      kueue.ResourceFlavorReference(flavor),
+		Resources: corev1.ResourceList{name: resource.MustParse(quantity)},
+	})
+	return r
+}
+
+// LeadTime sets the duration before the start of the window from which
+// the quota is held.
+func (r *ReservationWrapper) LeadTime(d time.Duration) *ReservationWrapper {
+	r.Spec.LeadTime = &metav1.Duration{Duration: d}
+	return r
+}
+
+// QuotaScheduleWrapper wraps a QuotaSchedule.
+type QuotaScheduleWrapper struct{ kueuealpha.QuotaSchedule }
+
+// MakeQuotaSchedule creates a wrapper for a QuotaSchedule of the ClusterQueue.
+func MakeQuotaSchedule(name, clusterQueue string) *QuotaScheduleWrapper {
+	return &QuotaScheduleWrapper{kueuealpha.QuotaSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kueuealpha.QuotaScheduleSpec{
+			ClusterQueue:  kueue.ClusterQueueReference(clusterQueue),
+			ReclaimPolicy: kueuealpha.QuotaReclaimNever,
+		},
+	}}
+}
+
+// Obj returns the inner QuotaSchedule.
+func (q *QuotaScheduleWrapper) Obj() *kueuealpha.QuotaSchedule {
+	return &q.QuotaSchedule
+}
+
+// TimeZone sets the time zone of the schedules.
+func (q *QuotaScheduleWrapper) TimeZone(tz string) *QuotaScheduleWrapper {
+	q.Spec.TimeZone = &tz
+	return q
+}
+
+// ReclaimPolicy sets the reclaim policy.
+func (q *QuotaScheduleWrapper) ReclaimPolicy(p kueuealpha.QuotaReclaimPolicy) *QuotaScheduleWrapper {
+	q.Spec.ReclaimPolicy = p
+	return q
+}
+
+// Window adds a window starting at the schedule, with the nominal quota of
+// the resource in the flavor.
+func (q *QuotaScheduleWrapper) Window(name, schedule string, d time.Duration, flavor string, res corev1.ResourceName, nominal string) *QuotaScheduleWrapper {
+	q.Spec.Windows = append(q.Spec.Windows, kueuealpha.QuotaWindow{
+		Name:     name,
+		Schedule: schedule,
+		Duration: metav1.Duration{Duration: d},
+		Flavors: []kueuealpha.ScheduledFlavorQuotas{{
+			Name: kueue.ResourceFlavorReference(flavor),
+			Resources: []kueuealpha.ScheduledResourceQuota{{
+				Name:         res,
+				NominalQuota: ptr.To(resource.MustParse(nominal)),
+			}},
+		}},
+	})
+	return q
+}
+
 // ClusterQueueWrapper wraps a ClusterQueue.
 type ClusterQueueWrapper struct{ kueue.ClusterQueue }
 
@@ -799,6 +941,42 @@ func (c *ClusterQueueWrapper) FairWeight(w resource.Quantity) *ClusterQueueWrapp
 	return c
 }
 
+// Backfill enables backfilling the ClusterQueue with the given maximum number of candidates.
+func (c *ClusterQueueWrapper) Backfill(maxCandidates int32) *ClusterQueueWrapper {
+	c.Spec.Backfill = &kueue.Backfill{MaxCandidates: &maxCandidates}
+	return c
+}
+
+// OrderingExpression sets the CEL expression ordering the pending workloads.
+func (c *ClusterQueueWrapper) OrderingExpression(expression string) *ClusterQueueWrapper {
+	c.Spec.OrderingExpression = &expression
+	return c
+}
+
+// FlavorSelectionPolicy sets the policy selecting the flavors of the resource
+// groups.
+func (c *ClusterQueueWrapper) FlavorSelectionPolicy(p kueue.FlavorSelectionPolicy) *ClusterQueueWrapper {
+	c.Spec.FlavorSelectionPolicy = &p
+	return c
+}
+
+// PriorityAging sets the priority aging policy of the ClusterQueue.
+func (c *ClusterQueueWrapper) PriorityAging(policy kueue.PriorityAgingPolicy, interval time.Duration, increment, maxIncrease int32) *ClusterQueueWrapper {
+	c.Spec.PriorityAging = &kueue.PriorityAging{
+		Policy:      policy,
+		Interval:    metav1.Duration{Duration: interval},
+		Increment:   increment,
+		MaxIncrease: maxIncrease,
+	}
+	return c
+}
+
+// LocalQueueFairSharing sets the fair sharing between the LocalQueues of the ClusterQueue.
+func (c *ClusterQueueWrapper) LocalQueueFairSharing(mode kueue.LocalQueueUsageMode) *ClusterQueueWrapper {
+	c.Spec.LocalQueueFairSharing = &kueue.LocalQueueFairSharing{UsageMode: mode}
+	return c
+}
+
 // Condition sets a condition on the ClusterQueue.
 func (c *ClusterQueueWrapper) Condition(conditionType string, status metav1.ConditionStatus, reason, message string) *ClusterQueueWrapper {
 	apimeta.SetStatusCondition(&c.Status.Conditions, metav1.Condition{
@@ -834,6 +1012,19 @@ func (c *ClusterQueueWrapper) AdmittedWorkloads(n int32) *ClusterQueueWrapper {
 	return c
 }
 
+// ConsumedResources sets the historical resource consumption in the
+// admission fair sharing status.
+func (c *ClusterQueueWrapper) ConsumedResources(consumed corev1.ResourceList, lastUpdate time.Time) *ClusterQueueWrapper {
+	if c.Status.FairSharing == nil {
+		c.Status.FairSharing = &kueue.FairSharingStatus{}
+	}
+	c.Status.FairSharing.AdmissionFairSharingStatus = &kueue.AdmissionFairSharingStatus{
+		ConsumedResources: consumed,
+		LastUpdate:        metav1.NewTime(lastUpdate),
+	}
+	return c
+}
+
 // FlavorQuotasWrapper wraps a FlavorQuotas object.
 type FlavorQuotasWrapper struct{ kueue.FlavorQuotas }
 
@@ -922,6 +1113,12 @@ func (rf *ResourceFlavorWrapper) Obj() *kueue.ResourceFlavor {
 	return &rf.ResourceFlavor
 }
 
+// Cost sets the cost of the ResourceFlavor.
+func (rf *ResourceFlavorWrapper) Cost(c string) *ResourceFlavorWrapper {
+	rf.Spec.Cost = ptr.To(resource.MustParse(c))
+	return rf
+}
+
 // TopologyName sets the topology name
 func (rf *ResourceFlavorWrapper) TopologyName(name string) *ResourceFlavorWrapper {
 	rf.ResourceFlavor.Spec.TopologyName = ptr.To(kueue.TopologyReference(name))
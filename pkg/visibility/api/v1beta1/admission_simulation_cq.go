@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+)
+
+// AdmissionSimulator evaluates the admission of a workload without
+// modifying the state of the cluster.
+type AdmissionSimulator interface {
+	SimulateAdmission(ctx context.Context, wl *kueue.Workload, cqName string) (*scheduler.SimulationResult, error)
+}
+
+type admissionSimulationInCqREST struct {
+	simulator AdmissionSimulator
+	log       logr.Logger
+}
+
+var _ rest.Storage = &admissionSimulationInCqREST{}
+var _ rest.NamedCreater = &admissionSimulationInCqREST{}
+var _ rest.Scoper = &admissionSimulationInCqREST{}
+
+func NewAdmissionSimulationInCqREST(simulator AdmissionSimulator) *admissionSimulationInCqREST {
+	return &admissionSimulationInCqREST{
+		simulator: simulator,
+		log:       ctrl.Log.WithName("admission-simulation-in-cq"),
+	}
+}
+
+// New implements rest.Storage interface
+func (m *admissionSimulationInCqREST) New() runtime.Object {
+	return &visibility.AdmissionSimulation{}
+}
+
+// Destroy implements rest.Storage interface
+func (m *admissionSimulationInCqREST) Destroy() {}
+
+// Create implements rest.NamedCreater interface
+// It evaluates the admission of the workload in the spec by the ClusterQueue
+// and returns the result in the status.
+func (m *admissionSimulationInCqREST) Create(ctx context.Context, name string, obj runtime.Object, _ rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	simulation, ok := obj.(*visibility.AdmissionSimulation)
+	if !ok {
+		return nil, fmt.Errorf("invalid object: %#v", obj)
+	}
+	if len(simulation.Spec.Workload.Raw) == 0 {
+		return nil, apierrors.NewBadRequest("spec.workload is required")
+	}
+	wl := &kueue.Workload{}
+	if err := json.Unmarshal(simulation.Spec.Workload.Raw, wl); err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("decoding spec.workload: %v", err))
+	}
+	if wl.Namespace == "" {
+		return nil, apierrors.NewBadRequest("spec.workload.metadata.namespace is required")
+	}
+
+	result, err := m.simulator.SimulateAdmission(ctrl.LoggerInto(ctx, m.log), wl, name)
+	if errors.Is(err, cache.ErrCqNotFound) {
+		return nil, apierrors.NewNotFound(visibility.Resource("clusterqueue"), name)
+	}
+	if errors.Is(err, scheduler.ErrWorkloadAlreadyAdmitted) {
+		return nil, apierrors.NewConflict(kueue.Resource("workloads"), wl.Name, err)
+	}
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	out := simulation.DeepCopy()
+	out.Status = newAdmissionSimulationStatus(result)
+	return out, nil
+}
+
+// NamespaceScoped implements rest.Scoper interface
+func (m *admissionSimulationInCqREST) NamespaceScoped() bool {
+	return false
+}
+
+func newAdmissionSimulationStatus(result *scheduler.SimulationResult) visibility.AdmissionSimulationStatus {
+	status := visibility.AdmissionSimulationStatus{
+		Message: result.Message,
+	}
+	switch result.Mode() {
+	case flavorassigner.Fit:
+		status.Mode = visibility.AdmissionSimulationFit
+	case flavorassigner.Preempt:
+		status.Mode = visibility.AdmissionSimulationPreempt
+	default:
+		status.Mode = visibility.AdmissionSimulationNoFit
+		return status
+	}
+	status.Borrowing = result.Assignment.Borrows()
+	for _, psa := range result.Assignment.PodSets {
+		flavors := make(map[string]string, len(psa.Flavors))
+		for res, fa := range psa.Flavors {
+			flavors[string(res)] = string(fa.Name)
+		}
+		status.PodSetAssignments = append(status.PodSetAssignments, visibility.PodSetAssignmentSimulation{
+			Name:    psa.Name,
+			Flavors: flavors,
+			Count:   psa.Count,
+		})
+	}
+	for _, target := range result.PreemptionTargets {
+		wl := target.WorkloadInfo.Obj
+		var priority int32
+		if wl.Spec.Priority != nil {
+			priority = *wl.Spec.Priority
+		}
+		status.PreemptionTargets = append(status.PreemptionTargets, visibility.PreemptionTarget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      wl.Name,
+				Namespace: wl.Namespace,
+				UID:       wl.UID,
+			},
+			ClusterQueueName: target.WorkloadInfo.ClusterQueue,
+			Priority:         priority,
+			Reason:           target.Reason,
+		})
+	}
+	return status
+}
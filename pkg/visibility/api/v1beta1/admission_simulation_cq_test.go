@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+type fakeSimulator struct {
+	results map[string]*scheduler.SimulationResult
+	gotWl   *kueue.Workload
+}
+
+func (f *fakeSimulator) SimulateAdmission(_ context.Context, wl *kueue.Workload, cqName string) (*scheduler.SimulationResult, error) {
+	f.gotWl = wl
+	if wl.Name == "admitted" {
+		return nil, scheduler.ErrWorkloadAlreadyAdmitted
+	}
+	result, found := f.results[cqName]
+	if !found {
+		return nil, cache.ErrCqNotFound
+	}
+	return result, nil
+}
+
+func TestAdmissionSimulationInCQ(t *testing.T) {
+	wl := utiltesting.MakeWorkload("wl", "ns").Queue("lq").Request(corev1.ResourceCPU, "1").Obj()
+	rawWl, err := json.Marshal(wl)
+	if err != nil {
+		t.Fatalf("Failed to encode workload: %v", err)
+	}
+	victim := utiltesting.MakeWorkload("victim", "ns").UID("victim-uid").Priority(-1).Obj()
+
+	results := map[string]*scheduler.SimulationResult{
+		"cq-fit": {
+			Assignment: flavorassigner.Assignment{
+				Borrowing: true,
+				PodSets: []flavorassigner.PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: flavorassigner.ResourceAssignment{
+						corev1.ResourceCPU: &flavorassigner.FlavorAssignment{Name: "on-demand", Mode: flavorassigner.Fit},
+					},
+					Count: 1,
+				}},
+			},
+		},
+		"cq-preempt": {
+			Assignment: flavorassigner.Assignment{
+				PodSets: []flavorassigner.PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: flavorassigner.ResourceAssignment{
+						corev1.ResourceCPU: &flavorassigner.FlavorAssignment{Name: "spot", Mode: flavorassigner.Preempt},
+					},
+					Status: &flavorassigner.Status{},
+					Count:  1,
+				}},
+			},
+			PreemptionTargets: []*preemption.Target{{
+				WorkloadInfo: &workload.Info{Obj: victim, ClusterQueue: "cq-preempt"},
+				Reason:       kueue.InClusterQueueReason,
+			}},
+			Message: "insufficient unused quota",
+		},
+		"cq-nofit": {
+			Message: "Workload namespace doesn't match ClusterQueue selector",
+		},
+	}
+
+	cases := map[string]struct {
+		clusterQueue string
+		simulation   *visibility.AdmissionSimulation
+		wantStatus   *visibility.AdmissionSimulationStatus
+		wantErrMatch func(error) bool
+	}{
+		"workload fits": {
+			clusterQueue: "cq-fit",
+			simulation: &visibility.AdmissionSimulation{
+				Spec: visibility.AdmissionSimulationSpec{Workload: runtime.RawExtension{Raw: rawWl}},
+			},
+			wantStatus: &visibility.AdmissionSimulationStatus{
+				Mode:      visibility.AdmissionSimulationFit,
+				Borrowing: true,
+				PodSetAssignments: []visibility.PodSetAssignmentSimulation{{
+					Name:    kueue.DefaultPodSetName,
+					Flavors: map[string]string{"cpu": "on-demand"},
+					Count:   1,
+				}},
+			},
+		},
+		"workload needs preemption": {
+			clusterQueue: "cq-preempt",
+			simulation: &visibility.AdmissionSimulation{
+				Spec: visibility.AdmissionSimulationSpec{Workload: runtime.RawExtension{Raw: rawWl}},
+			},
+			wantStatus: &visibility.AdmissionSimulationStatus{
+				Mode: visibility.AdmissionSimulationPreempt,
+				PodSetAssignments: []visibility.PodSetAssignmentSimulation{{
+					Name:    kueue.DefaultPodSetName,
+					Flavors: map[string]string{"cpu": "spot"},
+					Count:   1,
+				}},
+				PreemptionTargets: []visibility.PreemptionTarget{{
+					ObjectMeta:       metav1.ObjectMeta{Name: "victim", Namespace: "ns", UID: "victim-uid"},
+					ClusterQueueName: "cq-preempt",
+					Priority:         -1,
+					Reason:           kueue.InClusterQueueReason,
+				}},
+				Message: "insufficient unused quota",
+			},
+		},
+		"workload doesn't fit": {
+			clusterQueue: "cq-nofit",
+			simulation: &visibility.AdmissionSimulation{
+				Spec: visibility.AdmissionSimulationSpec{Workload: runtime.RawExtension{Raw: rawWl}},
+			},
+			wantStatus: &visibility.AdmissionSimulationStatus{
+				Mode:    visibility.AdmissionSimulationNoFit,
+				Message: "Workload namespace doesn't match ClusterQueue selector",
+			},
+		},
+		"missing workload": {
+			clusterQueue: "cq-fit",
+			simulation:   &visibility.AdmissionSimulation{},
+			wantErrMatch: errors.IsBadRequest,
+		},
+		"invalid workload": {
+			clusterQueue: "cq-fit",
+			simulation: &visibility.AdmissionSimulation{
+				Spec: visibility.AdmissionSimulationSpec{Workload: runtime.RawExtension{Raw: []byte(`{"spec": 1}`)}},
+			},
+			wantErrMatch: errors.IsBadRequest,
+		},
+		"workload without namespace": {
+			clusterQueue: "cq-fit",
+			simulation: &visibility.AdmissionSimulation{
+				Spec: visibility.AdmissionSimulationSpec{Workload: runtime.RawExtension{Raw: []byte(`{"metadata": {"name": "wl"}}`)}},
+			},
+			wantErrMatch: errors.IsBadRequest,
+		},
+		"nonexistent ClusterQueue": {
+			clusterQueue: "cq-missing",
+			simulation: &visibility.AdmissionSimulation{
+				Spec: visibility.AdmissionSimulationSpec{Workload: runtime.RawExtension{Raw: rawWl}},
+			},
+			wantErrMatch: errors.IsNotFound,
+		},
+		"workload with the name of an admitted workload": {
+			clusterQueue: "cq-fit",
+			simulation: &visibility.AdmissionSimulation{
+				Spec: visibility.AdmissionSimulationSpec{Workload: runtime.RawExtension{Raw: []byte(`{"metadata": {"name": "admitted", "namespace": "ns"}}`)}},
+			},
+			wantErrMatch: errors.IsConflict,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			simulator := &fakeSimulator{results: results}
+			admissionSimulationRest := NewAdmissionSimulationInCqREST(simulator)
+
+			obj, err := admissionSimulationRest.Create(ctx, tc.clusterQueue, tc.simulation, nil, &metav1.CreateOptions{})
+			if tc.wantErrMatch != nil {
+				if !tc.wantErrMatch(err) {
+					t.Errorf("Error differs: (-want,+got):\n%s", cmp.Diff(tc.wantErrMatch, err))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(wl.Spec, simulator.gotWl.Spec); diff != "" {
+				t.Errorf("Unexpected simulated workload (-want,+got):\n%s", diff)
+			}
+			simulation, ok := obj.(*visibility.AdmissionSimulation)
+			if !ok {
+				t.Fatalf("Not a AdmissionSimulation")
+			}
+			if diff := cmp.Diff(tc.wantStatus, &simulation.Status, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected status (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -22,11 +22,12 @@ import (
 	"sigs.k8s.io/kueue/pkg/queue"
 )
 
-func NewStorage(mgr *queue.Manager) map[string]rest.Storage {
+func NewStorage(mgr *queue.Manager, simulator AdmissionSimulator) map[string]rest.Storage {
 	return map[string]rest.Storage{
-		"clusterqueues":                  NewCqREST(),
-		"clusterqueues/pendingworkloads": NewPendingWorkloadsInCqREST(mgr),
-		"localqueues":                    NewLqREST(),
-		"localqueues/pendingworkloads":   NewPendingWorkloadsInLqREST(mgr),
+		"clusterqueues":                     NewCqREST(),
+		"clusterqueues/pendingworkloads":    NewPendingWorkloadsInCqREST(mgr),
+		"clusterqueues/admissionsimulation": NewAdmissionSimulationInCqREST(simulator),
+		"localqueues":                       NewLqREST(),
+		"localqueues/pendingworkloads":      NewPendingWorkloadsInLqREST(mgr),
 	}
 }
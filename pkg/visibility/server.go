@@ -34,6 +34,7 @@ import (
 	visibilityv1beta1 "sigs.k8s.io/kueue/apis/visibility/v1beta1"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/visibility/api"
+	apiv1beta1 "sigs.k8s.io/kueue/pkg/visibility/api/v1beta1"
 
 	_ "k8s.io/component-base/metrics/prometheus/restclient" // for client-go metrics registration
 )
@@ -46,8 +47,9 @@ var (
 // +kubebuilder:rbac:groups=flowcontrol.apiserver.k8s.io,resources=flowschemas,verbs=list;watch
 // +kubebuilder:rbac:groups=flowcontrol.apiserver.k8s.io,resources=flowschemas/status,verbs=patch
 
-// CreateAndStartVisibilityServer creates visibility server injecting KueueManager and starts it
-func CreateAndStartVisibilityServer(ctx context.Context, kueueMgr *queue.Manager) {
+// CreateAndStartVisibilityServer creates visibility server injecting KueueManager and
+// the AdmissionSimulator, and starts it
+func CreateAndStartVisibilityServer(ctx context.Context, kueueMgr *queue.Manager, simulator apiv1beta1.AdmissionSimulator) {
 	config := newVisibilityServerConfig()
 	if err := applyVisibilityServerOptions(config); err != nil {
 		setupLog.Error(err, "Unable to apply VisibilityServerOptions")
@@ -60,7 +62,7 @@ func CreateAndStartVisibilityServer(ctx context.Context, kueueMgr *queue.Manager
 		os.Exit(1)
 	}
 
-	if err := api.Install(visibilityServer, kueueMgr); err != nil {
+	if err := api.Install(visibilityServer, kueueMgr, simulator); err != nil {
 		setupLog.Error(err, "Unable to install visibility.kueue.x-k8s.io API")
 		os.Exit(1)
 	}
@@ -33,6 +33,7 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 const (
@@ -111,6 +112,17 @@ func ValidateClusterQueue(cq *kueue.ClusterQueue) field.ErrorList {
 	if cq.Spec.FairSharing != nil {
 		allErrs = append(allErrs, validateFairSharing(cq.Spec.FairSharing, path.Child("fairSharing"))...)
 	}
+	if cq.Spec.Backfill != nil && cq.Spec.QueueingStrategy != kueue.StrictFIFO {
+		allErrs = append(allErrs, field.Forbidden(path.Child("backfill"), "backfill is only supported with the StrictFIFO queueing strategy"))
+	}
+	if cq.Spec.PriorityAging != nil && cq.Spec.PriorityAging.Interval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("priorityAging", "interval"), cq.Spec.PriorityAging.Interval.Duration.String(), "must be positive"))
+	}
+	if cq.Spec.OrderingExpression != nil {
+		if _, err := workload.CompileOrderingExpression(*cq.Spec.OrderingExpression); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("orderingExpression"), *cq.Spec.OrderingExpression, err.Error()))
+		}
+	}
 	return allErrs
 }
 
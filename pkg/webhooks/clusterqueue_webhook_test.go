@@ -18,6 +18,7 @@ package webhooks
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -310,6 +311,70 @@ func TestValidateClusterQueue(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "backfill with StrictFIFO",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				QueueingStrategy(kueue.StrictFIFO).
+				Backfill(10).
+				Obj(),
+		},
+		{
+			name: "backfill with BestEffortFIFO",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				Backfill(10).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(specPath.Child("backfill"), ""),
+			},
+		},
+		{
+			name: "priority aging",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				PriorityAging(kueue.PriorityAgingStep, time.Hour, 10, 100).
+				Obj(),
+		},
+		{
+			name: "priority aging with zero interval",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				PriorityAging(kueue.PriorityAgingLinear, 0, 10, 100).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("priorityAging", "interval"), "0s", ""),
+			},
+		},
+		{
+			name: "ordering expression",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				OrderingExpression(`int(labels["team-rank"]) * 1000 - priority`).
+				Obj(),
+		},
+		{
+			name: "ordering expression with a syntax error",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				OrderingExpression("priority +").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("orderingExpression"), "", ""),
+			},
+		},
+		{
+			name: "ordering expression with an unknown variable",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				OrderingExpression("spec.priority").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("orderingExpression"), "", ""),
+			},
+		},
+		{
+			name: "ordering expression with a non comparable result",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				OrderingExpression(`"gpu" in requests`).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("orderingExpression"), "", ""),
+			},
+		},
 		{
 			name: "existing cluster queue created with older Kueue version that has a nil borrowWithinCohort field",
 			clusterQueue: &kueue.ClusterQueue{
@@ -72,5 +72,9 @@ func validateCohort(cohort *kueuealpha.Cohort) field.ErrorList {
 		hasParent:                        cohort.Spec.Parent != "",
 		enforceNominalGreaterThanLending: false,
 	}
-	return validateResourceGroups(cohort.Spec.ResourceGroups, config, path.Child("resourceGroups"))
+	allErrs := validateResourceGroups(cohort.Spec.ResourceGroups, config, path.Child("resourceGroups"))
+	if cohort.Spec.FairSharing != nil {
+		allErrs = append(allErrs, validateFairSharing(cohort.Spec.FairSharing, path.Child("fairSharing"))...)
+	}
+	return allErrs
 }
@@ -93,6 +93,9 @@ func ValidateResourceFlavor(rf *kueue.ResourceFlavor) field.ErrorList {
 
 	allErrs = append(allErrs, validateNodeTaints(rf.Spec.NodeTaints, specPath.Child("nodeTaints"))...)
 	allErrs = append(allErrs, validateTolerations(rf.Spec.Tolerations, specPath.Child("tolerations"))...)
+	if rf.Spec.Cost != nil {
+		allErrs = append(allErrs, validateResourceQuantity(*rf.Spec.Cost, specPath.Child("cost"))...)
+	}
 	return allErrs
 }
 
@@ -56,6 +56,13 @@ func TestValidateResourceFlavor(t *testing.T) {
 				field.Invalid(field.NewPath("spec", "nodeLabels"), "@abc", ""),
 			},
 		},
+		{
+			name: "negative cost",
+			rf:   utiltesting.MakeResourceFlavor("resource-flavor").Cost("-1").Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "cost"), "-1", ""),
+			},
+		},
 		{
 			name: "invalid label value",
 			rf:   utiltesting.MakeResourceFlavor("resource-flavor").NodeLabel("foo", "@abc").Obj(),
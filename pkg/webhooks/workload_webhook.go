@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -111,6 +112,8 @@ func ValidateWorkload(obj *kueue.Workload) field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("podSets"), variableCountPodSets, "at most one podSet can use minCount"))
 	}
 
+	allErrs = append(allErrs, validateDependencies(obj, specPath.Child("dependencies"))...)
+
 	statusPath := field.NewPath("status")
 	if workload.HasQuotaReservation(obj) {
 		allErrs = append(allErrs, validateAdmission(obj, statusPath.Child("admission"))...)
@@ -123,6 +126,26 @@ func ValidateWorkload(obj *kueue.Workload) field.ErrorList {
 	return allErrs
 }
 
+func validateDependencies(obj *kueue.Workload, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i := range obj.Spec.Dependencies {
+		dep := &obj.Spec.Dependencies[i]
+		depPath := path.Index(i)
+		if dep.Name != nil {
+			for _, msg := range apivalidation.NameIsDNSSubdomain(*dep.Name, false) {
+				allErrs = append(allErrs, field.Invalid(depPath.Child("name"), *dep.Name, msg))
+			}
+			if *dep.Name == obj.Name {
+				allErrs = append(allErrs, field.Invalid(depPath.Child("name"), *dep.Name, "a workload cannot depend on itself"))
+			}
+		}
+		if dep.Selector != nil {
+			allErrs = append(allErrs, metav1validation.ValidateLabelSelector(dep.Selector, metav1validation.LabelSelectorValidationOptions{}, depPath.Child("selector"))...)
+		}
+	}
+	return allErrs
+}
+
 func validatePodSet(ps *kueue.PodSet, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -274,6 +297,9 @@ func ValidateWorkloadUpdate(newObj, oldObj *kueue.Workload) field.ErrorList {
 	if workload.HasQuotaReservation(oldObj) {
 		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newObj.Spec.PodSets, oldObj.Spec.PodSets, specPath.Child("podSets"))...)
 	}
+	if apimeta.IsStatusConditionTrue(oldObj.Status.Conditions, kueue.WorkloadDependenciesSatisfied) {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newObj.Spec.Dependencies, oldObj.Spec.Dependencies, specPath.Child("dependencies"))...)
+	}
 	if workload.HasQuotaReservation(newObj) && workload.HasQuotaReservation(oldObj) {
 		allErrs = append(allErrs, validateReclaimablePodsUpdate(newObj, oldObj, field.NewPath("status", "reclaimablePods"))...)
 	}
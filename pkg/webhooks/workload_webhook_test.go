@@ -237,6 +237,28 @@ func TestValidateWorkload(t *testing.T) {
 				field.Invalid(podSetsPath, nil, ""),
 			},
 		},
+		"valid dependencies": {
+			workload: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Dependencies(
+					kueue.WorkloadDependency{Name: ptr.To("prepare")},
+					kueue.WorkloadDependency{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"stage": "prepare"}}},
+				).
+				Obj(),
+		},
+		"invalid dependencies": {
+			workload: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Dependencies(
+					kueue.WorkloadDependency{Name: ptr.To("@prepare")},
+					kueue.WorkloadDependency{Name: ptr.To(testWorkloadName)},
+					kueue.WorkloadDependency{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"stage": "@prepare"}}},
+				).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("dependencies").Index(0).Child("name"), nil, ""),
+				field.Invalid(specPath.Child("dependencies").Index(1).Child("name"), nil, ""),
+				field.Invalid(specPath.Child("dependencies").Index(2).Child("selector", "matchLabels"), nil, ""),
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -392,6 +414,35 @@ func TestValidateWorkloadUpdate(t *testing.T) {
 				State:              kueue.CheckStateReady,
 			}).Obj(),
 		},
+		"dependencies can change while they are not satisfied": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Dependencies(kueue.WorkloadDependency{Name: ptr.To("prepare")}).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Dependencies(kueue.WorkloadDependency{Name: ptr.To("download")}).
+				Obj(),
+		},
+		"dependencies cannot change once satisfied": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Dependencies(kueue.WorkloadDependency{Name: ptr.To("prepare")}).
+				Condition(metav1.Condition{
+					Type:   kueue.WorkloadDependenciesSatisfied,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadDependenciesReasonSatisfied,
+				}).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Dependencies(kueue.WorkloadDependency{Name: ptr.To("download")}).
+				Condition(metav1.Condition{
+					Type:   kueue.WorkloadDependenciesSatisfied,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadDependenciesReasonSatisfied,
+				}).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "dependencies"), nil, ""),
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
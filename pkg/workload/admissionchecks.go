@@ -32,9 +32,10 @@ import (
 func SyncAdmittedCondition(w *kueue.Workload, now time.Time) bool {
 	hasReservation := HasQuotaReservation(w)
 	hasAllChecksReady := HasAllChecksReady(w)
+	hasTopologyAssignmentsPending := HasTopologyAssignmentsPending(w)
 	isAdmitted := IsAdmitted(w)
 
-	if isAdmitted == (hasReservation && hasAllChecksReady) {
+	if isAdmitted == (hasReservation && hasAllChecksReady && !hasTopologyAssignmentsPending) {
 		return false
 	}
 	newCondition := metav1.Condition{
@@ -57,6 +58,10 @@ func SyncAdmittedCondition(w *kueue.Workload, now time.Time) bool {
 		newCondition.Status = metav1.ConditionFalse
 		newCondition.Reason = "UnsatisfiedChecks"
 		newCondition.Message = "The workload has not all checks ready"
+	case hasTopologyAssignmentsPending:
+		newCondition.Status = metav1.ConditionFalse
+		newCondition.Reason = "PendingDelayedTopologyRequests"
+		newCondition.Message = "The workload has pending delayed topology requests"
 	}
 
 	// Accumulate the admitted time if needed
@@ -35,6 +35,7 @@ func TestSyncAdmittedCondition(t *testing.T) {
 	cases := map[string]struct {
 		checkStates      []kueue.AdmissionCheckState
 		conditions       []metav1.Condition
+		admission        *kueue.Admission
 		pastAdmittedTime int32
 
 		wantConditions   []metav1.Condition
@@ -118,6 +119,63 @@ func TestSyncAdmittedCondition(t *testing.T) {
 			},
 			wantChange: true,
 		},
+		"reservation, checks ready, delayed topology request pending": {
+			checkStates: []kueue.AdmissionCheckState{
+				{
+					Name:  "check1",
+					State: kueue.CheckStateReady,
+				},
+			},
+			conditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+			},
+			admission: utiltesting.MakeAdmission("cq").
+				DelayedTopologyRequest(kueue.DelayedTopologyRequestStatePending).
+				Obj(),
+			wantConditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+			},
+		},
+		"reservation, checks ready, delayed topology request ready": {
+			checkStates: []kueue.AdmissionCheckState{
+				{
+					Name:  "check1",
+					State: kueue.CheckStateReady,
+				},
+			},
+			conditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+			},
+			admission: utiltesting.MakeAdmission("cq").
+				DelayedTopologyRequest(kueue.DelayedTopologyRequestStateReady).
+				TopologyAssignment(&kueue.TopologyAssignment{
+					Levels:  []string{corev1.LabelHostname},
+					Domains: []kueue.TopologyDomainAssignment{{Values: []string{"x1"}, Count: 1}},
+				}).
+				Obj(),
+			wantConditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+				{
+					Type:               kueue.WorkloadAdmitted,
+					Status:             metav1.ConditionTrue,
+					Reason:             "Admitted",
+					ObservedGeneration: 1,
+				},
+			},
+			wantChange: true,
+		},
 		"reservation lost": {
 			checkStates: []kueue.AdmissionCheckState{
 				{
@@ -263,6 +321,7 @@ func TestSyncAdmittedCondition(t *testing.T) {
 				builder = builder.PastAdmittedTime(tc.pastAdmittedTime)
 			}
 			wl := builder.Obj()
+			wl.Status.Admission = tc.admission
 
 			gotChange := SyncAdmittedCondition(wl, testTime)
 
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"fmt"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/util/api"
+)
+
+// DependenciesState is the state of the dependencies of a workload.
+type DependenciesState int
+
+const (
+	// DependenciesPending means that at least one dependency didn't finish.
+	DependenciesPending DependenciesState = iota
+	// DependenciesSatisfied means that all the dependencies finished as
+	// required.
+	DependenciesSatisfied
+	// DependenciesFailed means that a dependency finished without satisfying
+	// its condition.
+	DependenciesFailed
+)
+
+// HasDependencies returns true if the workload has dependencies which need to
+// be enforced.
+func HasDependencies(wl *kueue.Workload) bool {
+	return features.Enabled(features.WorkloadDependencies) && len(wl.Spec.Dependencies) > 0
+}
+
+// DependenciesReady returns true if the workload can compete for admission as
+// far as its dependencies are concerned.
+func DependenciesReady(wl *kueue.Workload) bool {
+	return !HasDependencies(wl) || apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadDependenciesSatisfied)
+}
+
+// EvaluateDependencies returns the state of the dependencies of wl given the
+// workloads in its namespace, and a message describing it.
+func EvaluateDependencies(wl *kueue.Workload, workloads []kueue.Workload) (DependenciesState, string, error) {
+	state := DependenciesSatisfied
+	message := "All the dependencies are satisfied"
+	for i := range wl.Spec.Dependencies {
+		dep := &wl.Spec.Dependencies[i]
+		matched, err := dependencyMatches(wl, dep, workloads)
+		if err != nil {
+			return DependenciesPending, "", err
+		}
+		if len(matched) == 0 {
+			if state == DependenciesSatisfied {
+				state = DependenciesPending
+				message = fmt.Sprintf("Waiting for the dependency %s to be created", dependencyDescription(dep))
+			}
+			continue
+		}
+		for _, m := range matched {
+			finished := apimeta.FindStatusCondition(m.Status.Conditions, kueue.WorkloadFinished)
+			if finished == nil || finished.Status != metav1.ConditionTrue {
+				if state == DependenciesSatisfied {
+					state = DependenciesPending
+					message = fmt.Sprintf("Waiting for the dependency %q to finish", m.Name)
+				}
+				continue
+			}
+			if dep.Condition != kueue.WorkloadDependencyFinished && finished.Reason != kueue.WorkloadFinishedReasonSucceeded {
+				return DependenciesFailed, fmt.Sprintf("The dependency %q finished with the %s reason", m.Name, finished.Reason), nil
+			}
+		}
+	}
+	return state, message, nil
+}
+
+// DependsOn returns true if any dependency of wl references dep.
+func DependsOn(wl, dep *kueue.Workload) bool {
+	if wl.Namespace != dep.Namespace || wl.Name == dep.Name {
+		return false
+	}
+	for i := range wl.Spec.Dependencies {
+		matched, err := dependencyMatches(wl, &wl.Spec.Dependencies[i], []kueue.Workload{*dep})
+		if err == nil && len(matched) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func dependencyMatches(wl *kueue.Workload, dep *kueue.WorkloadDependency, workloads []kueue.Workload) ([]*kueue.Workload, error) {
+	var selector labels.Selector
+	if dep.Selector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(dep.Selector); err != nil {
+			return nil, err
+		}
+	}
+	var matched []*kueue.Workload
+	for i := range workloads {
+		candidate := &workloads[i]
+		if candidate.Name == wl.Name {
+			continue
+		}
+		switch {
+		case dep.Name != nil && candidate.Name == *dep.Name:
+			matched = append(matched, candidate)
+		case selector != nil && selector.Matches(labels.Set(candidate.Labels)):
+			matched = append(matched, candidate)
+		}
+	}
+	return matched, nil
+}
+
+func dependencyDescription(dep *kueue.WorkloadDependency) string {
+	if dep.Name != nil {
+		return fmt.Sprintf("%q", *dep.Name)
+	}
+	return fmt.Sprintf("matching %q", metav1.FormatLabelSelector(dep.Selector))
+}
+
+// SetDependenciesSatisfiedCondition sets the DependenciesSatisfied condition,
+// returns true if it changed.
+func SetDependenciesSatisfiedCondition(wl *kueue.Workload, satisfied bool, message string) bool {
+	condition := metav1.Condition{
+		Type:               kueue.WorkloadDependenciesSatisfied,
+		Status:             metav1.ConditionFalse,
+		Reason:             kueue.WorkloadDependenciesReasonPending,
+		Message:            api.TruncateConditionMessage(message),
+		ObservedGeneration: wl.Generation,
+	}
+	if satisfied {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = kueue.WorkloadDependenciesReasonSatisfied
+	}
+	return apimeta.SetStatusCondition(&wl.Status.Conditions, condition)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func finishedCondition(reason string) metav1.Condition {
+	return metav1.Condition{
+		Type:   kueue.WorkloadFinished,
+		Status: metav1.ConditionTrue,
+		Reason: reason,
+	}
+}
+
+func TestEvaluateDependencies(t *testing.T) {
+	byName := kueue.WorkloadDependency{Name: ptr.To("a"), Condition: kueue.WorkloadDependencySucceeded}
+	bySelector := kueue.WorkloadDependency{
+		Selector:  &metav1.LabelSelector{MatchLabels: map[string]string{"stage": "prepare"}},
+		Condition: kueue.WorkloadDependencySucceeded,
+	}
+	anyCompletion := kueue.WorkloadDependency{Name: ptr.To("a"), Condition: kueue.WorkloadDependencyFinished}
+
+	cases := map[string]struct {
+		deps        []kueue.WorkloadDependency
+		workloads   []kueue.Workload
+		wantState   DependenciesState
+		wantMessage string
+	}{
+		"dependency not created": {
+			deps:        []kueue.WorkloadDependency{byName},
+			wantState:   DependenciesPending,
+			wantMessage: `Waiting for the dependency "a" to be created`,
+		},
+		"dependency running": {
+			deps:        []kueue.WorkloadDependency{byName},
+			workloads:   []kueue.Workload{*utiltesting.MakeWorkload("a", "ns").Obj()},
+			wantState:   DependenciesPending,
+			wantMessage: `Waiting for the dependency "a" to finish`,
+		},
+		"dependency succeeded": {
+			deps: []kueue.WorkloadDependency{byName},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Condition(finishedCondition(kueue.WorkloadFinishedReasonSucceeded)).Obj(),
+			},
+			wantState:   DependenciesSatisfied,
+			wantMessage: "All the dependencies are satisfied",
+		},
+		"dependency failed": {
+			deps: []kueue.WorkloadDependency{byName},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Condition(finishedCondition(kueue.WorkloadFinishedReasonFailed)).Obj(),
+			},
+			wantState:   DependenciesFailed,
+			wantMessage: `The dependency "a" finished with the Failed reason`,
+		},
+		"dependency failed with any completion": {
+			deps: []kueue.WorkloadDependency{anyCompletion},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Condition(finishedCondition(kueue.WorkloadFinishedReasonFailed)).Obj(),
+			},
+			wantState:   DependenciesSatisfied,
+			wantMessage: "All the dependencies are satisfied",
+		},
+		"selector without matches": {
+			deps:        []kueue.WorkloadDependency{bySelector},
+			workloads:   []kueue.Workload{*utiltesting.MakeWorkload("a", "ns").Obj()},
+			wantState:   DependenciesPending,
+			wantMessage: `Waiting for the dependency matching "stage=prepare" to be created`,
+		},
+		"selector with a running match": {
+			deps: []kueue.WorkloadDependency{bySelector},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Label("stage", "prepare").Condition(finishedCondition(kueue.WorkloadFinishedReasonSucceeded)).Obj(),
+				*utiltesting.MakeWorkload("b", "ns").Label("stage", "prepare").Obj(),
+			},
+			wantState:   DependenciesPending,
+			wantMessage: `Waiting for the dependency "b" to finish`,
+		},
+		"failure reported before pending dependencies": {
+			deps: []kueue.WorkloadDependency{bySelector, byName},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Condition(finishedCondition(kueue.WorkloadFinishedReasonFailed)).Obj(),
+			},
+			wantState:   DependenciesFailed,
+			wantMessage: `The dependency "a" finished with the Failed reason`,
+		},
+		"the workload doesn't match its own selector": {
+			deps: []kueue.WorkloadDependency{bySelector},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl", "ns").Label("stage", "prepare").Obj(),
+				*utiltesting.MakeWorkload("a", "ns").Label("stage", "prepare").Condition(finishedCondition(kueue.WorkloadFinishedReasonSucceeded)).Obj(),
+			},
+			wantState:   DependenciesSatisfied,
+			wantMessage: "All the dependencies are satisfied",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			wl := utiltesting.MakeWorkload("wl", "ns").Label("stage", "prepare").Dependencies(tc.deps...).Obj()
+			state, message, err := EvaluateDependencies(wl, tc.workloads)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if state != tc.wantState {
+				t.Errorf("Unexpected state, want=%d, got=%d", tc.wantState, state)
+			}
+			if message != tc.wantMessage {
+				t.Errorf("Unexpected message, want=%q, got=%q", tc.wantMessage, message)
+			}
+		})
+	}
+}
+
+func TestDependsOn(t *testing.T) {
+	wl := utiltesting.MakeWorkload("wl", "ns").
+		Dependencies(
+			kueue.WorkloadDependency{Name: ptr.To("a")},
+			kueue.WorkloadDependency{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"stage": "prepare"}}},
+		).
+		Obj()
+	cases := map[string]struct {
+		dep  *kueue.Workload
+		want bool
+	}{
+		"by name": {
+			dep:  utiltesting.MakeWorkload("a", "ns").Obj(),
+			want: true,
+		},
+		"by selector": {
+			dep:  utiltesting.MakeWorkload("b", "ns").Label("stage", "prepare").Obj(),
+			want: true,
+		},
+		"other namespace": {
+			dep: utiltesting.MakeWorkload("a", "other").Obj(),
+		},
+		"unrelated": {
+			dep: utiltesting.MakeWorkload("b", "ns").Label("stage", "train").Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := DependsOn(wl, tc.dep); got != tc.want {
+				t.Errorf("Unexpected DependsOn, want=%t, got=%t", tc.want, got)
+			}
+		})
+	}
+}
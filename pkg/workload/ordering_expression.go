@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utilpriority "sigs.k8s.io/kueue/pkg/util/priority"
+)
+
+// orderingExpressionCostLimit bounds the cost of evaluating an ordering
+// expression for one workload.
+const orderingExpressionCostLimit = 100_000
+
+var (
+	orderingExpressionEnv *cel.Env
+
+	orderingExpressionOutputTypes = []*cel.Type{
+		cel.IntType,
+		cel.UintType,
+		cel.DoubleType,
+		cel.StringType,
+		cel.TimestampType,
+		cel.DurationType,
+	}
+)
+
+func init() {
+	var err error
+	orderingExpressionEnv, err = cel.NewEnv(
+		cel.Variable("labels", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("annotations", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("priority", cel.IntType),
+		cel.Variable("basePriority", cel.IntType),
+		cel.Variable("requests", cel.MapType(cel.StringType, cel.IntType)),
+		cel.Variable("creationTimestamp", cel.TimestampType),
+		cel.Variable("queueName", cel.StringType),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("creating the CEL environment of the ordering expressions: %v", err))
+	}
+}
+
+// OrderingExpression is a compiled CEL expression which computes the sort key
+// of the pending workloads of a ClusterQueue.
+type OrderingExpression struct {
+	expression string
+	program    cel.Program
+}
+
+// CompileOrderingExpression compiles the expression and checks that it
+// evaluates to a comparable type.
+func CompileOrderingExpression(expression string) (*OrderingExpression, error) {
+	ast, iss := orderingExpressionEnv.Compile(expression)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	if !comparableOutputType(ast.OutputType()) {
+		return nil, fmt.Errorf("the expression must evaluate to an int, uint, double, string, timestamp or duration, got %s", ast.OutputType())
+	}
+	program, err := orderingExpressionEnv.Program(ast, cel.CostLimit(orderingExpressionCostLimit))
+	if err != nil {
+		return nil, err
+	}
+	return &OrderingExpression{expression: expression, program: program}, nil
+}
+
+func comparableOutputType(t *cel.Type) bool {
+	for _, ot := range orderingExpressionOutputTypes {
+		if t.IsExactType(ot) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the source of the expression.
+func (e *OrderingExpression) String() string {
+	return e.expression
+}
+
+// OrderingKeys compares the workloads by the results of an
+// OrderingExpression, which are memoized until the workload objects are
+// replaced or forgotten.
+type OrderingKeys struct {
+	expression *OrderingExpression
+
+	mu   sync.Mutex
+	keys map[string]orderingKey
+}
+
+// orderingKey is the result of the expression for the workload object it was
+// evaluated on.
+type orderingKey struct {
+	obj *kueue.Workload
+	val ref.Val
+	err error
+}
+
+// NewOrderingKeys returns the OrderingKeys of the expression.
+func NewOrderingKeys(expression *OrderingExpression) *OrderingKeys {
+	return &OrderingKeys{
+		expression: expression,
+		keys:       make(map[string]orderingKey),
+	}
+}
+
+// Expression returns the expression used to compute the keys.
+func (k *OrderingKeys) Expression() *OrderingExpression {
+	return k.expression
+}
+
+// Compare returns a negative number if a goes before b, a positive number if
+// b goes before a, or zero if their keys are equal. The workloads for which
+// the expression fails to evaluate go after the others.
+func (k *OrderingKeys) Compare(a, b *Info) int {
+	kA, errA := k.key(a)
+	kB, errB := k.key(b)
+	switch {
+	case errA != nil && errB != nil:
+		return 0
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	}
+	c, ok := kA.(traits.Comparer).Compare(kB).(types.Int)
+	if !ok {
+		return 0
+	}
+	return int(c)
+}
+
+// Forget drops the memoized key of the workload.
+func (k *OrderingKeys) Forget(wlKey string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	delete(k.keys, wlKey)
+}
+
+func (k *OrderingKeys) key(i *Info) (ref.Val, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	wlKey := Key(i.Obj)
+	if cached, found := k.keys[wlKey]; found && cached.obj == i.Obj {
+		return cached.val, cached.err
+	}
+	val, err := k.expression.eval(i)
+	k.keys[wlKey] = orderingKey{obj: i.Obj, val: val, err: err}
+	return val, err
+}
+
+func (e *OrderingExpression) eval(i *Info) (ref.Val, error) {
+	requests := make(map[string]int64)
+	for _, ps := range i.TotalRequests {
+		for name, v := range ps.Requests {
+			requests[string(name)] += v
+		}
+	}
+	val, _, err := e.program.Eval(map[string]any{
+		"labels":            nonNilMap(i.Obj.Labels),
+		"annotations":       nonNilMap(i.Obj.Annotations),
+		"priority":          int64(utilpriority.Effective(i.Obj)),
+		"basePriority":      int64(utilpriority.Priority(i.Obj)),
+		"requests":          requests,
+		"creationTimestamp": i.Obj.CreationTimestamp.Time,
+		"queueName":         i.Obj.Spec.QueueName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := val.(traits.Comparer); !ok {
+		return nil, errors.New("the result of the expression is not comparable")
+	}
+	return val, nil
+}
+
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
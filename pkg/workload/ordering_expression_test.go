@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestCompileOrderingExpression(t *testing.T) {
+	cases := map[string]struct {
+		expression string
+		wantErr    bool
+	}{
+		"int": {
+			expression: "-priority",
+		},
+		"string": {
+			expression: `labels["team"]`,
+		},
+		"timestamp": {
+			expression: "creationTimestamp",
+		},
+		"duration": {
+			expression: `creationTimestamp - timestamp("2024-01-01T00:00:00Z")`,
+		},
+		"no matching overload": {
+			expression: `duration("1h") * priority`,
+			wantErr:    true,
+		},
+		"double": {
+			expression: `double(requests["cpu"]) / 1000.0`,
+		},
+		"bool": {
+			expression: `"gpu" in requests`,
+			wantErr:    true,
+		},
+		"unknown variable": {
+			expression: "spec.priority",
+			wantErr:    true,
+		},
+		"syntax error": {
+			expression: "priority +",
+			wantErr:    true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := CompileOrderingExpression(tc.expression)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Unexpected error, want error=%t, got=%v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestOrderingKeysCompare(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cases := map[string]struct {
+		expression          string
+		enablePriorityAging bool
+		a, b                *Info
+		want                int
+	}{
+		"lower effective priority first": {
+			expression:          "priority",
+			enablePriorityAging: true,
+			a:                   NewInfo(utiltesting.MakeWorkload("a", "ns").Priority(1).EffectivePriority(20).Obj()),
+			b:                   NewInfo(utiltesting.MakeWorkload("b", "ns").Priority(10).Obj()),
+			want:                1,
+		},
+		"lower base priority first": {
+			expression:          "basePriority",
+			enablePriorityAging: true,
+			a:                   NewInfo(utiltesting.MakeWorkload("a", "ns").Priority(1).EffectivePriority(20).Obj()),
+			b:                   NewInfo(utiltesting.MakeWorkload("b", "ns").Priority(10).Obj()),
+			want:                -1,
+		},
+		"lower priority first": {
+			expression: "priority",
+			a:          NewInfo(utiltesting.MakeWorkload("a", "ns").Priority(10).Obj()),
+			b:          NewInfo(utiltesting.MakeWorkload("b", "ns").Priority(1).Obj()),
+			want:       1,
+		},
+		"equal keys": {
+			expression: `labels["team"]`,
+			a:          NewInfo(utiltesting.MakeWorkload("a", "ns").Label("team", "x").Obj()),
+			b:          NewInfo(utiltesting.MakeWorkload("b", "ns").Label("team", "x").Obj()),
+			want:       0,
+		},
+		"smaller requests first": {
+			expression: `requests["cpu"]`,
+			a: NewInfo(utiltesting.MakeWorkload("a", "ns").
+				Request(corev1.ResourceCPU, "500m").
+				Obj()),
+			b: NewInfo(utiltesting.MakeWorkload("b", "ns").
+				PodSets(*utiltesting.MakePodSet("main", 2).Request(corev1.ResourceCPU, "1").Obj()).
+				Obj()),
+			want: -1,
+		},
+		"older first": {
+			expression: "creationTimestamp",
+			a:          NewInfo(utiltesting.MakeWorkload("a", "ns").Creation(now.Add(time.Second)).Obj()),
+			b:          NewInfo(utiltesting.MakeWorkload("b", "ns").Creation(now).Obj()),
+			want:       1,
+		},
+		"failed evaluation last": {
+			expression: `int(labels["rank"])`,
+			a:          NewInfo(utiltesting.MakeWorkload("a", "ns").Obj()),
+			b:          NewInfo(utiltesting.MakeWorkload("b", "ns").Label("rank", "5").Obj()),
+			want:       1,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PriorityAging, tc.enablePriorityAging)
+			expression, err := CompileOrderingExpression(tc.expression)
+			if err != nil {
+				t.Fatalf("Compiling the expression: %v", err)
+			}
+			keys := NewOrderingKeys(expression)
+			if got := keys.Compare(tc.a, tc.b); got != tc.want {
+				t.Errorf("Unexpected comparison, want=%d, got=%d", tc.want, got)
+			}
+			if got := keys.Compare(tc.b, tc.a); got != -tc.want {
+				t.Errorf("Unexpected reverse comparison, want=%d, got=%d", -tc.want, got)
+			}
+		})
+	}
+}
+
+func TestOrderingKeysMemoization(t *testing.T) {
+	expression, err := CompileOrderingExpression(`int(labels["rank"])`)
+	if err != nil {
+		t.Fatalf("Compiling the expression: %v", err)
+	}
+	keys := NewOrderingKeys(expression)
+	a := NewInfo(utiltesting.MakeWorkload("a", "ns").Label("rank", "1").Obj())
+	b := NewInfo(utiltesting.MakeWorkload("b", "ns").Label("rank", "2").Obj())
+	if got := keys.Compare(a, b); got != -1 {
+		t.Fatalf("Unexpected comparison, want=-1, got=%d", got)
+	}
+
+	// Mutating the object in place doesn't change the memoized key.
+	a.Obj.Labels["rank"] = "3"
+	if got := keys.Compare(a, b); got != -1 {
+		t.Errorf("Unexpected comparison with the memoized key, want=-1, got=%d", got)
+	}
+
+	// Replacing the object re-evaluates the expression.
+	a.Update(a.Obj.DeepCopy())
+	if got := keys.Compare(a, b); got != 1 {
+		t.Errorf("Unexpected comparison after the update, want=1, got=%d", got)
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -64,6 +65,7 @@ var (
 		kueue.WorkloadPreempted,
 		kueue.WorkloadRequeued,
 		kueue.WorkloadDeactivationTarget,
+		kueue.WorkloadDependenciesSatisfied,
 	}
 )
 
@@ -185,6 +187,24 @@ type TopologyDomainRequests struct {
 	Requests resources.Requests
 }
 
+// TopologyDomainRequestsFor returns the requests per topology domain of the
+// assignment, given the requests of a single pod.
+func TopologyDomainRequestsFor(ta *kueue.TopologyAssignment, singlePodRequests resources.Requests) []TopologyDomainRequests {
+	if ta == nil {
+		return nil
+	}
+	result := make([]TopologyDomainRequests, 0, len(ta.Domains))
+	for _, domain := range ta.Domains {
+		domainRequests := singlePodRequests.Clone()
+		domainRequests.Mul(int64(domain.Count))
+		result = append(result, TopologyDomainRequests{
+			Values:   domain.Values,
+			Requests: domainRequests,
+		})
+	}
+	return result
+}
+
 func (psr *PodSetResources) ScaledTo(newCount int32) *PodSetResources {
 	if psr.TopologyRequest != nil {
 		return psr
@@ -537,11 +557,16 @@ func SetRequeuedCondition(wl *kueue.Workload, reason, message string, status boo
 }
 
 func QueuedWaitTime(wl *kueue.Workload) time.Duration {
-	queuedTime := wl.CreationTimestamp.Time
+	return time.Since(QueuedSince(wl))
+}
+
+// QueuedSince returns the time at which the workload was created or last
+// requeued.
+func QueuedSince(wl *kueue.Workload) time.Time {
 	if c := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadRequeued); c != nil {
-		queuedTime = c.LastTransitionTime.Time
+		return c.LastTransitionTime.Time
 	}
-	return time.Since(queuedTime)
+	return wl.CreationTimestamp.Time
 }
 
 // BaseSSAWorkload creates a new object based on the input workload that
@@ -657,6 +682,19 @@ func PropagateResourceRequests(w *kueue.Workload, info *Info) bool {
 	return true
 }
 
+// PropagateSchedulingAttempt sets the details of the last scheduling attempt
+// in the workload status. Returns true if the status changed.
+func PropagateSchedulingAttempt(w *kueue.Workload, attempt *kueue.SchedulingAttempt) bool {
+	if !features.Enabled(features.WorkloadSchedulingAttemptDetails) {
+		return false
+	}
+	if equality.Semantic.DeepEqual(w.Status.LastSchedulingAttempt, attempt) {
+		return false
+	}
+	w.Status.LastSchedulingAttempt = attempt
+	return true
+}
+
 // AdmissionStatusPatch creates a new object based on the input workload that contains
 // the admission and related conditions. The object can be used in Server-Side-Apply.
 // If strict is true, resourceVersion will be part of the patch.
@@ -680,6 +718,10 @@ func AdmissionStatusPatch(w *kueue.Workload, wlCopy *kueue.Workload, strict bool
 		wlCopy.ResourceVersion = w.ResourceVersion
 	}
 	wlCopy.Status.AccumulatedPastExexcutionTimeSeconds = w.Status.AccumulatedPastExexcutionTimeSeconds
+	wlCopy.Status.EffectivePriority = w.Status.EffectivePriority
+	if wlCopy.Status.Admission == nil && features.Enabled(features.WorkloadSchedulingAttemptDetails) {
+		wlCopy.Status.LastSchedulingAttempt = w.Status.LastSchedulingAttempt.DeepCopy()
+	}
 }
 
 func AdmissionChecksStatusPatch(w *kueue.Workload, wlCopy *kueue.Workload) {
@@ -733,6 +775,31 @@ func (o Ordering) GetQueueOrderTimestamp(w *kueue.Workload) *metav1.Time {
 	return &w.CreationTimestamp
 }
 
+// GetLatestStartTime returns the latest time at which the workload can start
+// to complete before its deadline, to be used by the ClusterQueues with the
+// EarliestDeadlineFirst queueing strategy. Returns nil if the workload doesn't
+// have a deadline or the DeadlineAwareScheduling feature is disabled.
+func (o Ordering) GetLatestStartTime(w *kueue.Workload) *metav1.Time {
+	if !features.Enabled(features.DeadlineAwareScheduling) {
+		return nil
+	}
+	return LatestStartTime(w)
+}
+
+// LatestStartTime returns the completion deadline of the workload minus its
+// remaining maximum execution time, or nil if the workload has no deadline.
+func LatestStartTime(w *kueue.Workload) *metav1.Time {
+	if w.Spec.CompletionDeadline == nil {
+		return nil
+	}
+	latest := w.Spec.CompletionDeadline.Time
+	if w.Spec.MaximumExecutionTimeSeconds != nil {
+		remaining := *w.Spec.MaximumExecutionTimeSeconds - ptr.Deref(w.Status.AccumulatedPastExexcutionTimeSeconds, 0)
+		latest = latest.Add(-time.Duration(remaining) * time.Second)
+	}
+	return &metav1.Time{Time: latest}
+}
+
 // HasQuotaReservation checks if workload is admitted based on conditions
 func HasQuotaReservation(w *kueue.Workload) bool {
 	return apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadQuotaReserved)
@@ -798,6 +865,50 @@ func IsEvicted(w *kueue.Workload) bool {
 	return apimeta.IsStatusConditionPresentAndEqual(w.Status.Conditions, kueue.WorkloadEvicted, metav1.ConditionTrue)
 }
 
+// HasTopologyAssignmentsPending returns true if any of the PodSets of the
+// workload has the topology assignment delayed and not computed yet.
+func HasTopologyAssignmentsPending(w *kueue.Workload) bool {
+	if w.Status.Admission == nil {
+		return false
+	}
+	for _, psa := range w.Status.Admission.PodSetAssignments {
+		if psa.TopologyAssignment == nil &&
+			ptr.Deref(psa.DelayedTopologyRequest, "") == kueue.DelayedTopologyRequestStatePending {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllTopologyAssignments returns true if all the PodSets of the workload
+// which require Topology Aware Scheduling have the topology assignment
+// computed.
+func HasAllTopologyAssignments(w *kueue.Workload) bool {
+	for _, ps := range w.Spec.PodSets {
+		if ps.TopologyRequest == nil {
+			continue
+		}
+		if w.Status.Admission == nil {
+			return false
+		}
+		idx := slices.IndexFunc(w.Status.Admission.PodSetAssignments, func(psa kueue.PodSetAssignment) bool {
+			return psa.Name == ps.Name
+		})
+		if idx == -1 || w.Status.Admission.PodSetAssignments[idx].TopologyAssignment == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// NeedsSecondPass returns true if the workload has reserved quota and all
+// its AdmissionChecks are Ready, but the topology assignment is still
+// pending for some of its PodSets.
+func NeedsSecondPass(w *kueue.Workload) bool {
+	return !IsFinished(w) && !IsEvicted(w) && HasQuotaReservation(w) &&
+		HasAllChecksReady(w) && HasTopologyAssignmentsPending(w)
+}
+
 func RemoveFinalizer(ctx context.Context, c client.Client, wl *kueue.Workload) error {
 	if controllerutil.RemoveFinalizer(wl, kueue.ResourceInUseFinalizerName) {
 		return c.Update(ctx, wl)
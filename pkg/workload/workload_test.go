@@ -887,3 +887,64 @@ func TestAdmissionCheckStrategy(t *testing.T) {
 		})
 	}
 }
+
+func TestPropagateSchedulingAttempt(t *testing.T) {
+	attempt := &kueue.SchedulingAttempt{
+		ClusterQueue: "cq",
+		PodSets: []kueue.PodSetSchedulingAttempt{{
+			Name: kueue.DefaultPodSetName,
+			Flavors: []kueue.FlavorSchedulingAttempt{{
+				Name: "default",
+				Mode: kueue.FlavorSchedulingAttemptNoFit,
+				Resources: []kueue.ResourceSchedulingAttempt{{
+					Name:      corev1.ResourceCPU,
+					Requested: resource.MustParse("2"),
+					Available: resource.MustParse("1"),
+					Missing:   resource.MustParse("1"),
+				}},
+			}},
+		}},
+	}
+	cases := map[string]struct {
+		enableFeature bool
+		current       *kueue.SchedulingAttempt
+		attempt       *kueue.SchedulingAttempt
+		wantChanged   bool
+		wantAttempt   *kueue.SchedulingAttempt
+	}{
+		"feature disabled": {
+			attempt: attempt,
+		},
+		"new attempt": {
+			enableFeature: true,
+			attempt:       attempt,
+			wantChanged:   true,
+			wantAttempt:   attempt,
+		},
+		"same attempt": {
+			enableFeature: true,
+			current:       attempt.DeepCopy(),
+			attempt:       attempt,
+			wantAttempt:   attempt,
+		},
+		"attempt cleared": {
+			enableFeature: true,
+			current:       attempt.DeepCopy(),
+			wantChanged:   true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.WorkloadSchedulingAttemptDetails, tc.enableFeature)
+			wl := utiltesting.MakeWorkload("wl", "ns").Obj()
+			wl.Status.LastSchedulingAttempt = tc.current
+			gotChanged := PropagateSchedulingAttempt(wl, tc.attempt)
+			if gotChanged != tc.wantChanged {
+				t.Errorf("Unexpected changed, want=%v, got=%v", tc.wantChanged, gotChanged)
+			}
+			if diff := cmp.Diff(tc.wantAttempt, wl.Status.LastSchedulingAttempt); diff != "" {
+				t.Errorf("Unexpected LastSchedulingAttempt, (want-/got+):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+const (
+	namespace              = "default"
+	clusterQueuesPerCohort = 2
+)
+
+// BenchmarkSchedule measures the time the scheduler takes to admit the
+// pending workloads of many unrelated Cohorts, with the Cohort trees
+// scheduled sequentially or concurrently. Every workload fits the quota of
+// its ClusterQueue, so that the measure isn't affected by the requeuing.
+//
+// Run with:
+//
+//	go test ./test/performance/scheduler/benchmark/ -run=^$ -bench=Schedule -benchtime=3x
+func BenchmarkSchedule(b *testing.B) {
+	for _, size := range []struct {
+		cohorts        int
+		workloadsPerCQ int
+	}{
+		{cohorts: 100, workloadsPerCQ: 50},
+		{cohorts: 250, workloadsPerCQ: 40},
+	} {
+		total := size.cohorts * clusterQueuesPerCohort * size.workloadsPerCQ
+		for _, parallel := range []bool{false, true} {
+			b.Run(fmt.Sprintf("cohorts=%d/workloads=%d/parallel=%t", size.cohorts, total, parallel), func(b *testing.B) {
+				features.SetFeatureGateDuringTest(b, features.ParallelCohortScheduling, parallel)
+				for range b.N {
+					b.StopTimer()
+					env := newEnvironment(b, size.cohorts, size.workloadsPerCQ)
+					b.StartTimer()
+					env.run(b, total)
+				}
+				b.ReportMetric(float64(total*b.N)/b.Elapsed().Seconds(), "workloads/s")
+			})
+		}
+	}
+}
+
+type environment struct {
+	cache         *cache.Cache
+	queues        *queue.Manager
+	scheduler     *scheduler.Scheduler
+	clusterQueues []*kueue.ClusterQueue
+}
+
+// newEnvironment creates the given number of Cohorts, each with two
+// ClusterQueues holding the given number of pending workloads.
+func newEnvironment(b *testing.B, cohorts, workloadsPerCQ int) *environment {
+	ctx := ctrl.LoggerInto(context.Background(), logr.Discard())
+	cl := utiltesting.NewClientBuilder().
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}).
+		WithStatusSubresource(&kueue.Workload{}).
+		WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+		Build()
+	env := &environment{cache: cache.New(cl)}
+	env.queues = queue.NewManager(cl, env.cache)
+	env.cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+
+	quota := strconv.Itoa(workloadsPerCQ)
+	for c := range cohorts {
+		for q := range clusterQueuesPerCohort {
+			name := fmt.Sprintf("cohort-%d-cq-%d", c, q)
+			cq := utiltesting.MakeClusterQueue(name).
+				Cohort(fmt.Sprintf("cohort-%d", c)).
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, quota).Obj()).
+				Obj()
+			lq := utiltesting.MakeLocalQueue(name, namespace).ClusterQueue(name).Obj()
+			mustSucceed(b, env.cache.AddClusterQueue(ctx, cq))
+			mustSucceed(b, env.queues.AddClusterQueue(ctx, cq))
+			mustSucceed(b, env.cache.AddLocalQueue(lq))
+			mustSucceed(b, env.queues.AddLocalQueue(ctx, lq))
+			env.clusterQueues = append(env.clusterQueues, cq)
+		}
+	}
+	// The workloads are created after the queues, which would otherwise list
+	// them when added.
+	for _, cq := range env.clusterQueues {
+		for w := range workloadsPerCQ {
+			wl := utiltesting.MakeWorkload(fmt.Sprintf("%s-wl-%d", cq.Name, w), namespace).
+				Queue(cq.Name).
+				Request(corev1.ResourceCPU, "1").
+				Obj()
+			mustSucceed(b, cl.Create(ctx, wl))
+			mustSucceed(b, env.queues.AddOrUpdateWorkload(wl))
+		}
+	}
+	env.scheduler = scheduler.New(env.queues, env.cache, cl, &utiltesting.EventRecorder{})
+	return env
+}
+
+// run starts the scheduler and waits until the given number of workloads
+// reserved quota.
+func (env *environment) run(b *testing.B, workloads int) {
+	ctx := ctrl.LoggerInto(context.Background(), logr.Discard())
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+	go env.queues.CleanUpOnContext(ctx)
+	mustSucceed(b, env.scheduler.Start(ctx))
+
+	ticker := time.NewTicker(5 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			b.Fatalf("Timed out waiting for %d workloads to reserve quota", workloads)
+		case <-ticker.C:
+			if env.reservingWorkloads(b) >= workloads {
+				return
+			}
+		}
+	}
+}
+
+func (env *environment) reservingWorkloads(b *testing.B) int {
+	var count int
+	for _, cq := range env.clusterQueues {
+		stats, err := env.cache.Usage(cq)
+		mustSucceed(b, err)
+		count += stats.ReservingWorkloads
+	}
+	return count
+}
+
+func mustSucceed(b *testing.B, err error) {
+	b.Helper()
+	if err != nil {
+		b.Fatal(err)
+	}
+}
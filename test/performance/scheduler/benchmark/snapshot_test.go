@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+// BenchmarkSnapshot measures the time to take the snapshot of the cache at
+// the beginning of a scheduling cycle, when the workloads of a few of the
+// ClusterQueues changed since the previous cycle. The snapshot is either
+// built from scratch, or reuses the snapshots of the unchanged ClusterQueues.
+//
+// Run with:
+//
+//	go test ./test/performance/scheduler/benchmark/ -run=^$ -bench=Snapshot
+func BenchmarkSnapshot(b *testing.B) {
+	for _, size := range []struct {
+		clusterQueues  int
+		workloadsPerCQ int
+		changedCQs     int
+	}{
+		{clusterQueues: 1000, workloadsPerCQ: 100, changedCQs: 1},
+		{clusterQueues: 1000, workloadsPerCQ: 100, changedCQs: 50},
+		{clusterQueues: 1000, workloadsPerCQ: 100, changedCQs: 1000},
+	} {
+		for _, incremental := range []bool{false, true} {
+			b.Run(fmt.Sprintf("clusterQueues=%d/changed=%d/incremental=%t", size.clusterQueues, size.changedCQs, incremental), func(b *testing.B) {
+				features.SetFeatureGateDuringTest(b, features.IncrementalCacheSnapshots, incremental)
+				ctx := ctrl.LoggerInto(context.Background(), logr.Discard())
+				c, workloads := newAdmittedCache(b, size.clusterQueues, size.workloadsPerCQ)
+				b.ResetTimer()
+				for i := range b.N {
+					// Readmit one workload of each changed ClusterQueue, as if a
+					// workload finished and another one was admitted.
+					b.StopTimer()
+					for j := range size.changedCQs {
+						wl := workloads[(i*size.changedCQs+j)%len(workloads)]
+						mustSucceed(b, c.DeleteWorkload(wl))
+						c.AddOrUpdateWorkload(wl)
+					}
+					b.StartTimer()
+					_, err := c.Snapshot(ctx)
+					mustSucceed(b, err)
+				}
+			})
+		}
+	}
+}
+
+// newAdmittedCache returns a cache with the given number of ClusterQueues,
+// grouped in Cohorts, each with the given number of admitted workloads. It
+// also returns one of the workloads of each ClusterQueue.
+func newAdmittedCache(b *testing.B, clusterQueues, workloadsPerCQ int) (*cache.Cache, []*kueue.Workload) {
+	ctx := ctrl.LoggerInto(context.Background(), logr.Discard())
+	c := cache.New(utiltesting.NewFakeClient())
+	c.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	var workloads []*kueue.Workload
+	for q := range clusterQueues {
+		name := fmt.Sprintf("cq-%d", q)
+		cq := utiltesting.MakeClusterQueue(name).
+			Cohort(fmt.Sprintf("cohort-%d", q/clusterQueuesPerCohort)).
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1000").Obj()).
+			Obj()
+		mustSucceed(b, c.AddClusterQueue(ctx, cq))
+		for w := range workloadsPerCQ {
+			wl := utiltesting.MakeWorkload(fmt.Sprintf("%s-wl-%d", name, w), namespace).
+				Request(corev1.ResourceCPU, "1").
+				ReserveQuota(utiltesting.MakeAdmission(name).Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+				Obj()
+			c.AddOrUpdateWorkload(wl)
+			if w == 0 {
+				workloads = append(workloads, wl)
+			}
+		}
+	}
+	return c, workloads
+}
@@ -29,6 +29,7 @@ import (
 	"go.uber.org/zap/zapcore"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -52,6 +53,10 @@ var (
 	cpuprofile = flag.String("cpuprofile", "", "write cpu profile to `file`")
 
 	metricsPort = flag.Int("metricsPort", 0, "metrics serving port")
+
+	maxAdmissionsPerCycle = flag.Int("maxAdmissionsPerCycle", 1, "maximum number of workloads admitted from the same ClusterQueue in a scheduling cycle")
+
+	featureGates = flag.String("featureGates", "", "comma separated list of feature gates to set, e.g. ParallelCohortScheduling=true")
 )
 
 var (
@@ -83,6 +88,13 @@ func mainWithExitCode() int {
 	ctrl.SetLogger(log)
 	log.Info("Start")
 
+	if *featureGates != "" {
+		if err := utilfeature.DefaultMutableFeatureGate.Set(*featureGates); err != nil {
+			log.Error(err, "Unable to set the feature gates")
+			return 1
+		}
+	}
+
 	if *cpuprofile != "" {
 		f, err := os.Create(*cpuprofile)
 		if err != nil {
@@ -169,6 +181,7 @@ func mainWithExitCode() int {
 		cCache,
 		mgr.GetClient(),
 		mgr.GetEventRecorderFor(constants.AdmissionName),
+		scheduler.WithMaxAdmissionsPerCycle(int32(*maxAdmissionsPerCycle)),
 	)
 
 	if err := mgr.Add(sched); err != nil {
@@ -135,3 +135,24 @@ func TestLoadConfig(t *testing.T) {
 		t.Errorf("unexpected config(want-/ got+):\n%s", diff)
 	}
 }
+
+func TestLoadBundledConfigs(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "..", "*_generator_config.yaml"))
+	if err != nil {
+		t.Fatalf("unable to list the generator configs: %s", err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no generator config found")
+	}
+	for _, p := range paths {
+		t.Run(filepath.Base(p), func(t *testing.T) {
+			got, err := LoadConfig(p)
+			if err != nil {
+				t.Fatalf("unexpected load error: %s", err)
+			}
+			if len(got) == 0 {
+				t.Error("unexpected empty config")
+			}
+		})
+	}
+}
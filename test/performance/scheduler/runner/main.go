@@ -74,6 +74,9 @@ var (
 	withLogs         = flag.Bool("withLogs", false, "capture minimalkueue logs")
 	logLevel         = flag.Int("withLogsLevel", 2, "set minimalkueue logs level")
 	logToFile        = flag.Bool("logToFile", false, "capture minimalkueue logs to files")
+
+	maxAdmissionsPerCycle = flag.Int("maxAdmissionsPerCycle", 1, "maximum number of workloads admitted by minimalkueue from the same ClusterQueue in a scheduling cycle")
+	featureGates          = flag.String("featureGates", "", "comma separated list of feature gates set in minimalkueue")
 )
 
 var (
@@ -149,7 +152,7 @@ func main() {
 		}
 
 		// start the minimal kueue manager process
-		err = runCommand(ctx, *outputDir, *minimalKueuePath, "kubeconfig", *withCPUProfile, *withLogs, *logToFile, *logLevel, errCh, wg, metricsPort)
+		err = runCommand(ctx, *outputDir, *minimalKueuePath, "kubeconfig", *withCPUProfile, *withLogs, *logToFile, *logLevel, errCh, wg, metricsPort, *maxAdmissionsPerCycle, *featureGates)
 		if err != nil {
 			log.Error(err, "MinimalKueue start")
 			os.Exit(1)
@@ -244,7 +247,7 @@ func main() {
 	}
 }
 
-func runCommand(ctx context.Context, workDir, cmdPath, kubeconfig string, withCPUProf, withLogs, logToFile bool, logLevel int, errCh chan<- error, wg *sync.WaitGroup, metricsPort int) error {
+func runCommand(ctx context.Context, workDir, cmdPath, kubeconfig string, withCPUProf, withLogs, logToFile bool, logLevel int, errCh chan<- error, wg *sync.WaitGroup, metricsPort int, maxAdmissionsPerCycle int, featureGates string) error {
 	log := ctrl.LoggerFrom(ctx).WithName("Run command")
 
 	cmd := exec.CommandContext(ctx, cmdPath, "--kubeconfig", filepath.Join(workDir, kubeconfig))
@@ -285,6 +288,14 @@ func runCommand(ctx context.Context, workDir, cmdPath, kubeconfig string, withCP
 		cmd.Args = append(cmd.Args, "--metricsPort", strconv.Itoa(metricsPort))
 	}
 
+	if maxAdmissionsPerCycle > 1 {
+		cmd.Args = append(cmd.Args, "--maxAdmissionsPerCycle", strconv.Itoa(maxAdmissionsPerCycle))
+	}
+
+	if featureGates != "" {
+		cmd.Args = append(cmd.Args, "--featureGates", featureGates)
+	}
+
 	log.Info("Starting process", "path", cmd.Path, "args", cmd.Args)
 	err := cmd.Start()
 	if err != nil {